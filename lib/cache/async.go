@@ -0,0 +1,257 @@
+package cache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/eko/gocache/lib/v4/metrics"
+	"github.com/eko/gocache/lib/v4/store"
+)
+
+// ErrClosed is returned by caches when they are used after having been closed. It is
+// the same sentinel as store.ErrClosed, which the metrics recorder returns as well.
+var ErrClosed = store.ErrClosed
+
+// QueueStats represents the statistics of a queue of values applied in background. It
+// is the same type as metrics.QueueStats, so it can be recorded by metrics providers.
+type QueueStats = metrics.QueueStats
+
+// asyncSetterEntry is an item waiting to be applied, along with its position in the queue
+type asyncSetterEntry[I any] struct {
+	item     *I
+	sequence uint64
+}
+
+// asyncSetter applies items in background goroutines, in the order they have been
+// sent when using a single worker. It is bounded and handles the items sent while
+// it is full according to its overflow policy.
+type asyncSetter[I any] struct {
+	apply   func(item *I)
+	options *QueueOptions
+	mu      sync.Mutex
+	entries []asyncSetterEntry[I]
+	// running contains the sequences of the entries being applied
+	running  map[uint64]struct{}
+	sequence uint64
+	// changed is closed on the next change of the queue, if anyone is waiting for it
+	changed chan struct{}
+	stats   QueueStats
+	closed  bool
+	workers sync.WaitGroup
+	done    chan struct{}
+}
+
+func newAsyncSetter[I any](queueOptions *QueueOptions, apply func(item *I)) *asyncSetter[I] {
+	options := *queueOptions
+	if options.Size < 1 {
+		options.Size = 1
+	}
+	if options.Workers < 1 {
+		options.Workers = 1
+	}
+
+	setter := &asyncSetter[I]{
+		apply:   apply,
+		options: &options,
+		running: make(map[uint64]struct{}, options.Workers),
+		done:    make(chan struct{}),
+	}
+	setter.stats.Size = options.Size
+
+	setter.workers.Add(options.Workers)
+	for i := 0; i < options.Workers; i++ {
+		go setter.run()
+	}
+
+	go func() {
+		setter.workers.Wait()
+		close(setter.done)
+	}()
+
+	return setter
+}
+
+func (s *asyncSetter[I]) run() {
+	defer s.workers.Done()
+
+	s.mu.Lock()
+	for {
+		for len(s.entries) == 0 {
+			// Apply the entries sent before closing
+			if s.closed {
+				s.mu.Unlock()
+				return
+			}
+
+			s.wait()
+		}
+
+		entry := s.pop()
+		s.running[entry.sequence] = struct{}{}
+		s.notify()
+		s.mu.Unlock()
+
+		s.apply(entry.item)
+
+		s.mu.Lock()
+		delete(s.running, entry.sequence)
+		s.notify()
+	}
+}
+
+// wait releases the lock until the next change of the queue, the lock has to be held
+func (s *asyncSetter[I]) wait() {
+	changed := s.changes()
+	s.mu.Unlock()
+	<-changed
+	s.mu.Lock()
+}
+
+// changes returns a channel closed on the next change of the queue, the lock has to be held
+func (s *asyncSetter[I]) changes() <-chan struct{} {
+	if s.changed == nil {
+		s.changed = make(chan struct{})
+	}
+
+	return s.changed
+}
+
+// notify wakes up the goroutines waiting for a change of the queue, the lock has to be held
+func (s *asyncSetter[I]) notify() {
+	if s.changed != nil {
+		close(s.changed)
+		s.changed = nil
+	}
+}
+
+// pop removes the oldest entry of the queue, the lock has to be held
+func (s *asyncSetter[I]) pop() asyncSetterEntry[I] {
+	entry := s.entries[0]
+	s.entries[0] = asyncSetterEntry[I]{}
+	s.entries = s.entries[1:]
+
+	return entry
+}
+
+// send queues the given item to be applied, it is dropped once the setter is closed
+func (s *asyncSetter[I]) send(item *I) {
+	s.mu.Lock()
+
+	for !s.closed && len(s.entries) >= s.options.Size {
+		switch s.options.OverflowPolicy {
+		case OverflowDropNewest:
+			s.stats.Dropped++
+			s.mu.Unlock()
+			return
+
+		case OverflowDropOldest:
+			s.pop()
+			s.stats.Dropped++
+
+		case OverflowSync:
+			s.stats.Synchronous++
+			s.mu.Unlock()
+			s.apply(item)
+			return
+
+		default:
+			s.wait()
+		}
+	}
+
+	if s.closed {
+		s.stats.Dropped++
+		s.mu.Unlock()
+		return
+	}
+
+	s.sequence++
+	s.entries = append(s.entries, asyncSetterEntry[I]{item: item, sequence: s.sequence})
+	s.notify()
+	s.mu.Unlock()
+}
+
+// flush waits until all the items sent so far have been applied or dropped
+func (s *asyncSetter[I]) flush(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return ErrClosed
+	}
+
+	sequence := s.sequence
+
+	for !s.isApplied(sequence) {
+		changed := s.changes()
+		s.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		s.mu.Lock()
+	}
+	s.mu.Unlock()
+
+	return nil
+}
+
+// isApplied returns true once the items sent up to the given sequence are not
+// queued nor being applied anymore, the lock has to be held
+func (s *asyncSetter[I]) isApplied(sequence uint64) bool {
+	if len(s.entries) > 0 && s.entries[0].sequence <= sequence {
+		return false
+	}
+
+	for running := range s.running {
+		if running <= sequence {
+			return false
+		}
+	}
+
+	return true
+}
+
+// close applies the pending items and stops the background goroutines
+func (s *asyncSetter[I]) close(ctx context.Context) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return ErrClosed
+	}
+	s.closed = true
+	s.notify()
+	s.mu.Unlock()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// isClosed returns true once the setter has been closed
+func (s *asyncSetter[I]) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.closed
+}
+
+// getStats returns the current statistics of the queue
+func (s *asyncSetter[I]) getStats() QueueStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.stats
+	stats.Depth = len(s.entries)
+
+	return stats
+}
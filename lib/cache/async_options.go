@@ -0,0 +1,63 @@
+package cache
+
+// OverflowPolicy represents the way values are handled when they are sent to a full
+// background queue
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits for room in the queue
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropNewest drops the value being sent
+	OverflowDropNewest
+	// OverflowDropOldest drops the oldest value of the queue to make room for the new one
+	OverflowDropOldest
+	// OverflowSync applies the value synchronously, in the goroutine sending it
+	OverflowSync
+)
+
+const defaultQueueSize = 10000
+
+// QueueOption represents a background queue option function.
+type QueueOption func(o *QueueOptions)
+
+type QueueOptions struct {
+	Size           int
+	Workers        int
+	OverflowPolicy OverflowPolicy
+}
+
+func applyQueueOptions(opts ...QueueOption) *QueueOptions {
+	o := &QueueOptions{
+		Size:    defaultQueueSize,
+		Workers: 1,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithQueueSize allows to specify the number of values the queue can hold (10000 by default).
+func WithQueueSize(size int) QueueOption {
+	return func(o *QueueOptions) {
+		o.Size = size
+	}
+}
+
+// WithQueueWorkers allows to specify the number of goroutines applying the values of
+// the queue (1 by default). Values are not applied in order anymore when using several ones.
+func WithQueueWorkers(workers int) QueueOption {
+	return func(o *QueueOptions) {
+		o.Workers = workers
+	}
+}
+
+// WithQueueOverflowPolicy allows to specify the way values are handled when they are
+// sent while the queue is full (OverflowBlock by default).
+func WithQueueOverflowPolicy(policy OverflowPolicy) QueueOption {
+	return func(o *QueueOptions) {
+		o.OverflowPolicy = policy
+	}
+}
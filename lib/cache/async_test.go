@@ -0,0 +1,247 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingApplier records the applied items, waiting for release before applying each of them
+type blockingApplier struct {
+	mu      sync.Mutex
+	applied []int
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingApplier() *blockingApplier {
+	return &blockingApplier{
+		started: make(chan struct{}, 100),
+		release: make(chan struct{}),
+	}
+}
+
+func (a *blockingApplier) apply(item *int) {
+	a.started <- struct{}{}
+	<-a.release
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.applied = append(a.applied, *item)
+}
+
+func (a *blockingApplier) getApplied() []int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return append([]int{}, a.applied...)
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestAsyncSetterAppliesItemsInOrder(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	var applied []int
+	setter := newAsyncSetter(applyQueueOptions(), func(item *int) {
+		applied = append(applied, *item)
+	})
+
+	// When
+	for i := 0; i < 10; i++ {
+		setter.send(intPtr(i))
+	}
+	err := setter.flush(ctx)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, applied)
+	assert.Equal(t, QueueStats{Size: defaultQueueSize}, setter.getStats())
+}
+
+func TestAsyncSetterWithDropNewestOverflowPolicy(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	applier := newBlockingApplier()
+	setter := newAsyncSetter(applyQueueOptions(WithQueueSize(2), WithQueueOverflowPolicy(OverflowDropNewest)), applier.apply)
+
+	// The worker is busy with the first item
+	setter.send(intPtr(0))
+	<-applier.started
+
+	// When
+	for i := 1; i <= 4; i++ {
+		setter.send(intPtr(i))
+	}
+
+	// Then
+	assert.Equal(t, QueueStats{Depth: 2, Size: 2, Dropped: 2}, setter.getStats())
+
+	close(applier.release)
+	setter.flush(ctx)
+
+	assert.Equal(t, []int{0, 1, 2}, applier.getApplied())
+}
+
+func TestAsyncSetterWithDropOldestOverflowPolicy(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	applier := newBlockingApplier()
+	setter := newAsyncSetter(applyQueueOptions(WithQueueSize(2), WithQueueOverflowPolicy(OverflowDropOldest)), applier.apply)
+
+	setter.send(intPtr(0))
+	<-applier.started
+
+	// When
+	for i := 1; i <= 4; i++ {
+		setter.send(intPtr(i))
+	}
+
+	// Then
+	assert.Equal(t, QueueStats{Depth: 2, Size: 2, Dropped: 2}, setter.getStats())
+
+	close(applier.release)
+	setter.flush(ctx)
+
+	assert.Equal(t, []int{0, 3, 4}, applier.getApplied())
+}
+
+func TestAsyncSetterWithSyncOverflowPolicy(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	applier := newBlockingApplier()
+	setter := newAsyncSetter(applyQueueOptions(WithQueueSize(1), WithQueueOverflowPolicy(OverflowSync)), applier.apply)
+
+	setter.send(intPtr(0))
+	<-applier.started
+	setter.send(intPtr(1))
+
+	// When
+	sent := make(chan struct{})
+	go func() {
+		setter.send(intPtr(2))
+		close(sent)
+	}()
+
+	// Then the item is applied by the sending goroutine while the queue is still full
+	<-applier.started
+	assert.Equal(t, QueueStats{Depth: 1, Size: 1, Synchronous: 1}, setter.getStats())
+
+	close(applier.release)
+	<-sent
+	setter.flush(ctx)
+
+	assert.ElementsMatch(t, []int{0, 1, 2}, applier.getApplied())
+}
+
+func TestAsyncSetterWithBlockOverflowPolicy(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	applier := newBlockingApplier()
+	setter := newAsyncSetter(applyQueueOptions(WithQueueSize(1)), applier.apply)
+
+	setter.send(intPtr(0))
+	<-applier.started
+	setter.send(intPtr(1))
+
+	// When
+	sent := make(chan struct{})
+	go func() {
+		setter.send(intPtr(2))
+		close(sent)
+	}()
+
+	// Then the send waits for room in the queue
+	select {
+	case <-sent:
+		t.Fatal("send should be blocked while the queue is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(applier.release)
+	<-sent
+	setter.flush(ctx)
+
+	assert.Equal(t, []int{0, 1, 2}, applier.getApplied())
+}
+
+func TestAsyncSetterWithSeveralWorkers(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	applier := newBlockingApplier()
+	setter := newAsyncSetter(applyQueueOptions(WithQueueWorkers(3)), applier.apply)
+
+	// When
+	for i := 0; i < 3; i++ {
+		setter.send(intPtr(i))
+	}
+
+	// Then all the items are applied at the same time
+	for i := 0; i < 3; i++ {
+		<-applier.started
+	}
+
+	close(applier.release)
+	err := setter.flush(ctx)
+
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []int{0, 1, 2}, applier.getApplied())
+}
+
+func TestAsyncSetterFlushWhenContextIsDone(t *testing.T) {
+	// Given
+	applier := newBlockingApplier()
+	setter := newAsyncSetter(applyQueueOptions(), applier.apply)
+
+	setter.send(intPtr(0))
+	<-applier.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// When
+	err := setter.flush(ctx)
+
+	// Then
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	close(applier.release)
+}
+
+func TestAsyncSetterClose(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	var applied []int
+	setter := newAsyncSetter(applyQueueOptions(), func(item *int) {
+		applied = append(applied, *item)
+	})
+
+	for i := 0; i < 3; i++ {
+		setter.send(intPtr(i))
+	}
+
+	// When
+	err := setter.close(ctx)
+
+	// Then the pending items are applied and the next ones dropped
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 1, 2}, applied)
+
+	setter.send(intPtr(3))
+
+	assert.Equal(t, QueueStats{Size: defaultQueueSize, Dropped: 1}, setter.getStats())
+	assert.Equal(t, ErrClosed, setter.flush(ctx))
+	assert.Equal(t, ErrClosed, setter.close(ctx))
+}
@@ -18,13 +18,20 @@ const (
 
 // Cache represents the configuration needed by a cache
 type Cache[T any] struct {
-	codec codec.CodecInterface
+	codec   codec.CodecInterface
+	options *CacheOptions
 }
 
 // New instantiates a new cache entry
 func New[T any](store store.StoreInterface) *Cache[T] {
+	return NewWithOptions[T](store)
+}
+
+// NewWithOptions instantiates a new cache entry using the given options
+func NewWithOptions[T any](store store.StoreInterface, options ...CacheOption) *Cache[T] {
 	return &Cache[T]{
-		codec: codec.New(store),
+		codec:   codec.New(store),
+		options: applyCacheOptions(options...),
 	}
 }
 
@@ -37,6 +44,12 @@ func (c *Cache[T]) Get(ctx context.Context, key any) (T, error) {
 		return *new(T), err
 	}
 
+	if c.versionsTags() {
+		if value, err = c.unwrapValue(ctx, value); err != nil {
+			return *new(T), err
+		}
+	}
+
 	if v, ok := value.(T); ok {
 		return v, nil
 	}
@@ -53,6 +66,12 @@ func (c *Cache[T]) GetWithTTL(ctx context.Context, key any) (T, time.Duration, e
 		return *new(T), duration, err
 	}
 
+	if c.versionsTags() {
+		if value, err = c.unwrapValue(ctx, value); err != nil {
+			return *new(T), 0, err
+		}
+	}
+
 	if v, ok := value.(T); ok {
 		return v, duration, nil
 	}
@@ -63,17 +82,185 @@ func (c *Cache[T]) GetWithTTL(ctx context.Context, key any) (T, time.Duration, e
 // Set populates the cache item using the given key
 func (c *Cache[T]) Set(ctx context.Context, key any, object T, options ...store.Option) error {
 	cacheKey := c.getCacheKey(key)
+
+	if c.versionsTags() {
+		versions, options, err := c.prepareTagVersions(ctx, options)
+		if err != nil {
+			return err
+		}
+
+		return c.codec.Set(ctx, cacheKey, wrapTagVersions(object, versions), options...)
+	}
+
 	return c.codec.Set(ctx, cacheKey, object, options...)
 }
 
+// GetMany returns the objects stored in cache for the given keys, in a single round
+// trip when the store supports it. Keys that cannot be found are absent from the returned map.
+func (c *Cache[T]) GetMany(ctx context.Context, keys []any) (map[any]T, error) {
+	cacheKeys := make([]any, 0, len(keys))
+	keysByCacheKey := make(map[string][]any, len(keys))
+
+	for _, key := range keys {
+		cacheKey := c.getCacheKey(key)
+		if _, ok := keysByCacheKey[cacheKey]; !ok {
+			cacheKeys = append(cacheKeys, cacheKey)
+		}
+		keysByCacheKey[cacheKey] = append(keysByCacheKey[cacheKey], key)
+	}
+
+	values, err := c.codec.GetMany(ctx, cacheKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.versionsTags() {
+		if err := c.unwrapValues(ctx, values); err != nil {
+			return nil, err
+		}
+	}
+
+	objects := make(map[any]T, len(values))
+	for cacheKey, value := range values {
+		v, _ := value.(T)
+		for _, key := range keysByCacheKey[cacheKey.(string)] {
+			objects[key] = v
+		}
+	}
+
+	return objects, nil
+}
+
+// SetMany populates the cache items using the given keys, in a single round
+// trip when the store supports it
+func (c *Cache[T]) SetMany(ctx context.Context, items map[any]T, options ...store.Option) error {
+	var versions map[string]int64
+	if c.versionsTags() {
+		var err error
+		if versions, options, err = c.prepareTagVersions(ctx, options); err != nil {
+			return err
+		}
+	}
+
+	values := make(map[any]any, len(items))
+	for key, object := range items {
+		values[c.getCacheKey(key)] = wrapTagVersions(object, versions)
+	}
+
+	return c.codec.SetMany(ctx, values, options...)
+}
+
+// DeleteMany removes the cache items using the given keys, in a single round
+// trip when the store supports it
+func (c *Cache[T]) DeleteMany(ctx context.Context, keys []any) error {
+	cacheKeys := make([]any, 0, len(keys))
+	for _, key := range keys {
+		cacheKeys = append(cacheKeys, c.getCacheKey(key))
+	}
+
+	return c.codec.DeleteMany(ctx, cacheKeys)
+}
+
+// Increment atomically adds delta to the counter stored at the given key and returns
+// its new value. A *store.NotSupported error is returned if the store lacks this capability.
+func (c *Cache[T]) Increment(ctx context.Context, key any, delta int64) (int64, error) {
+	return store.Increment(ctx, c.codec.GetStore(), c.getCacheKey(key), delta)
+}
+
+// Decrement atomically subtracts delta from the counter stored at the given key and returns
+// its new value. A *store.NotSupported error is returned if the store lacks this capability.
+func (c *Cache[T]) Decrement(ctx context.Context, key any, delta int64) (int64, error) {
+	return store.Decrement(ctx, c.codec.GetStore(), c.getCacheKey(key), delta)
+}
+
+// SetIfNotExists populates the cache item only if the given key does not exist yet.
+// A *store.NotSupported error is returned if the store lacks this capability.
+func (c *Cache[T]) SetIfNotExists(ctx context.Context, key any, object T, options ...store.Option) (bool, error) {
+	cacheKey := c.getCacheKey(key)
+
+	if c.versionsTags() {
+		versions, options, err := c.prepareTagVersions(ctx, options)
+		if err != nil {
+			return false, err
+		}
+
+		return store.SetIfNotExists(ctx, c.codec.GetStore(), cacheKey, wrapTagVersions(object, versions), options...)
+	}
+
+	return store.SetIfNotExists(ctx, c.codec.GetStore(), cacheKey, object, options...)
+}
+
+// CompareAndSwap replaces the cache item only if its current value equals the old one.
+// A *store.NotSupported error is returned if the store lacks this capability.
+func (c *Cache[T]) CompareAndSwap(ctx context.Context, key any, oldObject T, newObject T, options ...store.Option) (bool, error) {
+	cacheKey := c.getCacheKey(key)
+
+	if c.versionsTags() {
+		return c.compareAndSwapVersioned(ctx, cacheKey, oldObject, newObject, options)
+	}
+
+	return store.CompareAndSwap(ctx, c.codec.GetStore(), cacheKey, oldObject, newObject, options...)
+}
+
+// CompareAndDelete removes the cache item only if its current value equals the given one.
+// A *store.NotSupported error is returned if the store lacks this capability.
+func (c *Cache[T]) CompareAndDelete(ctx context.Context, key any, oldObject T) (bool, error) {
+	cacheKey := c.getCacheKey(key)
+
+	if c.versionsTags() {
+		return c.compareAndDeleteVersioned(ctx, cacheKey, oldObject)
+	}
+
+	return store.CompareAndDelete(ctx, c.codec.GetStore(), cacheKey, oldObject)
+}
+
+// KeysForTag returns the cache keys associated to the given tag. A *store.NotSupported error
+// is returned if the store lacks this capability, or when using the TagInvalidationVersion
+// policy as values are then stored without their tags.
+func (c *Cache[T]) KeysForTag(ctx context.Context, tag string) ([]string, error) {
+	if c.versionsTags() {
+		return nil, store.NotSupportedOperation(CacheType, "KeysForTag")
+	}
+
+	return store.KeysForTag(ctx, c.codec.GetStore(), tag)
+}
+
+// TagsForKey returns the tags associated to the cache item using the given key, which are
+// the tags it has been written under when using the TagInvalidationVersion policy.
+// A *store.NotSupported error is returned if the store lacks this capability.
+func (c *Cache[T]) TagsForKey(ctx context.Context, key any) ([]string, error) {
+	cacheKey := c.getCacheKey(key)
+
+	if c.versionsTags() {
+		return c.getVersionedTags(ctx, cacheKey)
+	}
+
+	return store.TagsForKey(ctx, c.codec.GetStore(), cacheKey)
+}
+
 // Delete removes the cache item using the given key
 func (c *Cache[T]) Delete(ctx context.Context, key any) error {
-	cacheKey := c.getCacheKey(key)
-	return c.codec.Delete(ctx, cacheKey)
+	return c.codec.Delete(ctx, c.getCacheKey(key))
 }
 
-// Invalidate invalidates cache item from given options
+// Invalidate invalidates cache item from given options. Using the TagInvalidationVersion
+// policy, the version counters of the given tags are incremented instead of letting
+// the store delete the values associated to them, the keys given by prefix or pattern
+// being still invalidated by the store.
 func (c *Cache[T]) Invalidate(ctx context.Context, options ...store.InvalidateOption) error {
+	if c.versionsTags() {
+		opts := store.ApplyInvalidateOptions(options...)
+		if err := c.invalidateTagVersions(ctx, opts.Tags); err != nil {
+			return err
+		}
+
+		if !opts.TargetsKeys() {
+			return nil
+		}
+
+		options = append(append([]store.InvalidateOption{}, options...), store.WithInvalidateTags(nil))
+	}
+
 	return c.codec.Invalidate(ctx, options...)
 }
 
@@ -92,10 +279,15 @@ func (c *Cache[T]) GetType() string {
 	return CacheType
 }
 
+// getCacheKey returns the cache key for the given key object
+func (c *Cache[T]) getCacheKey(key any) string {
+	return getCacheKey(key)
+}
+
 // getCacheKey returns the cache key for the given key object by returning
 // the key if type is string or by computing a checksum of key structure
 // if its type is other than string
-func (c *Cache[T]) getCacheKey(key any) string {
+func getCacheKey(key any) string {
 	switch v := key.(type) {
 	case string:
 		return v
@@ -115,3 +307,57 @@ func checksum(object any) string {
 
 	return fmt.Sprintf("%x", hash)
 }
+
+// getMany returns the objects stored in the given cache for the given keys,
+// using its bulk capability when available or one Get call per key otherwise
+func getMany[T any](ctx context.Context, cache CacheInterface[T], keys []any) (map[any]T, error) {
+	if bulkCache, ok := cache.(BulkCacheInterface[T]); ok {
+		return bulkCache.GetMany(ctx, keys)
+	}
+
+	objects := make(map[any]T, len(keys))
+	for _, key := range keys {
+		object, err := cache.Get(ctx, key)
+		if err != nil {
+			continue
+		}
+
+		objects[key] = object
+	}
+
+	return objects, nil
+}
+
+// setMany populates the given cache items, using its bulk capability when
+// available or one Set call per item otherwise
+func setMany[T any](ctx context.Context, cache CacheInterface[T], items map[any]T, options ...store.Option) error {
+	if bulkCache, ok := cache.(BulkCacheInterface[T]); ok {
+		return bulkCache.SetMany(ctx, items, options...)
+	}
+
+	var firstErr error
+	for key, object := range items {
+		if err := cache.Set(ctx, key, object, options...); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// deleteMany removes the given cache items, using its bulk capability when
+// available or one Delete call per key otherwise
+func deleteMany[T any](ctx context.Context, cache CacheInterface[T], keys []any) error {
+	if bulkCache, ok := cache.(BulkCacheInterface[T]); ok {
+		return bulkCache.DeleteMany(ctx, keys)
+	}
+
+	var firstErr error
+	for _, key := range keys {
+		if err := cache.Delete(ctx, key); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
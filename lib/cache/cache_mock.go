@@ -132,6 +132,238 @@ func (mr *MockCacheInterfaceMockRecorder[T]) Set(ctx, key, object interface{}, o
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCacheInterface[T])(nil).Set), varargs...)
 }
 
+// MockBulkCacheInterface is a mock of BulkCacheInterface interface.
+type MockBulkCacheInterface[T any] struct {
+	ctrl     *gomock.Controller
+	recorder *MockBulkCacheInterfaceMockRecorder[T]
+}
+
+// MockBulkCacheInterfaceMockRecorder is the mock recorder for MockBulkCacheInterface.
+type MockBulkCacheInterfaceMockRecorder[T any] struct {
+	mock *MockBulkCacheInterface[T]
+}
+
+// NewMockBulkCacheInterface creates a new mock instance.
+func NewMockBulkCacheInterface[T any](ctrl *gomock.Controller) *MockBulkCacheInterface[T] {
+	mock := &MockBulkCacheInterface[T]{ctrl: ctrl}
+	mock.recorder = &MockBulkCacheInterfaceMockRecorder[T]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBulkCacheInterface[T]) EXPECT() *MockBulkCacheInterfaceMockRecorder[T] {
+	return m.recorder
+}
+
+// DeleteMany mocks base method.
+func (m *MockBulkCacheInterface[T]) DeleteMany(ctx context.Context, keys []any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMany", ctx, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMany indicates an expected call of DeleteMany.
+func (mr *MockBulkCacheInterfaceMockRecorder[T]) DeleteMany(ctx, keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockBulkCacheInterface[T])(nil).DeleteMany), ctx, keys)
+}
+
+// GetMany mocks base method.
+func (m *MockBulkCacheInterface[T]) GetMany(ctx context.Context, keys []any) (map[any]T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMany", ctx, keys)
+	ret0, _ := ret[0].(map[any]T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMany indicates an expected call of GetMany.
+func (mr *MockBulkCacheInterfaceMockRecorder[T]) GetMany(ctx, keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockBulkCacheInterface[T])(nil).GetMany), ctx, keys)
+}
+
+// SetMany mocks base method.
+func (m *MockBulkCacheInterface[T]) SetMany(ctx context.Context, items map[any]T, options ...store.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, items}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetMany", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMany indicates an expected call of SetMany.
+func (mr *MockBulkCacheInterfaceMockRecorder[T]) SetMany(ctx, items interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, items}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMany", reflect.TypeOf((*MockBulkCacheInterface[T])(nil).SetMany), varargs...)
+}
+
+// MockAtomicCacheInterface is a mock of AtomicCacheInterface interface.
+type MockAtomicCacheInterface[T any] struct {
+	ctrl     *gomock.Controller
+	recorder *MockAtomicCacheInterfaceMockRecorder[T]
+}
+
+// MockAtomicCacheInterfaceMockRecorder is the mock recorder for MockAtomicCacheInterface.
+type MockAtomicCacheInterfaceMockRecorder[T any] struct {
+	mock *MockAtomicCacheInterface[T]
+}
+
+// NewMockAtomicCacheInterface creates a new mock instance.
+func NewMockAtomicCacheInterface[T any](ctrl *gomock.Controller) *MockAtomicCacheInterface[T] {
+	mock := &MockAtomicCacheInterface[T]{ctrl: ctrl}
+	mock.recorder = &MockAtomicCacheInterfaceMockRecorder[T]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAtomicCacheInterface[T]) EXPECT() *MockAtomicCacheInterfaceMockRecorder[T] {
+	return m.recorder
+}
+
+// CompareAndDelete mocks base method.
+func (m *MockAtomicCacheInterface[T]) CompareAndDelete(ctx context.Context, key any, oldObject T) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareAndDelete", ctx, key, oldObject)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareAndDelete indicates an expected call of CompareAndDelete.
+func (mr *MockAtomicCacheInterfaceMockRecorder[T]) CompareAndDelete(ctx, key, oldObject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareAndDelete", reflect.TypeOf((*MockAtomicCacheInterface[T])(nil).CompareAndDelete), ctx, key, oldObject)
+}
+
+// CompareAndSwap mocks base method.
+func (m *MockAtomicCacheInterface[T]) CompareAndSwap(ctx context.Context, key any, oldObject, newObject T, options ...store.Option) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, key, oldObject, newObject}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CompareAndSwap", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareAndSwap indicates an expected call of CompareAndSwap.
+func (mr *MockAtomicCacheInterfaceMockRecorder[T]) CompareAndSwap(ctx, key, oldObject, newObject interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, key, oldObject, newObject}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareAndSwap", reflect.TypeOf((*MockAtomicCacheInterface[T])(nil).CompareAndSwap), varargs...)
+}
+
+// Decrement mocks base method.
+func (m *MockAtomicCacheInterface[T]) Decrement(ctx context.Context, key any, delta int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decrement", ctx, key, delta)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decrement indicates an expected call of Decrement.
+func (mr *MockAtomicCacheInterfaceMockRecorder[T]) Decrement(ctx, key, delta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrement", reflect.TypeOf((*MockAtomicCacheInterface[T])(nil).Decrement), ctx, key, delta)
+}
+
+// Increment mocks base method.
+func (m *MockAtomicCacheInterface[T]) Increment(ctx context.Context, key any, delta int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Increment", ctx, key, delta)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Increment indicates an expected call of Increment.
+func (mr *MockAtomicCacheInterfaceMockRecorder[T]) Increment(ctx, key, delta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Increment", reflect.TypeOf((*MockAtomicCacheInterface[T])(nil).Increment), ctx, key, delta)
+}
+
+// SetIfNotExists mocks base method.
+func (m *MockAtomicCacheInterface[T]) SetIfNotExists(ctx context.Context, key any, object T, options ...store.Option) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, key, object}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetIfNotExists", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetIfNotExists indicates an expected call of SetIfNotExists.
+func (mr *MockAtomicCacheInterfaceMockRecorder[T]) SetIfNotExists(ctx, key, object interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, key, object}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIfNotExists", reflect.TypeOf((*MockAtomicCacheInterface[T])(nil).SetIfNotExists), varargs...)
+}
+
+// MockTagIndexCacheInterface is a mock of TagIndexCacheInterface interface.
+type MockTagIndexCacheInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagIndexCacheInterfaceMockRecorder
+}
+
+// MockTagIndexCacheInterfaceMockRecorder is the mock recorder for MockTagIndexCacheInterface.
+type MockTagIndexCacheInterfaceMockRecorder struct {
+	mock *MockTagIndexCacheInterface
+}
+
+// NewMockTagIndexCacheInterface creates a new mock instance.
+func NewMockTagIndexCacheInterface(ctrl *gomock.Controller) *MockTagIndexCacheInterface {
+	mock := &MockTagIndexCacheInterface{ctrl: ctrl}
+	mock.recorder = &MockTagIndexCacheInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagIndexCacheInterface) EXPECT() *MockTagIndexCacheInterfaceMockRecorder {
+	return m.recorder
+}
+
+// KeysForTag mocks base method.
+func (m *MockTagIndexCacheInterface) KeysForTag(ctx context.Context, tag string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KeysForTag", ctx, tag)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KeysForTag indicates an expected call of KeysForTag.
+func (mr *MockTagIndexCacheInterfaceMockRecorder) KeysForTag(ctx, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeysForTag", reflect.TypeOf((*MockTagIndexCacheInterface)(nil).KeysForTag), ctx, tag)
+}
+
+// TagsForKey mocks base method.
+func (m *MockTagIndexCacheInterface) TagsForKey(ctx context.Context, key any) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagsForKey", ctx, key)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagsForKey indicates an expected call of TagsForKey.
+func (mr *MockTagIndexCacheInterfaceMockRecorder) TagsForKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagsForKey", reflect.TypeOf((*MockTagIndexCacheInterface)(nil).TagsForKey), ctx, key)
+}
+
 // MockCacheKeyGenerator is a mock of CacheKeyGenerator interface.
 type MockCacheKeyGenerator struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,48 @@
+package cache
+
+// TagInvalidationPolicy represents the way values are invalidated using their tags
+type TagInvalidationPolicy int
+
+const (
+	// TagInvalidationDelete lets the store delete the values associated to the
+	// invalidated tags, one by one
+	TagInvalidationDelete TagInvalidationPolicy = iota
+	// TagInvalidationVersion increments a version counter of the invalidated tags,
+	// the values written under a previous version of one of their tags being then
+	// considered as missing
+	TagInvalidationVersion
+)
+
+// CacheOption represents a cache option function.
+type CacheOption func(o *CacheOptions)
+
+type CacheOptions struct {
+	TagInvalidation TagInvalidationPolicy
+}
+
+func applyCacheOptions(opts ...CacheOption) *CacheOptions {
+	o := &CacheOptions{}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithTagInvalidation allows to specify the way Invalidate removes the values
+// associated to tags (TagInvalidationDelete by default).
+//
+// Using TagInvalidationVersion, each tag has a version counter kept in the store
+// and each value written with tags is stored in the same entry as the versions of its
+// tags: strings and bytes are prefixed by a header holding them, and other values are
+// wrapped in an envelope. Invalidating a tag is a single increment of its counter
+// whatever the number of values associated to it, in exchange for an additional round
+// trip by each Get of a value having tags to read the current versions of its tags,
+// and by each Set with tags to read them (creating the missing counters costs more).
+// The store needs to support atomic operations.
+func WithTagInvalidation(policy TagInvalidationPolicy) CacheOption {
+	return func(o *CacheOptions) {
+		o.TagInvalidation = policy
+	}
+}
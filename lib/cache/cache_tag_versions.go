@@ -0,0 +1,374 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eko/gocache/lib/v4/store"
+)
+
+const (
+	// TagVersionKeyPattern represents the key pattern used to keep the version counter
+	// of a tag when using the TagInvalidationVersion policy
+	TagVersionKeyPattern = "gocache_tag_version_%s"
+
+	// tagVersionsHeader starts the string and bytes values stored along with the versions
+	// of their tags, which are encoded in JSON up to the following line feed
+	tagVersionsHeader = "\x00gocache_tag_versions"
+)
+
+// ErrOutdatedTags is the cause of the not found errors returned for the values
+// written before one of their tags has been invalidated
+var ErrOutdatedTags = errors.New("value has been written before the invalidation of its tags")
+
+// versionedValue is the envelope a value written with tags is stored in when using the
+// TagInvalidationVersion policy, so the versions of its tags cannot be evicted apart from it.
+// Strings and bytes are stored as such instead, prefixed by a header holding the versions,
+// so stores only supporting these types can keep them.
+type versionedValue struct {
+	Value    any
+	Versions map[string]int64
+}
+
+func tagVersionKey(tag string) string {
+	return fmt.Sprintf(TagVersionKeyPattern, tag)
+}
+
+// versionsTags returns true when the tags are invalidated using version counters
+func (c *Cache[T]) versionsTags() bool {
+	return c.options.TagInvalidation == TagInvalidationVersion
+}
+
+// prepareTagVersions returns the current versions of the tags given in options, and the
+// options without these tags so the store does not index the values
+func (c *Cache[T]) prepareTagVersions(ctx context.Context, options []store.Option) (map[string]int64, []store.Option, error) {
+	var versions map[string]int64
+	if tags := store.ApplyOptions(options...).Tags; len(tags) > 0 {
+		var err error
+		if versions, err = c.getTagVersions(ctx, tags); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return versions, append(append([]store.Option{}, options...), store.WithTags(nil)), nil
+}
+
+// getTagVersions returns the current versions of the given tags, creating the
+// missing counters
+func (c *Cache[T]) getTagVersions(ctx context.Context, tags []string) (map[string]int64, error) {
+	versions, err := c.readTagVersions(ctx, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range tags {
+		if _, ok := versions[tag]; ok {
+			continue
+		}
+
+		// Counters start from the current time so a counter created again after having
+		// expired does not match the versions recorded before
+		version := time.Now().UnixNano()
+
+		set, err := store.SetIfNotExists(ctx, c.codec.GetStore(), tagVersionKey(tag), strconv.FormatInt(version, 10))
+		if err != nil {
+			return nil, err
+		}
+
+		if !set {
+			// The counter has been created meanwhile
+			value, err := c.codec.GetStore().Get(ctx, tagVersionKey(tag))
+			if err != nil {
+				return nil, err
+			}
+
+			if version, err = store.ToInt64(value); err != nil {
+				return nil, err
+			}
+		}
+
+		versions[tag] = version
+	}
+
+	return versions, nil
+}
+
+// readTagVersions returns the current versions of the given tags, the ones without
+// counter being absent from the returned map
+func (c *Cache[T]) readTagVersions(ctx context.Context, tags []string) (map[string]int64, error) {
+	keys := make([]any, 0, len(tags))
+	for _, tag := range tags {
+		keys = append(keys, tagVersionKey(tag))
+	}
+
+	values, err := store.GetMany(ctx, c.codec.GetStore(), keys)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string]int64, len(tags))
+	for _, tag := range tags {
+		value, ok := values[tagVersionKey(tag)]
+		if !ok {
+			continue
+		}
+
+		if version, err := store.ToInt64(value); err == nil {
+			versions[tag] = version
+		}
+	}
+
+	return versions, nil
+}
+
+// unwrapValue returns the given stored value without the versions of its tags, or a not
+// found error if it has been written under a previous version of one of them
+func (c *Cache[T]) unwrapValue(ctx context.Context, value any) (any, error) {
+	value, versions, ok := unwrapTagVersions(value)
+	if !ok {
+		return value, nil
+	}
+
+	current, err := c.readTagVersions(ctx, sortedTags(versions))
+	if err != nil {
+		return nil, err
+	}
+
+	if isOutdated(versions, current) {
+		return nil, store.NotFoundWithCause(ErrOutdatedTags)
+	}
+
+	return value, nil
+}
+
+// unwrapValues removes the versions of their tags from the given stored values, reading
+// the current versions of all the tags at once, and removes the values written under
+// a previous version of one of their tags. A tag whose counter does not exist anymore
+// is considered as invalidated.
+func (c *Cache[T]) unwrapValues(ctx context.Context, values map[any]any) error {
+	recorded := make(map[any]map[string]int64)
+
+	var tags []string
+	seen := make(map[string]struct{})
+
+	for key, value := range values {
+		value, versions, ok := unwrapTagVersions(value)
+		if !ok {
+			continue
+		}
+
+		values[key] = value
+		recorded[key] = versions
+		for tag := range versions {
+			if _, ok := seen[tag]; !ok {
+				seen[tag] = struct{}{}
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	if len(recorded) == 0 {
+		return nil
+	}
+
+	current, err := c.readTagVersions(ctx, tags)
+	if err != nil {
+		return err
+	}
+
+	for key, versions := range recorded {
+		if isOutdated(versions, current) {
+			delete(values, key)
+		}
+	}
+
+	return nil
+}
+
+// compareAndSwapVersioned replaces the stored value of the given cache key only if its
+// current value, without the versions of its tags, equals the old one. The stored value
+// is given as old value to the store so it is not replaced if written meanwhile.
+func (c *Cache[T]) compareAndSwapVersioned(ctx context.Context, cacheKey string, oldObject T, newObject T, options []store.Option) (bool, error) {
+	current, ok, err := c.getStoredValue(ctx, cacheKey, oldObject)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	versions, options, err := c.prepareTagVersions(ctx, options)
+	if err != nil {
+		return false, err
+	}
+
+	return store.CompareAndSwap(ctx, c.codec.GetStore(), cacheKey, current, wrapTagVersions(newObject, versions), options...)
+}
+
+// compareAndDeleteVersioned removes the given cache key only if its current value,
+// without the versions of its tags, equals the old one
+func (c *Cache[T]) compareAndDeleteVersioned(ctx context.Context, cacheKey string, oldObject T) (bool, error) {
+	current, ok, err := c.getStoredValue(ctx, cacheKey, oldObject)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	return store.CompareAndDelete(ctx, c.codec.GetStore(), cacheKey, current)
+}
+
+// getStoredValue returns the value stored for the given cache key as is, ok being false
+// when it is missing or does not equal the given object once its tag versions are removed
+func (c *Cache[T]) getStoredValue(ctx context.Context, cacheKey string, object T) (any, bool, error) {
+	current, err := c.codec.GetStore().Get(ctx, cacheKey)
+	if errors.Is(err, store.NotFound{}) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	value, _, _ := unwrapTagVersions(current)
+
+	return current, equalValues(value, object), nil
+}
+
+// getVersionedTags returns the tags the value of the given cache key has been written
+// under, sorted by name
+func (c *Cache[T]) getVersionedTags(ctx context.Context, cacheKey string) ([]string, error) {
+	value, err := c.codec.GetStore().Get(ctx, cacheKey)
+	if errors.Is(err, store.NotFound{}) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_, versions, _ := unwrapTagVersions(value)
+
+	return sortedTags(versions), nil
+}
+
+// invalidateTagVersions increments the version counter of the given tags
+func (c *Cache[T]) invalidateTagVersions(ctx context.Context, tags []string) error {
+	for _, tag := range tags {
+		if _, err := store.Increment(ctx, c.codec.GetStore(), tagVersionKey(tag), 1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// wrapTagVersions returns the value to store for the given value written under the
+// given tag versions, which is the value itself when written without tags
+func wrapTagVersions(value any, versions map[string]int64) any {
+	if len(versions) == 0 {
+		return value
+	}
+
+	// Encoding a map of integers cannot fail
+	data, _ := json.Marshal(versions)
+
+	switch v := value.(type) {
+	case string:
+		return tagVersionsHeader + string(data) + "\n" + v
+	case []byte:
+		header := make([]byte, 0, len(tagVersionsHeader)+len(data)+1+len(v))
+		header = append(header, tagVersionsHeader...)
+		header = append(header, data...)
+		header = append(header, '\n')
+		return append(header, v...)
+	}
+
+	return versionedValue{Value: value, Versions: versions}
+}
+
+// unwrapTagVersions returns the value and the tag versions stored in the given value,
+// ok being false when it has been written without tags. The versions are empty when
+// they cannot be decoded.
+func unwrapTagVersions(value any) (any, map[string]int64, bool) {
+	switch v := value.(type) {
+	case versionedValue:
+		return v.Value, v.Versions, true
+	case string:
+		if !strings.HasPrefix(v, tagVersionsHeader) {
+			return value, nil, false
+		}
+
+		end := strings.IndexByte(v, '\n')
+		if end < 0 {
+			return "", nil, true
+		}
+
+		return v[end+1:], decodeTagVersions(v[len(tagVersionsHeader):end]), true
+	case []byte:
+		if !bytes.HasPrefix(v, []byte(tagVersionsHeader)) {
+			return value, nil, false
+		}
+
+		end := bytes.IndexByte(v, '\n')
+		if end < 0 {
+			return []byte{}, nil, true
+		}
+
+		return v[end+1:], decodeTagVersions(string(v[len(tagVersionsHeader):end])), true
+	}
+
+	return value, nil, false
+}
+
+// decodeTagVersions returns the tag versions encoded in the given header data
+func decodeTagVersions(data string) map[string]int64 {
+	var versions map[string]int64
+	if err := json.Unmarshal([]byte(data), &versions); err != nil {
+		return nil
+	}
+
+	return versions
+}
+
+// sortedTags returns the tags of the given versions, sorted by name
+func sortedTags(versions map[string]int64) []string {
+	tags := make([]string, 0, len(versions))
+	for tag := range versions {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	return tags
+}
+
+// isOutdated returns true if a value written under the given tag versions has been
+// written under a previous version of one of its tags, given their current versions.
+// Versions which cannot be decoded are considered as outdated.
+func isOutdated(versions map[string]int64, current map[string]int64) bool {
+	if len(versions) == 0 {
+		return true
+	}
+
+	for tag, version := range versions {
+		if currentVersion, ok := current[tag]; !ok || currentVersion != version {
+			return true
+		}
+	}
+
+	return false
+}
+
+// equalValues returns true if the given stored value equals the given object, a string
+// being equal to the same bytes as stores may return strings for the bytes they are given
+func equalValues(value any, object any) bool {
+	if b, ok := value.([]byte); ok {
+		value = string(b)
+	}
+	if b, ok := object.([]byte); ok {
+		object = string(b)
+	}
+
+	return reflect.DeepEqual(value, object)
+}
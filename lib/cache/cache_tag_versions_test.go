@@ -0,0 +1,531 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/eko/gocache/lib/v4/store"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	store := store.NewMockStoreInterface(ctrl)
+
+	// When
+	cache := NewWithOptions[any](store, WithTagInvalidation(TagInvalidationVersion))
+
+	// Then
+	assert.Equal(t, store, cache.codec.GetStore())
+	assert.Equal(t, TagInvalidationVersion, cache.options.TagInvalidation)
+}
+
+func TestCacheSetWithTagVersions(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().Get(ctx, "gocache_tag_version_tag1").Return("5", nil)
+	mockedStore.EXPECT().Set(ctx, "my-key", "\x00gocache_tag_versions{\"tag1\":5}\nmy-value", store.OptionsMatcher{
+		Expiration: 5 * time.Second,
+	}).Return(nil)
+
+	cache := NewWithOptions[string](&atomicStore{mockedStore, store.NewMockAtomicStoreInterface(ctrl)}, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	err := cache.Set(ctx, "my-key", "my-value", store.WithExpiration(5*time.Second), store.WithTags([]string{"tag1"}))
+
+	// Then the value is stored along with the versions of its tags
+	assert.Nil(t, err)
+}
+
+func TestCacheSetWithTagVersionsWhenValueIsNotAString(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().Get(ctx, "gocache_tag_version_tag1").Return("5", nil)
+	mockedStore.EXPECT().Set(ctx, "my-key", versionedValue{
+		Value:    42,
+		Versions: map[string]int64{"tag1": 5},
+	}, store.OptionsMatcher{}).Return(nil)
+
+	cache := NewWithOptions[int](mockedStore, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	err := cache.Set(ctx, "my-key", 42, store.WithTags([]string{"tag1"}))
+
+	// Then the value is wrapped in an envelope
+	assert.Nil(t, err)
+}
+
+func TestCacheSetWithTagVersionsWhenCounterDoesNotExist(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	var version string
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().Get(ctx, "gocache_tag_version_tag1").Return(nil, store.NotFoundWithCause(errors.New("not found")))
+	mockedStore.EXPECT().Set(ctx, "my-key", gomock.Any(), store.OptionsMatcher{}).DoAndReturn(func(_ context.Context, _ any, value any, _ ...store.Option) error {
+		assert.Equal(t, "\x00gocache_tag_versions{\"tag1\":"+version+"}\nmy-value", value)
+		return nil
+	})
+
+	atomic := store.NewMockAtomicStoreInterface(ctrl)
+	atomic.EXPECT().SetIfNotExists(ctx, "gocache_tag_version_tag1", gomock.Any()).DoAndReturn(func(_ context.Context, _ any, value any, _ ...store.Option) (bool, error) {
+		version = value.(string)
+		return true, nil
+	})
+
+	cache := NewWithOptions[string](&atomicStore{mockedStore, atomic}, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	err := cache.Set(ctx, "my-key", "my-value", store.WithTags([]string{"tag1"}))
+
+	// Then the counter starts from the current time
+	assert.Nil(t, err)
+
+	initial, err := strconv.ParseInt(version, 10, 64)
+	assert.Nil(t, err)
+	assert.InDelta(t, time.Now().UnixNano(), initial, float64(time.Minute))
+}
+
+func TestCacheSetWithTagVersionsWhenNotSupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().Get(ctx, "gocache_tag_version_tag1").Return(nil, store.NotFoundWithCause(errors.New("not found")))
+	mockedStore.EXPECT().GetType().Return("mock")
+
+	cache := NewWithOptions[string](mockedStore, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	err := cache.Set(ctx, "my-key", "my-value", store.WithTags([]string{"tag1"}))
+
+	// Then the value is not written
+	assert.True(t, errors.Is(err, &store.NotSupported{}))
+}
+
+func TestCacheSetWithTagVersionsWhenNoTags(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().Set(ctx, "my-key", "my-value", store.OptionsMatcher{}).Return(nil)
+
+	cache := NewWithOptions[string](mockedStore, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	err := cache.Set(ctx, "my-key", "my-value")
+
+	// Then the value is stored as is
+	assert.Nil(t, err)
+}
+
+func TestCacheGetWithTagVersions(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().Get(ctx, "my-key").Return("\x00gocache_tag_versions{\"tag1\":5,\"tag2\":3}\nmy-value", nil)
+	mockedStore.EXPECT().Get(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, key any) (any, error) {
+		return map[any]any{
+			"gocache_tag_version_tag1": int64(5),
+			"gocache_tag_version_tag2": "3",
+		}[key], nil
+	}).Times(2)
+
+	cache := NewWithOptions[string](mockedStore, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestCacheGetWithTagVersionsWhenValueIsNotAString(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().Get(ctx, "my-key").Return(versionedValue{
+		Value:    42,
+		Versions: map[string]int64{"tag1": 5},
+	}, nil)
+	mockedStore.EXPECT().Get(ctx, "gocache_tag_version_tag1").Return(int64(5), nil)
+
+	cache := NewWithOptions[int](mockedStore, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 42, value)
+}
+
+func TestCacheGetWithTagVersionsWhenWrittenWithoutTags(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().Get(ctx, "my-key").Return([]byte("my-value"), nil)
+
+	cache := NewWithOptions[[]byte](mockedStore, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then no tag version is read
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("my-value"), value)
+}
+
+func TestCacheGetWithTagVersionsWhenVersionsCannotBeDecoded(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().Get(ctx, "my-key").Return([]byte("\x00gocache_tag_versions{\"tag1\"\nmy-value"), nil)
+
+	cache := NewWithOptions[[]byte](mockedStore, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	_, err := cache.Get(ctx, "my-key")
+
+	// Then the value is considered as written under outdated versions
+	assert.True(t, errors.Is(err, ErrOutdatedTags))
+}
+
+func TestCacheGetWithTagVersionsWhenTagHasBeenInvalidated(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().GetWithTTL(ctx, "my-key").Return("\x00gocache_tag_versions{\"tag1\":5}\nmy-value", time.Minute, nil)
+	mockedStore.EXPECT().Get(ctx, "gocache_tag_version_tag1").Return("6", nil)
+
+	cache := NewWithOptions[string](mockedStore, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	value, ttl, err := cache.GetWithTTL(ctx, "my-key")
+
+	// Then
+	assert.True(t, errors.Is(err, &store.NotFound{}))
+	assert.True(t, errors.Is(err, ErrOutdatedTags))
+	assert.Equal(t, "", value)
+	assert.Equal(t, time.Duration(0), ttl)
+}
+
+func TestCacheGetWithTagVersionsWhenCounterHasExpired(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().Get(ctx, "my-key").Return("\x00gocache_tag_versions{\"tag1\":5}\nmy-value", nil)
+	mockedStore.EXPECT().Get(ctx, "gocache_tag_version_tag1").Return(nil, store.NotFoundWithCause(errors.New("not found")))
+
+	cache := NewWithOptions[string](mockedStore, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	_, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.True(t, errors.Is(err, ErrOutdatedTags))
+}
+
+func TestCacheGetManyWithTagVersions(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	values := map[any]any{
+		"key1":                     "\x00gocache_tag_versions{\"tag1\":5}\nvalue1",
+		"key2":                     "\x00gocache_tag_versions{\"tag1\":4}\nvalue2",
+		"key3":                     "value3",
+		"gocache_tag_version_tag1": "5",
+	}
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().Get(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, key any) (any, error) {
+		if value, ok := values[key]; ok {
+			return value, nil
+		}
+		return nil, store.NotFoundWithCause(errors.New("not found"))
+	}).AnyTimes()
+
+	cache := NewWithOptions[string](mockedStore, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	objects, err := cache.GetMany(ctx, []any{"key1", "key2", "key3", "unknown"})
+
+	// Then the value written under a previous version of its tag is missing
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]string{"key1": "value1", "key3": "value3"}, objects)
+}
+
+func TestCacheInvalidateWithTagVersions(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	atomic := store.NewMockAtomicStoreInterface(ctrl)
+	atomic.EXPECT().Increment(ctx, "gocache_tag_version_tag1", int64(1)).Return(int64(6), nil)
+	atomic.EXPECT().Increment(ctx, "gocache_tag_version_tag2", int64(1)).Return(int64(1), nil)
+
+	cache := NewWithOptions[string](&atomicStore{store.NewMockStoreInterface(ctrl), atomic}, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	err := cache.Invalidate(ctx, store.WithInvalidateTags([]string{"tag1", "tag2"}))
+
+	// Then the store does not delete any value
+	assert.Nil(t, err)
+}
+
+func TestCacheInvalidateWithTagVersionsAndPrefix(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().Invalidate(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, options ...store.InvalidateOption) error {
+		opts := store.ApplyInvalidateOptions(options...)
+		assert.Empty(t, opts.Tags)
+		assert.Equal(t, "tenant:17:", opts.Prefix)
+		return nil
+	})
+
+	atomic := store.NewMockAtomicStoreInterface(ctrl)
+	atomic.EXPECT().Increment(ctx, "gocache_tag_version_tag1", int64(1)).Return(int64(6), nil)
+
+	cache := NewWithOptions[string](&atomicStore{store1, atomic}, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	err := cache.Invalidate(ctx, store.WithInvalidateTags([]string{"tag1"}), store.WithInvalidatePrefix("tenant:17:"))
+
+	// Then the store only deletes the keys having the prefix
+	assert.Nil(t, err)
+}
+
+func TestCacheInvalidateWithTagVersionsWhenError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	incrementErr := errors.New("unexpected error")
+
+	atomic := store.NewMockAtomicStoreInterface(ctrl)
+	atomic.EXPECT().Increment(ctx, "gocache_tag_version_tag1", int64(1)).Return(int64(0), incrementErr)
+
+	cache := NewWithOptions[string](&atomicStore{store.NewMockStoreInterface(ctrl), atomic}, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	err := cache.Invalidate(ctx, store.WithInvalidateTags([]string{"tag1", "tag2"}))
+
+	// Then
+	assert.Equal(t, incrementErr, err)
+}
+
+func TestCacheSetIfNotExistsWithTagVersions(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().Get(ctx, "gocache_tag_version_tag1").Return("2", nil)
+
+	atomic := store.NewMockAtomicStoreInterface(ctrl)
+	atomic.EXPECT().SetIfNotExists(ctx, "my-key", "\x00gocache_tag_versions{\"tag1\":2}\nmy-value", store.OptionsMatcher{}).Return(true, nil)
+	atomic.EXPECT().SetIfNotExists(ctx, "other-key", "my-value", store.OptionsMatcher{}).Return(false, nil)
+
+	cache := NewWithOptions[string](&atomicStore{mockedStore, atomic}, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	set1, err1 := cache.SetIfNotExists(ctx, "my-key", "my-value", store.WithTags([]string{"tag1"}))
+	set2, err2 := cache.SetIfNotExists(ctx, "other-key", "my-value")
+
+	// Then the value written without tags is stored as is
+	assert.Nil(t, err1)
+	assert.True(t, set1)
+	assert.Nil(t, err2)
+	assert.False(t, set2)
+}
+
+func TestCacheCompareAndSwapWithTagVersions(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().Get(ctx, "my-key").Return("\x00gocache_tag_versions{\"tag1\":2}\nold-value", nil)
+	mockedStore.EXPECT().Get(ctx, "gocache_tag_version_tag1").Return("3", nil)
+
+	atomic := store.NewMockAtomicStoreInterface(ctrl)
+	atomic.EXPECT().CompareAndSwap(ctx, "my-key",
+		"\x00gocache_tag_versions{\"tag1\":2}\nold-value",
+		"\x00gocache_tag_versions{\"tag1\":3}\nnew-value",
+		store.OptionsMatcher{},
+	).Return(true, nil)
+
+	cache := NewWithOptions[string](&atomicStore{mockedStore, atomic}, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	swapped, err := cache.CompareAndSwap(ctx, "my-key", "old-value", "new-value", store.WithTags([]string{"tag1"}))
+
+	// Then the stored value is compared without the versions of its tags
+	assert.Nil(t, err)
+	assert.True(t, swapped)
+}
+
+func TestCacheCompareAndSwapWithTagVersionsWhenValueDiffers(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().Get(ctx, "my-key").Return("\x00gocache_tag_versions{\"tag1\":2}\nother-value", nil)
+
+	cache := NewWithOptions[string](&atomicStore{mockedStore, store.NewMockAtomicStoreInterface(ctrl)}, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	swapped, err := cache.CompareAndSwap(ctx, "my-key", "old-value", "new-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, swapped)
+}
+
+func TestCacheCompareAndDeleteWithTagVersions(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().Get(ctx, "my-key").Return([]byte("\x00gocache_tag_versions{\"tag1\":2}\nold-value"), nil)
+
+	atomic := store.NewMockAtomicStoreInterface(ctrl)
+	atomic.EXPECT().CompareAndDelete(ctx, "my-key", []byte("\x00gocache_tag_versions{\"tag1\":2}\nold-value")).Return(true, nil)
+
+	cache := NewWithOptions[string](&atomicStore{mockedStore, atomic}, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	deleted, err := cache.CompareAndDelete(ctx, "my-key", "old-value")
+
+	// Then bytes equal to the old string are deleted
+	assert.Nil(t, err)
+	assert.True(t, deleted)
+}
+
+func TestCacheDeleteWithTagVersions(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().Delete(ctx, "my-key").Return(nil)
+
+	cache := NewWithOptions[string](mockedStore, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	err := cache.Delete(ctx, "my-key")
+
+	// Then the versions are deleted along with the value
+	assert.Nil(t, err)
+}
+
+func TestCacheTagsForKeyWithTagVersions(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().Get(ctx, "my-key").Return("\x00gocache_tag_versions{\"tag2\":1,\"tag1\":5}\nmy-value", nil)
+
+	cache := NewWithOptions[string](mockedStore, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	tags, err := cache.TagsForKey(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"tag1", "tag2"}, tags)
+}
+
+func TestCacheTagsForKeyWithTagVersionsWhenNotFound(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().Get(ctx, "my-key").Return(nil, store.NotFound{})
+
+	cache := NewWithOptions[string](mockedStore, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	tags, err := cache.TagsForKey(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Empty(t, tags)
+}
+
+func TestCacheKeysForTagWithTagVersions(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	tagIndex := store.NewMockTagIndexStoreInterface(ctrl)
+
+	cache := NewWithOptions[string](&tagIndexStore{store.NewMockStoreInterface(ctrl), tagIndex}, WithTagInvalidation(TagInvalidationVersion))
+
+	// When
+	keys, err := cache.KeysForTag(ctx, "tag1")
+
+	// Then values are stored without their tags
+	assert.True(t, errors.Is(err, &store.NotSupported{}))
+	assert.Nil(t, keys)
+}
@@ -379,3 +379,258 @@ func TestCacheDeleteWhenError(t *testing.T) {
 	// Then
 	assert.Equal(t, expectedErr, err)
 }
+
+func TestCacheGetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	key := struct{ ID int }{ID: 1}
+
+	store := store.NewMockStoreInterface(ctrl)
+	store.EXPECT().Get(ctx, "my-key").Return("value1", nil)
+	store.EXPECT().Get(ctx, checksum(key)).Return("value2", nil)
+	store.EXPECT().Get(ctx, "missing-key").Return(nil, errors.New("unable to find"))
+
+	cache := New[any](store)
+
+	// When
+	values, err := cache.GetMany(ctx, []any{"my-key", key, "missing-key"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"my-key": "value1", key: "value2"}, values)
+
+	assert.Equal(t, 2, cache.GetCodec().GetStats().Hits)
+	assert.Equal(t, 1, cache.GetCodec().GetStats().Miss)
+}
+
+func TestCacheSetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().Set(ctx, "key1", "value1", store.OptionsMatcher{
+		Expiration: 5 * time.Second,
+	}).Return(nil)
+	mockedStore.EXPECT().Set(ctx, "key2", "value2", store.OptionsMatcher{
+		Expiration: 5 * time.Second,
+	}).Return(nil)
+
+	cache := New[any](mockedStore)
+
+	// When
+	err := cache.SetMany(ctx, map[any]any{"key1": "value1", "key2": "value2"}, store.WithExpiration(5*time.Second))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestCacheDeleteMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := store.NewMockStoreInterface(ctrl)
+	store.EXPECT().Delete(ctx, "key1").Return(nil)
+	store.EXPECT().Delete(ctx, "key2").Return(nil)
+
+	cache := New[any](store)
+
+	// When
+	err := cache.DeleteMany(ctx, []any{"key1", "key2"})
+
+	// Then
+	assert.Nil(t, err)
+}
+
+type atomicStore struct {
+	*store.MockStoreInterface
+	*store.MockAtomicStoreInterface
+}
+
+func TestCacheIncrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	key := struct{ ID int }{ID: 1}
+
+	atomic := store.NewMockAtomicStoreInterface(ctrl)
+	atomic.EXPECT().Increment(ctx, checksum(key), int64(3)).Return(int64(10), nil)
+
+	cache := New[int64](&atomicStore{store.NewMockStoreInterface(ctrl), atomic})
+
+	// When
+	value, err := cache.Increment(ctx, key, 3)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), value)
+}
+
+func TestCacheIncrementWhenNotSupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().GetType().Return("mock")
+
+	cache := New[int64](mockedStore)
+
+	// When
+	value, err := cache.Increment(ctx, "my-counter", 3)
+
+	// Then
+	assert.True(t, errors.Is(err, &store.NotSupported{}))
+	assert.Equal(t, int64(0), value)
+}
+
+func TestCacheDecrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	atomic := store.NewMockAtomicStoreInterface(ctrl)
+	atomic.EXPECT().Decrement(ctx, "my-counter", int64(1)).Return(int64(4), nil)
+
+	cache := New[int64](&atomicStore{store.NewMockStoreInterface(ctrl), atomic})
+
+	// When
+	value, err := cache.Decrement(ctx, "my-counter", 1)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), value)
+}
+
+func TestCacheSetIfNotExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	atomic := store.NewMockAtomicStoreInterface(ctrl)
+	atomic.EXPECT().SetIfNotExists(ctx, "my-key", "my-value", store.OptionsMatcher{
+		Expiration: 5 * time.Second,
+	}).Return(true, nil)
+
+	cache := New[string](&atomicStore{store.NewMockStoreInterface(ctrl), atomic})
+
+	// When
+	set, err := cache.SetIfNotExists(ctx, "my-key", "my-value", store.WithExpiration(5*time.Second))
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, set)
+}
+
+func TestCacheCompareAndSwap(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	atomic := store.NewMockAtomicStoreInterface(ctrl)
+	atomic.EXPECT().CompareAndSwap(ctx, "my-key", "old-value", "new-value").Return(false, nil)
+
+	cache := New[string](&atomicStore{store.NewMockStoreInterface(ctrl), atomic})
+
+	// When
+	swapped, err := cache.CompareAndSwap(ctx, "my-key", "old-value", "new-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, swapped)
+}
+
+func TestCacheCompareAndDelete(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	atomic := store.NewMockAtomicStoreInterface(ctrl)
+	atomic.EXPECT().CompareAndDelete(ctx, "my-key", "old-value").Return(true, nil)
+
+	cache := New[string](&atomicStore{store.NewMockStoreInterface(ctrl), atomic})
+
+	// When
+	deleted, err := cache.CompareAndDelete(ctx, "my-key", "old-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, deleted)
+}
+
+type tagIndexStore struct {
+	*store.MockStoreInterface
+	*store.MockTagIndexStoreInterface
+}
+
+func TestCacheKeysForTag(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	tagIndex := store.NewMockTagIndexStoreInterface(ctrl)
+	tagIndex.EXPECT().KeysForTag(ctx, "tag1").Return([]string{"my-key", "my-other-key"}, nil)
+
+	cache := New[string](&tagIndexStore{store.NewMockStoreInterface(ctrl), tagIndex})
+
+	// When
+	keys, err := cache.KeysForTag(ctx, "tag1")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"my-key", "my-other-key"}, keys)
+}
+
+func TestCacheKeysForTagWhenNotSupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	mockedStore := store.NewMockStoreInterface(ctrl)
+	mockedStore.EXPECT().GetType().Return("mock")
+
+	cache := New[string](mockedStore)
+
+	// When
+	keys, err := cache.KeysForTag(ctx, "tag1")
+
+	// Then
+	assert.True(t, errors.Is(err, &store.NotSupported{}))
+	assert.Nil(t, keys)
+}
+
+func TestCacheTagsForKey(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	key := struct{ ID int }{ID: 1}
+
+	tagIndex := store.NewMockTagIndexStoreInterface(ctrl)
+	tagIndex.EXPECT().TagsForKey(ctx, checksum(key)).Return([]string{"tag1", "tag2"}, nil)
+
+	cache := New[string](&tagIndexStore{store.NewMockStoreInterface(ctrl), tagIndex})
+
+	// When
+	tags, err := cache.TagsForKey(ctx, key)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"tag1", "tag2"}, tags)
+}
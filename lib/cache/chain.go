@@ -2,8 +2,10 @@ package cache
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/eko/gocache/lib/v4/store"
@@ -12,111 +14,892 @@ import (
 const (
 	// ChainType represents the chain cache type as a string value
 	ChainType = "chain"
+	// ChainTagsKeyPattern represents the key pattern used to keep the tags of a value
+	// in each cache layer when tag propagation is enabled
+	ChainTagsKeyPattern = "gocache_tags_%s"
 )
 
+// ErrDuplicateLayerName is returned when the same name is given to several cache layers
+var ErrDuplicateLayerName = errors.New("the same name has been given to several cache layers")
+
+// chainTagsKey is the key used to keep the tags of a value
+type chainTagsKey struct {
+	key any
+}
+
+func (k chainTagsKey) GetCacheKey() string {
+	return fmt.Sprintf(ChainTagsKeyPattern, getCacheKey(k.key))
+}
+
 type chainKeyValue[T any] struct {
-	key       any
-	value     T
-	ttl       time.Duration
-	storeType *string
+	key      any
+	value    T
+	ttl      time.Duration
+	layer    int
+	items    map[any]T
+	sequence uint64
+	readAt   time.Time
+}
+
+// chainWrite represents a value, or several ones, written in the cache layers
+type chainWrite[T any] struct {
+	key     any
+	value   T
+	items   map[any]T
+	options []store.Option
+}
+
+// ChainLayerStats represents the statistics of a chain cache layer
+type ChainLayerStats struct {
+	Name     string
+	Hits     int
+	Miss     int
+	SetBack  int
+	SetError int
 }
 
 // ChainCache represents the configuration needed by a cache aggregator
 type ChainCache[T any] struct {
 	caches     []SetterCacheInterface[T]
-	setChannel chan *chainKeyValue[T]
+	setter     *asyncSetter[chainKeyValue[T]]
+	writer     *asyncSetter[chainWrite[T]]
+	options    *ChainOptions
+	stats      []ChainLayerStats
+	statsMtx   sync.Mutex
+	tombstones *chainTombstones
+	// repairMtx is held exclusively while setting values back and shared by removals
+	repairMtx sync.RWMutex
+	// names are resolved on first use, as it requires the store type of each layer
+	names     []string
+	namesOnce sync.Once
 }
 
 // NewChain instantiates a new cache aggregator
 func NewChain[T any](caches ...SetterCacheInterface[T]) *ChainCache[T] {
+	return newChain(caches, applyChainOptions())
+}
+
+// NewChainWithOptions instantiates a new cache aggregator using the given options.
+// It returns ErrDuplicateLayerName if the same name has been given to several cache layers.
+func NewChainWithOptions[T any](caches []SetterCacheInterface[T], options ...ChainOption) (*ChainCache[T], error) {
+	chainOptions := applyChainOptions(options...)
+
+	if err := checkLayerNames(chainOptions, len(caches)); err != nil {
+		return nil, err
+	}
+
+	return newChain(caches, chainOptions), nil
+}
+
+func newChain[T any](caches []SetterCacheInterface[T], options *ChainOptions) *ChainCache[T] {
 	chain := &ChainCache[T]{
 		caches:     caches,
-		setChannel: make(chan *chainKeyValue[T], 10000),
+		options:    options,
+		stats:      make([]ChainLayerStats, len(caches)),
+		tombstones: newChainTombstones(),
 	}
 
-	go chain.setter()
+	chain.setter = newAsyncSetter(chain.options.ReadRepairQueue, chain.setBack)
+
+	if chain.options.WritePolicy == WriteBehind {
+		chain.writer = newAsyncSetter(chain.options.WriteBehindQueue, func(item *chainWrite[T]) {
+			if len(chain.caches) > 1 {
+				chain.writeLayers(context.Background(), item, 1, len(chain.caches))
+			}
+		})
+	}
 
 	return chain
 }
 
-// setter sets a value in available caches, until a given cache layer
-func (c *ChainCache[T]) setter() {
-	for item := range c.setChannel {
-		for _, cache := range c.caches {
-			if item.storeType != nil && *item.storeType == cache.GetCodec().GetStore().GetType() {
-				break
+// setBack sets a value in the cache layers preceding the one it has been found in,
+// along with its tags when tag propagation is enabled.
+// Cache layers which do not take part in read-repair are skipped, as well as the
+// values removed from the cache since they have been read.
+func (c *ChainCache[T]) setBack(item *chainKeyValue[T]) {
+	ctx := context.Background()
+
+	if item.layer <= 0 || item.layer >= len(c.caches) {
+		return
+	}
+
+	var tags map[any][]string
+	if c.options.TagPropagation {
+		tags = c.getLayerTags(ctx, item.layer, item)
+	}
+
+	c.repairMtx.Lock()
+	defer c.repairMtx.Unlock()
+
+	if item.items != nil {
+		items := make(map[any]T, len(item.items))
+		for key, object := range item.items {
+			if !c.tombstones.isRemoved(key, item.sequence, item.readAt) {
+				items[key] = object
+			}
+		}
+
+		if len(items) == 0 {
+			return
+		}
+		item.items = items
+	} else if c.tombstones.isRemoved(item.key, item.sequence, item.readAt) {
+		return
+	}
+
+	for i := 0; i < item.layer; i++ {
+		if !c.options.layer(i).ReadRepair {
+			continue
+		}
+
+		var err error
+		if item.items != nil {
+			err = c.setBackItems(ctx, i, item.items, tags)
+		} else {
+			options := []store.Option{store.WithExpiration(item.ttl)}
+			if len(tags[item.key]) > 0 {
+				options = append(options, store.WithTags(tags[item.key]))
+			}
+			options = c.getLayerStoreOptions(i, options)
+
+			err = c.caches[i].Set(ctx, item.key, item.value, options...)
+			if err == nil {
+				c.setLayerTags(ctx, i, []any{item.key}, options)
+			}
+		}
+
+		c.statsMtx.Lock()
+		c.stats[i].SetBack++
+		if err != nil {
+			c.stats[i].SetError++
+		}
+		c.statsMtx.Unlock()
+	}
+}
+
+// setBackItems sets the given values back in the cache layer at the given position.
+// Values having tags are set one by one, the other ones at once.
+func (c *ChainCache[T]) setBackItems(ctx context.Context, i int, items map[any]T, tags map[any][]string) error {
+	var firstErr error
+
+	untaggedItems := make(map[any]T, len(items))
+	for key, object := range items {
+		if len(tags[key]) == 0 {
+			untaggedItems[key] = object
+			continue
+		}
+
+		options := c.getLayerStoreOptions(i, []store.Option{store.WithTags(tags[key])})
+		if err := c.caches[i].Set(ctx, key, object, options...); err != nil {
+			if firstErr == nil {
+				firstErr = err
 			}
+			continue
+		}
+
+		c.setLayerTags(ctx, i, []any{key}, options)
+	}
+
+	if len(untaggedItems) > 0 {
+		if err := setMany[T](ctx, c.caches[i], untaggedItems, c.getLayerStoreOptions(i, nil)...); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// getLayerTags returns the tags kept in the cache layer at the given position
+// for the keys of the given value
+func (c *ChainCache[T]) getLayerTags(ctx context.Context, i int, item *chainKeyValue[T]) map[any][]string {
+	codec := c.caches[i].GetCodec()
+	tags := make(map[any][]string)
 
-			cache.Set(context.Background(), item.key, item.value, store.WithExpiration(item.ttl))
+	if item.items == nil {
+		if value, err := codec.Get(ctx, chainTagsKey{item.key}.GetCacheKey()); err == nil {
+			tags[item.key] = decodeChainTags(value)
 		}
+
+		return tags
+	}
+
+	keys := make(map[any]any, len(item.items))
+	tagsKeys := make([]any, 0, len(item.items))
+	for key := range item.items {
+		tagsKey := chainTagsKey{key}.GetCacheKey()
+		keys[tagsKey] = key
+		tagsKeys = append(tagsKeys, tagsKey)
+	}
+
+	values, err := codec.GetMany(ctx, tagsKeys)
+	if err != nil {
+		return tags
+	}
+
+	for tagsKey, value := range values {
+		tags[keys[tagsKey]] = decodeChainTags(value)
+	}
+
+	return tags
+}
+
+// setLayerTags keeps the tags given in options for the given keys in the cache layer
+// at the given position, when tag propagation is enabled. They are stored with the same
+// options than the values so they expire and are invalidated along with them.
+func (c *ChainCache[T]) setLayerTags(ctx context.Context, i int, keys []any, options []store.Option) {
+	if !c.options.TagPropagation {
+		return
+	}
+
+	tags := store.ApplyOptions(options...).Tags
+	if len(tags) == 0 {
+		return
+	}
+
+	data, err := json.Marshal(tags)
+	if err != nil {
+		return
+	}
+
+	items := make(map[any]any, len(keys))
+	for _, key := range keys {
+		items[chainTagsKey{key}.GetCacheKey()] = data
+	}
+
+	c.caches[i].GetCodec().SetMany(ctx, items, options...)
+}
+
+// decodeChainTags returns the tags encoded in the given stored value
+func decodeChainTags(value any) []string {
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return nil
+	}
+
+	var tags []string
+	if err := json.Unmarshal(data, &tags); err != nil {
+		return nil
 	}
+
+	return tags
 }
 
 // Get returns the object stored in cache if it exists
 func (c *ChainCache[T]) Get(ctx context.Context, key any) (T, error) {
-	var object T
-	var err error
-	var ttl time.Duration
+	object, _, err := c.GetWithTTL(ctx, key)
+	return object, err
+}
+
+// GetWithTTL returns the object stored in the first cache having it and its corresponding TTL
+func (c *ChainCache[T]) GetWithTTL(ctx context.Context, key any) (T, time.Duration, error) {
+	if c.setter.isClosed() {
+		return *new(T), 0, ErrClosed
+	}
+
+	if len(c.caches) == 0 {
+		return *new(T), 0, nil
+	}
+
+	sequence, readAt := c.tombstones.current(), time.Now()
+
+	var result chainLayerResult[T]
+	switch c.options.ReadPolicy {
+	case ReadParallel:
+		result = c.getInParallel(ctx, key)
+	case ReadHedged:
+		result = c.getHedged(ctx, key)
+	default:
+		result = c.getSequentially(ctx, key)
+	}
+
+	if result.err != nil {
+		return result.object, result.ttl, result.err
+	}
+
+	// Set the value back until this cache layer, if it is worth it
+	if result.layer > 0 && c.options.PromotionPolicy.ShouldPromote(key, result.layer) {
+		c.setter.send(&chainKeyValue[T]{key: key, value: result.object, ttl: result.ttl, layer: result.layer, sequence: sequence, readAt: readAt})
+	}
+
+	return result.object, result.ttl, nil
+}
+
+// GetMany returns the objects stored in caches for the given keys. Each cache layer
+// is only asked for the keys that have not been found in the previous ones, and
+// found objects are set back in the previous layers.
+// Keys that cannot be found are absent from the returned map.
+func (c *ChainCache[T]) GetMany(ctx context.Context, keys []any) (map[any]T, error) {
+	if c.setter.isClosed() {
+		return nil, ErrClosed
+	}
+
+	objects := make(map[any]T, len(keys))
+	remainingKeys := keys
+
+	sequence, readAt := c.tombstones.current(), time.Now()
+
+	var errs []*ChainLayerError
+	for i, cache := range c.caches {
+		if len(remainingKeys) == 0 {
+			break
+		}
+
+		layerCtx, cancel := c.getLayerContext(ctx, i)
+		found, err := getMany[T](layerCtx, cache, remainingKeys)
+		cancel()
+		c.recordGet(i, len(found), len(remainingKeys)-len(found))
+		if err != nil {
+			errs = append(errs, c.newLayerError(i, ChainOperationGet, remainingKeys, err))
+			continue
+		}
+		if len(found) == 0 {
+			continue
+		}
+
+		missingKeys := make([]any, 0, len(remainingKeys))
+		for _, key := range remainingKeys {
+			object, ok := found[key]
+			if !ok {
+				missingKeys = append(missingKeys, key)
+				continue
+			}
+
+			objects[key] = object
+		}
+		remainingKeys = missingKeys
+
+		if i == 0 {
+			continue
+		}
 
-	for _, cache := range c.caches {
-		storeType := cache.GetCodec().GetStore().GetType()
-		object, ttl, err = cache.GetWithTTL(ctx, key)
-		if err == nil {
-			// Set the value back until this cache layer
-			c.setChannel <- &chainKeyValue[T]{key, object, ttl, &storeType}
-			return object, nil
+		// Set the values back until this cache layer, if they are worth it
+		promoted := make(map[any]T, len(found))
+		for key, object := range found {
+			if c.options.PromotionPolicy.ShouldPromote(key, i) {
+				promoted[key] = object
+			}
+		}
+
+		if len(promoted) > 0 {
+			c.setter.send(&chainKeyValue[T]{items: promoted, layer: i, sequence: sequence, readAt: readAt})
 		}
 	}
 
-	return object, err
+	if len(remainingKeys) > 0 && len(errs) > 0 {
+		return objects, &ChainError{Errors: errs}
+	}
+
+	return objects, nil
 }
 
-// Set sets a value in available caches
+// SetMany sets several values in available caches, according to the write policy
+func (c *ChainCache[T]) SetMany(ctx context.Context, items map[any]T, options ...store.Option) error {
+	if c.setter.isClosed() {
+		return ErrClosed
+	}
+
+	return c.write(ctx, &chainWrite[T]{items: items, options: options})
+}
+
+// DeleteMany removes several values from all available caches
+func (c *ChainCache[T]) DeleteMany(ctx context.Context, keys []any) error {
+	if c.setter.isClosed() {
+		return ErrClosed
+	}
+
+	c.startRemoval(ctx)
+	defer c.endRemoval(keys)
+
+	var tagsKeys []any
+	if c.options.TagPropagation {
+		tagsKeys = make([]any, 0, len(keys))
+		for _, key := range keys {
+			tagsKeys = append(tagsKeys, chainTagsKey{key}.GetCacheKey())
+		}
+	}
+
+	return c.applyLayers(ChainOperationDelete, keys, 0, len(c.caches), func(i int) error {
+		if err := deleteMany[T](ctx, c.caches[i], keys); err != nil {
+			return err
+		}
+
+		if tagsKeys != nil {
+			c.caches[i].GetCodec().DeleteMany(ctx, tagsKeys)
+		}
+
+		return nil
+	})
+}
+
+// Set sets a value in available caches, according to the write policy
 func (c *ChainCache[T]) Set(ctx context.Context, key any, object T, options ...store.Option) error {
-	errs := []error{}
-	for _, cache := range c.caches {
-		err := cache.Set(ctx, key, object, options...)
+	if c.setter.isClosed() {
+		return ErrClosed
+	}
+
+	return c.write(ctx, &chainWrite[T]{key: key, value: object, options: options})
+}
+
+// write writes the given value in the cache layers according to the write policy
+func (c *ChainCache[T]) write(ctx context.Context, item *chainWrite[T]) error {
+	if len(c.caches) == 0 {
+		return nil
+	}
+
+	switch c.options.WritePolicy {
+	case WriteThrough:
+		return c.writeLayersInParallel(ctx, item)
+
+	case WriteInvalidate:
+		last := len(c.caches) - 1
+		if err := c.writeLayer(ctx, last, item); err != nil {
+			return c.newChainError([]*ChainLayerError{c.newLayerError(last, ChainOperationSet, item.keys(), err)}, 0)
+		}
+
+		return c.invalidateLayers(ctx, item, last)
+
+	case WriteBehind:
+		if err := c.writeLayer(ctx, 0, item); err != nil {
+			return c.newChainError([]*ChainLayerError{c.newLayerError(0, ChainOperationSet, item.keys(), err)}, 0)
+		}
+
+		c.writer.send(item)
+
+		return nil
+	}
+
+	return c.writeLayers(ctx, item, 0, len(c.caches))
+}
+
+// keys returns the keys of the written values
+func (w *chainWrite[T]) keys() []any {
+	if w.items == nil {
+		return []any{w.key}
+	}
+
+	keys := make([]any, 0, len(w.items))
+	for key := range w.items {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// writeLayer writes the given value in the cache layer at the given position
+func (c *ChainCache[T]) writeLayer(ctx context.Context, i int, item *chainWrite[T]) error {
+	options := c.getLayerStoreOptions(i, item.options)
+
+	var err error
+	if item.items != nil {
+		err = setMany[T](ctx, c.caches[i], item.items, options...)
+	} else {
+		err = c.caches[i].Set(ctx, item.key, item.value, options...)
+	}
+
+	if err != nil {
+		c.statsMtx.Lock()
+		c.stats[i].SetError++
+		c.statsMtx.Unlock()
+
+		return err
+	}
+
+	c.setLayerTags(ctx, i, item.keys(), options)
+
+	return nil
+}
+
+// writeLayers writes the given value in the cache layers between the given
+// positions, one after another
+func (c *ChainCache[T]) writeLayers(ctx context.Context, item *chainWrite[T], from, to int) error {
+	return c.applyLayers(ChainOperationSet, item.keys(), from, to, func(i int) error {
+		return c.writeLayer(ctx, i, item)
+	})
+}
+
+// writeLayersInParallel writes the given value in all cache layers at the same time
+func (c *ChainCache[T]) writeLayersInParallel(ctx context.Context, item *chainWrite[T]) error {
+	layerErrs := make([]error, len(c.caches))
+
+	wg := &sync.WaitGroup{}
+	for i := range c.caches {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			layerErrs[i] = c.writeLayer(ctx, i, item)
+		}(i)
+	}
+	wg.Wait()
+
+	keys := item.keys()
+
+	var errs []*ChainLayerError
+	for i, err := range layerErrs {
 		if err != nil {
-			storeType := cache.GetCodec().GetStore().GetType()
-			errs = append(errs, fmt.Errorf("Unable to set item into cache with store '%s': %v", storeType, err))
+			errs = append(errs, c.newLayerError(i, ChainOperationSet, keys, err))
+		}
+	}
+
+	return c.newChainError(errs, len(c.caches)-len(errs))
+}
+
+// getLayerStoreOptions returns the store options to use when writing in the cache
+// layer at the given position, according to its TTL and tags configuration
+func (c *ChainCache[T]) getLayerStoreOptions(i int, options []store.Option) []store.Option {
+	layer, ok := c.options.Layers[i]
+	if !ok {
+		return options
+	}
+
+	applied := store.ApplyOptions(options...)
+	layerOptions := append([]store.Option{}, options...)
+
+	expiration := applied.Expiration
+	if expiration <= 0 {
+		expiration = layer.DefaultTTL
+	}
+	if layer.MaxTTL > 0 && (expiration <= 0 || expiration > layer.MaxTTL) {
+		expiration = layer.MaxTTL
+	}
+	if expiration != applied.Expiration {
+		layerOptions = append(layerOptions, store.WithExpiration(expiration))
+	}
+
+	if !layer.TagPassthrough && len(applied.Tags) > 0 {
+		layerOptions = append(layerOptions, store.WithTags(nil))
+	}
+
+	return layerOptions
+}
+
+// invalidateLayers removes the given value from the cache layers preceding the given position
+func (c *ChainCache[T]) invalidateLayers(ctx context.Context, item *chainWrite[T], to int) error {
+	keys := item.keys()
+
+	// The values read before are not set back, as for deletions
+	c.startRemoval(ctx)
+	defer c.endRemoval(keys)
+
+	var errs []*ChainLayerError
+	for i := 0; i < to; i++ {
+		if err := deleteMany[T](ctx, c.caches[i], keys); err != nil {
+			errs = append(errs, c.newLayerError(i, ChainOperationDelete, keys, err))
+			if c.options.ErrorPolicy == ErrorFailFast {
+				break
+			}
 		}
 	}
-	if len(errs) > 0 {
-		errStr := ""
-		for k, v := range errs {
-			errStr += fmt.Sprintf("error %d of %d: %v", k+1, len(errs), v.Error())
+
+	// The layer the value has been written in counts as a success
+	return c.newChainError(errs, to+1-len(errs))
+}
+
+// applyLayers applies an operation to the cache layers between the given positions,
+// one after another, and returns their errors according to the error policy
+func (c *ChainCache[T]) applyLayers(operation ChainOperation, keys []any, from, to int, apply func(i int) error) error {
+	var errs []*ChainLayerError
+	for i := from; i < to; i++ {
+		if err := apply(i); err != nil {
+			errs = append(errs, c.newLayerError(i, operation, keys, err))
+			if c.options.ErrorPolicy == ErrorFailFast {
+				break
+			}
 		}
-		return errors.New(errStr)
+	}
+
+	return c.newChainError(errs, to-from-len(errs))
+}
+
+// newLayerError returns the error of an operation which failed in the cache layer
+// at the given position
+func (c *ChainCache[T]) newLayerError(i int, operation ChainOperation, keys []any, err error) *ChainLayerError {
+	return &ChainLayerError{
+		Layer:     i,
+		Name:      c.getLayerName(i),
+		Operation: operation,
+		Keys:      keys,
+		Err:       err,
+	}
+}
+
+// newChainError returns an error listing the given errors of the cache layers,
+// or nil if there is none or enough cache layers succeeded
+func (c *ChainCache[T]) newChainError(errs []*ChainLayerError, successes int) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	if c.options.ErrorPolicy == ErrorRequireSuccesses && successes >= c.options.RequiredSuccesses {
+		return nil
+	}
+
+	return &ChainError{Errors: errs}
+}
+
+// recordGet updates the statistics of the cache layer at the given position
+func (c *ChainCache[T]) recordGet(i int, hits, miss int) {
+	c.statsMtx.Lock()
+	defer c.statsMtx.Unlock()
+
+	c.stats[i].Hits += hits
+	c.stats[i].Miss += miss
+}
+
+// checkLayerNames returns an error if the same name has been given to several cache
+// layers, as they could not be told apart in errors and statistics
+func checkLayerNames(options *ChainOptions, layers int) error {
+	names := make(map[string]int)
+	for i := 0; i < layers; i++ {
+		name := options.layer(i).Name
+		if name == "" {
+			continue
+		}
+
+		if other, ok := names[name]; ok {
+			return fmt.Errorf("%w: cache layers %d and %d are named %q", ErrDuplicateLayerName, other, i, name)
+		}
+		names[name] = i
 	}
 
 	return nil
 }
 
+// getLayerName returns the name of the cache layer at the given position
+func (c *ChainCache[T]) getLayerName(i int) string {
+	if name := c.options.layer(i).Name; name != "" {
+		return name
+	}
+
+	c.namesOnce.Do(c.resolveLayerNames)
+
+	return c.names[i]
+}
+
+// resolveLayerNames names the layers which have not been given a name after their
+// store type, suffixed with their position when it is shared with another layer
+func (c *ChainCache[T]) resolveLayerNames() {
+	names := make([]string, len(c.caches))
+	counts := make(map[string]int, len(c.caches))
+	for i := range c.caches {
+		if names[i] = c.options.layer(i).Name; names[i] == "" {
+			names[i] = c.caches[i].GetCodec().GetStore().GetType()
+		}
+		counts[names[i]]++
+	}
+
+	for i := range names {
+		if c.options.layer(i).Name == "" && counts[names[i]] > 1 {
+			names[i] = fmt.Sprintf("%s#%d", names[i], i)
+		}
+	}
+
+	c.names = names
+}
+
 // Delete removes a value from all available caches
 func (c *ChainCache[T]) Delete(ctx context.Context, key any) error {
-	for _, cache := range c.caches {
-		cache.Delete(ctx, key)
+	if c.setter.isClosed() {
+		return ErrClosed
 	}
 
-	return nil
+	c.startRemoval(ctx)
+	defer c.endRemoval([]any{key})
+
+	return c.applyLayers(ChainOperationDelete, []any{key}, 0, len(c.caches), func(i int) error {
+		if err := c.caches[i].Delete(ctx, key); err != nil {
+			return err
+		}
+
+		if c.options.TagPropagation {
+			c.caches[i].GetCodec().Delete(ctx, chainTagsKey{key}.GetCacheKey())
+		}
+
+		return nil
+	})
 }
 
 // Invalidate invalidates cache item from given options
 func (c *ChainCache[T]) Invalidate(ctx context.Context, options ...store.InvalidateOption) error {
-	for _, cache := range c.caches {
-		cache.Invalidate(ctx, options...)
+	if c.setter.isClosed() {
+		return ErrClosed
 	}
 
-	return nil
+	c.startRemoval(ctx)
+	defer c.endInvalidation()
+
+	return c.applyLayers(ChainOperationInvalidate, nil, 0, len(c.caches), func(i int) error {
+		return c.caches[i].Invalidate(ctx, options...)
+	})
+}
+
+// KeysForTag returns the keys associated to the given tag in the cache layers, without
+// duplicates and in the order of the layers. Layers lacking this capability are skipped,
+// and a *store.NotSupported error is returned when none of them has it.
+func (c *ChainCache[T]) KeysForTag(ctx context.Context, tag string) ([]string, error) {
+	return c.inspectTags(ctx, "KeysForTag", nil, func(ctx context.Context, cache TagIndexCacheInterface) ([]string, error) {
+		return cache.KeysForTag(ctx, tag)
+	})
+}
+
+// TagsForKey returns the tags associated to the given key in the cache layers, without
+// duplicates and in the order of the layers. Layers lacking this capability are skipped,
+// and a *store.NotSupported error is returned when none of them has it.
+func (c *ChainCache[T]) TagsForKey(ctx context.Context, key any) ([]string, error) {
+	return c.inspectTags(ctx, "TagsForKey", []any{key}, func(ctx context.Context, cache TagIndexCacheInterface) ([]string, error) {
+		return cache.TagsForKey(ctx, key)
+	})
+}
+
+// inspectTags merges the results of the given tag index operation in the cache layers.
+// The merged results are returned along with the errors of the failing layers, if any.
+func (c *ChainCache[T]) inspectTags(ctx context.Context, operation string, keys []any, inspect func(ctx context.Context, cache TagIndexCacheInterface) ([]string, error)) ([]string, error) {
+	if c.setter.isClosed() {
+		return nil, ErrClosed
+	}
+
+	var results []string
+	seen := make(map[string]struct{})
+
+	supported := 0
+	var errs []*ChainLayerError
+	for i, layer := range c.caches {
+		cache, ok := layer.(TagIndexCacheInterface)
+		if !ok {
+			continue
+		}
+
+		layerCtx, cancel := c.getLayerContext(ctx, i)
+		values, err := inspect(layerCtx, cache)
+		cancel()
+		if errors.Is(err, &store.NotSupported{}) {
+			continue
+		}
+
+		supported++
+		if err != nil {
+			errs = append(errs, c.newLayerError(i, ChainOperationInspectTags, keys, err))
+			continue
+		}
+
+		for _, value := range values {
+			if _, ok := seen[value]; !ok {
+				seen[value] = struct{}{}
+				results = append(results, value)
+			}
+		}
+	}
+
+	if supported == 0 {
+		return nil, store.NotSupportedOperation(ChainType, operation)
+	}
+
+	return results, c.newChainError(errs, supported-len(errs))
 }
 
 // Clear resets all cache data
 func (c *ChainCache[T]) Clear(ctx context.Context) error {
-	for _, cache := range c.caches {
-		cache.Clear(ctx)
+	if c.setter.isClosed() {
+		return ErrClosed
 	}
 
-	return nil
+	c.startRemoval(ctx)
+	defer c.endInvalidation()
+
+	return c.applyLayers(ChainOperationClear, nil, 0, len(c.caches), func(i int) error {
+		return c.caches[i].Clear(ctx)
+	})
+}
+
+// startRemoval waits for the values being set back and the values written behind,
+// so they cannot be set after the removal
+func (c *ChainCache[T]) startRemoval(ctx context.Context) {
+	if c.writer != nil {
+		c.writer.flush(ctx)
+	}
+
+	c.repairMtx.RLock()
+}
+
+// endRemoval records the removal of the given keys, so the values read before
+// are not set back
+func (c *ChainCache[T]) endRemoval(keys []any) {
+	c.tombstones.add(keys)
+	c.repairMtx.RUnlock()
+}
+
+// endInvalidation records the removal of all keys, so none of the values read
+// before is set back
+func (c *ChainCache[T]) endInvalidation() {
+	c.tombstones.addAll()
+	c.repairMtx.RUnlock()
+}
+
+// Flush waits until the values found in a cache layer have been set back
+// in the previous ones and the values written behind have been applied
+func (c *ChainCache[T]) Flush(ctx context.Context) error {
+	if c.writer != nil {
+		if err := c.writer.flush(ctx); err != nil {
+			return err
+		}
+	}
+
+	return c.setter.flush(ctx)
+}
+
+// Close applies the pending writes and set backs and stops the background
+// goroutines. Calls made after closing return ErrClosed.
+func (c *ChainCache[T]) Close(ctx context.Context) error {
+	if c.writer != nil {
+		if err := c.writer.close(ctx); err != nil {
+			return err
+		}
+	}
+
+	return c.setter.close(ctx)
+}
+
+// GetLayerNames returns the names of the cache layers, in order
+func (c *ChainCache[T]) GetLayerNames() []string {
+	names := make([]string, 0, len(c.caches))
+	for i := range c.caches {
+		names = append(names, c.getLayerName(i))
+	}
+
+	return names
+}
+
+// GetReadRepairQueueStats returns some statistics about the queue of values
+// waiting to be set back in the previous cache layers
+func (c *ChainCache[T]) GetReadRepairQueueStats() QueueStats {
+	return c.setter.getStats()
+}
+
+// GetWriteBehindQueueStats returns some statistics about the queue of values
+// waiting to be written in the lower cache layers, when using the WriteBehind policy
+func (c *ChainCache[T]) GetWriteBehindQueueStats() QueueStats {
+	if c.writer == nil {
+		return QueueStats{}
+	}
+
+	return c.writer.getStats()
+}
+
+// GetLayerStats returns some statistics about each cache layer, in order
+func (c *ChainCache[T]) GetLayerStats() []ChainLayerStats {
+	c.statsMtx.Lock()
+	stats := append([]ChainLayerStats{}, c.stats...)
+	c.statsMtx.Unlock()
+
+	for i := range stats {
+		stats[i].Name = c.getLayerName(i)
+	}
+
+	return stats
 }
 
 // GetCaches returns all Chained caches
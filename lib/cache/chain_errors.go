@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ChainOperation represents an operation applied to the cache layers of a chain cache
+type ChainOperation string
+
+const (
+	ChainOperationGet        ChainOperation = "get"
+	ChainOperationSet        ChainOperation = "set"
+	ChainOperationDelete     ChainOperation = "delete"
+	ChainOperationInvalidate ChainOperation = "invalidate"
+	ChainOperationClear      ChainOperation = "clear"
+	// ChainOperationInspectTags is used when listing the keys of a tag or the tags of a key
+	ChainOperationInspectTags ChainOperation = "inspect tags"
+)
+
+// ChainLayerError represents the error returned by a cache layer of a chain cache
+type ChainLayerError struct {
+	// Layer is the position of the cache layer in the chain
+	Layer     int
+	Name      string
+	Operation ChainOperation
+	// Keys are the keys the operation has been applied to, if any
+	Keys []any
+	Err  error
+}
+
+func (e *ChainLayerError) Error() string {
+	items := "item"
+	if len(e.Keys) > 1 {
+		items = "items"
+	}
+
+	switch e.Operation {
+	case ChainOperationSet:
+		return fmt.Sprintf("Unable to set %s into cache with store '%s': %v", items, e.Name, e.Err)
+	case ChainOperationInvalidate, ChainOperationClear:
+		return fmt.Sprintf("Unable to %s cache with store '%s': %v", e.Operation, e.Name, e.Err)
+	case ChainOperationInspectTags:
+		return fmt.Sprintf("Unable to inspect tags of cache with store '%s': %v", e.Name, e.Err)
+	}
+
+	return fmt.Sprintf("Unable to %s %s from cache with store '%s': %v", e.Operation, items, e.Name, e.Err)
+}
+
+func (e *ChainLayerError) Unwrap() error { return e.Err }
+
+// ChainError is returned by a chain cache when some of its cache layers failed,
+// it lists their errors ordered by layer
+type ChainError struct {
+	Errors []*ChainLayerError
+}
+
+func (e *ChainError) Error() string {
+	errStrs := make([]string, 0, len(e.Errors))
+	for k, v := range e.Errors {
+		errStrs = append(errStrs, fmt.Sprintf("error %d of %d: %v", k+1, len(e.Errors), v.Error()))
+	}
+
+	return strings.Join(errStrs, "; ")
+}
+
+// Is returns true if the error of any of the cache layers matches the given one
+func (e *ChainError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first error of the cache layers matching the given target
+func (e *ChainError) As(target any) bool {
+	for _, err := range e.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
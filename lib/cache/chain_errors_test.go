@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eko/gocache/lib/v4/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainLayerErrorError(t *testing.T) {
+	testCases := []struct {
+		err      *ChainLayerError
+		expected string
+	}{
+		{
+			err:      &ChainLayerError{Name: "redis", Operation: ChainOperationSet, Keys: []any{"my-key"}, Err: errors.New("timeout")},
+			expected: "Unable to set item into cache with store 'redis': timeout",
+		},
+		{
+			err:      &ChainLayerError{Name: "redis", Operation: ChainOperationSet, Keys: []any{"key-1", "key-2"}, Err: errors.New("timeout")},
+			expected: "Unable to set items into cache with store 'redis': timeout",
+		},
+		{
+			err:      &ChainLayerError{Name: "redis", Operation: ChainOperationGet, Keys: []any{"my-key"}, Err: errors.New("timeout")},
+			expected: "Unable to get item from cache with store 'redis': timeout",
+		},
+		{
+			err:      &ChainLayerError{Name: "redis", Operation: ChainOperationDelete, Keys: []any{"key-1", "key-2"}, Err: errors.New("timeout")},
+			expected: "Unable to delete items from cache with store 'redis': timeout",
+		},
+		{
+			err:      &ChainLayerError{Name: "redis", Operation: ChainOperationClear, Err: errors.New("timeout")},
+			expected: "Unable to clear cache with store 'redis': timeout",
+		},
+		{
+			err:      &ChainLayerError{Name: "redis", Operation: ChainOperationInspectTags, Keys: []any{"my-key"}, Err: errors.New("timeout")},
+			expected: "Unable to inspect tags of cache with store 'redis': timeout",
+		},
+	}
+
+	for _, tc := range testCases {
+		// When - Then
+		assert.Equal(t, tc.expected, tc.err.Error())
+	}
+}
+
+func TestChainErrorError(t *testing.T) {
+	// Given
+	err := &ChainError{Errors: []*ChainLayerError{
+		{Layer: 0, Name: "ristretto", Operation: ChainOperationDelete, Keys: []any{"my-key"}, Err: errors.New("first")},
+		{Layer: 1, Name: "redis", Operation: ChainOperationDelete, Keys: []any{"my-key"}, Err: errors.New("second")},
+	}}
+
+	// When - Then
+	assert.Equal(t, "error 1 of 2: Unable to delete item from cache with store 'ristretto': first; "+
+		"error 2 of 2: Unable to delete item from cache with store 'redis': second", err.Error())
+}
+
+func TestChainErrorIsAndAs(t *testing.T) {
+	// Given
+	cause := errors.New("connection refused")
+
+	var err error = &ChainError{Errors: []*ChainLayerError{
+		{Layer: 0, Name: "ristretto", Operation: ChainOperationGet, Err: store.NotFoundWithCause(errors.New("missing"))},
+		{Layer: 1, Name: "redis", Operation: ChainOperationGet, Err: cause},
+	}}
+
+	// When - Then
+	assert.True(t, errors.Is(err, cause))
+	assert.True(t, errors.Is(err, &store.NotFound{}))
+	assert.False(t, errors.Is(err, errors.New("another error")))
+
+	var layerErr *ChainLayerError
+	assert.True(t, errors.As(err, &layerErr))
+	assert.Equal(t, "ristretto", layerErr.Name)
+
+	var notFound *store.NotFound
+	assert.True(t, errors.As(err, &notFound))
+}
@@ -0,0 +1,267 @@
+package cache
+
+import "time"
+
+// WritePolicy represents the way values are written in the chain cache layers
+type WritePolicy int
+
+const (
+	// WriteSequential writes values in all cache layers, one after another
+	WriteSequential WritePolicy = iota
+	// WriteThrough writes values in all cache layers in parallel
+	WriteThrough
+	// WriteInvalidate writes values in the last (authoritative) cache layer only
+	// and removes them from the upper ones
+	WriteInvalidate
+	// WriteBehind writes values in the first cache layer and asynchronously in
+	// the other ones, through a bounded queue
+	WriteBehind
+)
+
+// ReadPolicy represents the way values are read from the chain cache layers
+type ReadPolicy int
+
+const (
+	// ReadSequential queries the cache layers one after another
+	ReadSequential ReadPolicy = iota
+	// ReadHedged queries the cache layers one after another but also queries the
+	// next one when the previous ones have not answered after the hedge delay
+	ReadHedged
+	// ReadParallel queries all the cache layers at the same time and returns the
+	// value of the first one having it
+	ReadParallel
+)
+
+// ErrorPolicy represents the way errors returned by the chain cache layers are
+// handled by write and removal operations
+type ErrorPolicy int
+
+const (
+	// ErrorBestEffort applies operations to all cache layers and returns an error
+	// if any of them failed
+	ErrorBestEffort ErrorPolicy = iota
+	// ErrorFailFast stops applying operations at the first cache layer failing
+	// and returns its error
+	ErrorFailFast
+	// ErrorRequireSuccesses applies operations to all cache layers and returns an
+	// error only when less than the required number of them succeeded
+	ErrorRequireSuccesses
+)
+
+// ChainOption represents a chain cache option function.
+type ChainOption func(o *ChainOptions)
+
+type ChainOptions struct {
+	WritePolicy       WritePolicy
+	WriteBehindQueue  *QueueOptions
+	ReadRepairQueue   *QueueOptions
+	ReadPolicy        ReadPolicy
+	HedgeDelay        time.Duration
+	ErrorPolicy       ErrorPolicy
+	RequiredSuccesses int
+	PromotionPolicy   PromotionPolicy
+	TagPropagation    bool
+	Layers            map[int]*LayerOptions
+}
+
+// layer returns the options of the cache layer at the given position
+func (o *ChainOptions) layer(i int) *LayerOptions {
+	if layer, ok := o.Layers[i]; ok {
+		return layer
+	}
+
+	return newLayerOptions()
+}
+
+// LayerOption represents a chain cache layer option function.
+type LayerOption func(o *LayerOptions)
+
+type LayerOptions struct {
+	Name           string
+	MaxTTL         time.Duration
+	DefaultTTL     time.Duration
+	TagPassthrough bool
+	ReadRepair     bool
+	Timeout        time.Duration
+}
+
+func newLayerOptions() *LayerOptions {
+	return &LayerOptions{
+		TagPassthrough: true,
+		ReadRepair:     true,
+	}
+}
+
+func applyChainOptions(opts ...ChainOption) *ChainOptions {
+	o := &ChainOptions{
+		WriteBehindQueue: applyQueueOptions(),
+		ReadRepairQueue:  applyQueueOptions(),
+		PromotionPolicy:  PromoteAlways(),
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithWritePolicy allows to specify the way Set and SetMany write values in the
+// cache layers (WriteSequential by default).
+func WithWritePolicy(policy WritePolicy) ChainOption {
+	return func(o *ChainOptions) {
+		o.WritePolicy = policy
+	}
+}
+
+// WithWriteBehindQueueSize allows to specify the number of values waiting to be
+// written in the lower cache layers when using the WriteBehind policy (10000 by default).
+// Writes block while the queue is full.
+func WithWriteBehindQueueSize(size int) ChainOption {
+	return WithWriteBehindQueue(WithQueueSize(size))
+}
+
+// WithWriteBehindQueue allows to configure the queue of values waiting to be written
+// in the lower cache layers when using the WriteBehind policy.
+func WithWriteBehindQueue(options ...QueueOption) ChainOption {
+	return func(o *ChainOptions) {
+		for _, opt := range options {
+			opt(o.WriteBehindQueue)
+		}
+	}
+}
+
+// WithReadRepairQueue allows to configure the queue of values found in a cache layer
+// waiting to be set back in the previous ones. By default, Get calls block while
+// it is full.
+func WithReadRepairQueue(options ...QueueOption) ChainOption {
+	return func(o *ChainOptions) {
+		for _, opt := range options {
+			opt(o.ReadRepairQueue)
+		}
+	}
+}
+
+// WithHedgedReads allows to query the next cache layer when the previous ones have
+// not answered after the given delay, instead of waiting for them. The value of the
+// first cache layer answering with it is returned.
+func WithHedgedReads(delay time.Duration) ChainOption {
+	return func(o *ChainOptions) {
+		o.ReadPolicy = ReadHedged
+		o.HedgeDelay = delay
+	}
+}
+
+// WithParallelReads allows to query all the cache layers at the same time. The value
+// of the first cache layer having it is returned, without waiting for the next ones.
+func WithParallelReads() ChainOption {
+	return func(o *ChainOptions) {
+		o.ReadPolicy = ReadParallel
+	}
+}
+
+// WithErrorPolicy allows to specify the way errors returned by the cache layers are
+// handled by write and removal operations (ErrorBestEffort by default).
+func WithErrorPolicy(policy ErrorPolicy) ChainOption {
+	return func(o *ChainOptions) {
+		o.ErrorPolicy = policy
+	}
+}
+
+// WithRequiredSuccesses allows write and removal operations to succeed as long as
+// the given number of cache layers succeeded, the errors of the other ones being ignored.
+func WithRequiredSuccesses(count int) ChainOption {
+	return func(o *ChainOptions) {
+		o.ErrorPolicy = ErrorRequireSuccesses
+		o.RequiredSuccesses = count
+	}
+}
+
+// WithPromotionPolicy allows to specify which values found in a cache layer are set
+// back in the previous ones (PromoteAlways by default), so one-off reads of cold keys
+// do not evict the hot ones from the first layers.
+func WithPromotionPolicy(policy PromotionPolicy) ChainOption {
+	return func(o *ChainOptions) {
+		o.PromotionPolicy = policy
+	}
+}
+
+// WithTagPropagation allows to keep the tags of the values written with tags in each
+// cache layer, so they are applied again when values are set back in the previous
+// layers and these copies are invalidated along with the original ones.
+// It costs an additional read in the layer a value is found in each time it is set back.
+func WithTagPropagation() ChainOption {
+	return func(o *ChainOptions) {
+		o.TagPropagation = true
+	}
+}
+
+// WithLayerOptions allows to configure the cache layer at the given position
+// (starting from 0 for the first one).
+func WithLayerOptions(index int, options ...LayerOption) ChainOption {
+	return func(o *ChainOptions) {
+		if o.Layers == nil {
+			o.Layers = make(map[int]*LayerOptions)
+		}
+
+		layer, ok := o.Layers[index]
+		if !ok {
+			layer = newLayerOptions()
+			o.Layers[index] = layer
+		}
+
+		for _, opt := range options {
+			opt(layer)
+		}
+	}
+}
+
+// WithLayerName allows to identify the cache layer by the given name in errors and
+// statistics instead of its store type. Names have to be unique within a chain,
+// the chain constructor returns ErrDuplicateLayerName otherwise.
+func WithLayerName(name string) LayerOption {
+	return func(o *LayerOptions) {
+		o.Name = name
+	}
+}
+
+// WithLayerMaxTTL allows to cap the expiration of the values written in the cache
+// layer, including the ones set back during read-repair. Values without expiration
+// are stored for the max TTL.
+func WithLayerMaxTTL(ttl time.Duration) LayerOption {
+	return func(o *LayerOptions) {
+		o.MaxTTL = ttl
+	}
+}
+
+// WithLayerDefaultTTL allows to specify the expiration of the values written in the
+// cache layer without expiration.
+func WithLayerDefaultTTL(ttl time.Duration) LayerOption {
+	return func(o *LayerOptions) {
+		o.DefaultTTL = ttl
+	}
+}
+
+// WithLayerTagPassthrough allows to specify whether tags are stored along with the
+// values written in the cache layer (enabled by default).
+func WithLayerTagPassthrough(enabled bool) LayerOption {
+	return func(o *LayerOptions) {
+		o.TagPassthrough = enabled
+	}
+}
+
+// WithLayerReadRepair allows to specify whether values found in the next cache
+// layers are set back in the cache layer (enabled by default).
+func WithLayerReadRepair(enabled bool) LayerOption {
+	return func(o *LayerOptions) {
+		o.ReadRepair = enabled
+	}
+}
+
+// WithLayerTimeout allows to give up on calls made to the cache layer (reads of the
+// chain cache) after the given timeout, so a slow backend is considered as a miss.
+func WithLayerTimeout(timeout time.Duration) LayerOption {
+	return func(o *LayerOptions) {
+		o.Timeout = timeout
+	}
+}
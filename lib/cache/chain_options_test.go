@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyChainOptions(t *testing.T) {
+	// When
+	options := applyChainOptions()
+
+	// Then
+	assert.Equal(t, WriteSequential, options.WritePolicy)
+	assert.Equal(t, &QueueOptions{Size: defaultQueueSize, Workers: 1}, options.WriteBehindQueue)
+	assert.Equal(t, &QueueOptions{Size: defaultQueueSize, Workers: 1}, options.ReadRepairQueue)
+}
+
+func TestApplyChainOptionsWithWriteBehind(t *testing.T) {
+	// When
+	options := applyChainOptions(WithWritePolicy(WriteBehind), WithWriteBehindQueueSize(100))
+
+	// Then
+	assert.Equal(t, WriteBehind, options.WritePolicy)
+	assert.Equal(t, 100, options.WriteBehindQueue.Size)
+}
+
+func TestApplyChainOptionsWithLayerOptions(t *testing.T) {
+	// When
+	options := applyChainOptions(
+		WithLayerOptions(0, WithLayerMaxTTL(30*time.Second)),
+		WithLayerOptions(0, WithLayerReadRepair(false)),
+		WithLayerOptions(1, WithLayerName("global"), WithLayerDefaultTTL(time.Hour), WithLayerTagPassthrough(false)),
+	)
+
+	// Then
+	assert.Equal(t, &LayerOptions{
+		MaxTTL:         30 * time.Second,
+		TagPassthrough: true,
+		ReadRepair:     false,
+	}, options.layer(0))
+
+	assert.Equal(t, &LayerOptions{
+		Name:           "global",
+		DefaultTTL:     time.Hour,
+		TagPassthrough: false,
+		ReadRepair:     true,
+	}, options.layer(1))
+
+	assert.Equal(t, newLayerOptions(), options.layer(2))
+}
+
+func TestApplyChainOptionsWithTagPropagation(t *testing.T) {
+	// When
+	options := applyChainOptions(WithTagPropagation())
+
+	// Then
+	assert.True(t, options.TagPropagation)
+}
+
+func TestApplyChainOptionsWithReadPolicy(t *testing.T) {
+	// When
+	parallel := applyChainOptions(WithParallelReads())
+	hedged := applyChainOptions(WithHedgedReads(10*time.Millisecond), WithLayerOptions(1, WithLayerTimeout(time.Second)))
+
+	// Then
+	assert.Equal(t, ReadSequential, applyChainOptions().ReadPolicy)
+	assert.Equal(t, ReadParallel, parallel.ReadPolicy)
+
+	assert.Equal(t, ReadHedged, hedged.ReadPolicy)
+	assert.Equal(t, 10*time.Millisecond, hedged.HedgeDelay)
+	assert.Equal(t, time.Duration(0), hedged.layer(0).Timeout)
+	assert.Equal(t, time.Second, hedged.layer(1).Timeout)
+}
+
+func TestApplyChainOptionsWithErrorPolicy(t *testing.T) {
+	// When
+	failFast := applyChainOptions(WithErrorPolicy(ErrorFailFast))
+	requireSuccesses := applyChainOptions(WithRequiredSuccesses(2))
+
+	// Then
+	assert.Equal(t, ErrorBestEffort, applyChainOptions().ErrorPolicy)
+	assert.Equal(t, ErrorFailFast, failFast.ErrorPolicy)
+
+	assert.Equal(t, ErrorRequireSuccesses, requireSuccesses.ErrorPolicy)
+	assert.Equal(t, 2, requireSuccesses.RequiredSuccesses)
+}
+
+func TestApplyChainOptionsWithPromotionPolicy(t *testing.T) {
+	// When
+	options := applyChainOptions(WithPromotionPolicy(PromoteNever()))
+
+	// Then
+	assert.Equal(t, PromoteAlways(), applyChainOptions().PromotionPolicy)
+	assert.Equal(t, PromoteNever(), options.PromotionPolicy)
+}
+
+func TestApplyChainOptionsWithQueues(t *testing.T) {
+	// When
+	options := applyChainOptions(
+		WithReadRepairQueue(WithQueueSize(100), WithQueueOverflowPolicy(OverflowDropNewest)),
+		WithWriteBehindQueue(WithQueueWorkers(4)),
+	)
+
+	// Then
+	assert.Equal(t, &QueueOptions{Size: 100, Workers: 1, OverflowPolicy: OverflowDropNewest}, options.ReadRepairQueue)
+	assert.Equal(t, &QueueOptions{Size: defaultQueueSize, Workers: 4}, options.WriteBehindQueue)
+}
@@ -0,0 +1,169 @@
+package cache
+
+import (
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// PromotionPolicy decides whether a value found in a chain cache layer is set back
+// in the previous ones, so only keys that are read often enough take place in them
+type PromotionPolicy interface {
+	// ShouldPromote records a hit of the given key in the cache layer at the given
+	// position (greater than 0) and returns true if its value has to be set back
+	ShouldPromote(key any, layer int) bool
+}
+
+type alwaysPromotion struct{}
+
+// PromoteAlways returns a promotion policy setting back every value found in a
+// cache layer in the previous ones (default)
+func PromoteAlways() PromotionPolicy {
+	return alwaysPromotion{}
+}
+
+func (alwaysPromotion) ShouldPromote(key any, layer int) bool {
+	return true
+}
+
+type neverPromotion struct{}
+
+// PromoteNever returns a promotion policy never setting back values found in a
+// cache layer in the previous ones
+func PromoteNever() PromotionPolicy {
+	return neverPromotion{}
+}
+
+func (neverPromotion) ShouldPromote(key any, layer int) bool {
+	return false
+}
+
+type hitsPromotionCount struct {
+	hits  int
+	start time.Time
+}
+
+// hitsPromotion counts the hits of each key during a window of time
+type hitsPromotion struct {
+	hits      int
+	window    time.Duration
+	mu        sync.Mutex
+	counts    map[string]*hitsPromotionCount
+	lastPrune time.Time
+}
+
+// PromoteAfterHits returns a promotion policy setting back values once their key
+// has been found the given number of times in the next cache layers within the
+// given window of time.
+func PromoteAfterHits(hits int, window time.Duration) PromotionPolicy {
+	return &hitsPromotion{
+		hits:      hits,
+		window:    window,
+		counts:    make(map[string]*hitsPromotionCount),
+		lastPrune: time.Now(),
+	}
+}
+
+func (p *hitsPromotion) ShouldPromote(key any, layer int) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	cacheKey := getCacheKey(key)
+
+	if now.Sub(p.lastPrune) > p.window {
+		for k, count := range p.counts {
+			if now.Sub(count.start) > p.window {
+				delete(p.counts, k)
+			}
+		}
+		p.lastPrune = now
+	}
+
+	count, ok := p.counts[cacheKey]
+	if !ok || now.Sub(count.start) > p.window {
+		count = &hitsPromotionCount{start: now}
+		p.counts[cacheKey] = count
+	}
+	count.hits++
+
+	if count.hits < p.hits {
+		return false
+	}
+
+	// The key starts again from zero if it is evicted from the previous layers
+	delete(p.counts, cacheKey)
+
+	return true
+}
+
+const (
+	frequencySketchDepth      = 4
+	frequencySketchMaxCounter = 15
+)
+
+// frequencyPromotion estimates the frequency of the keys using a count-min sketch
+// of small counters which are halved periodically, as done by TinyLFU, so only
+// recent frequencies are taken into account using a fixed amount of memory
+type frequencyPromotion struct {
+	minFrequency int
+	mu           sync.Mutex
+	counters     []uint8
+	mask         uint64
+	additions    int
+	sampleSize   int
+}
+
+// PromoteFrequent returns a promotion policy setting back values once the estimated
+// frequency of their key reaches the given minimum (up to 15). The capacity is the
+// expected number of keys in the previous cache layers and sizes the frequency
+// sketch, whose counters are halved each time 10 times this number of hits have
+// been recorded.
+func PromoteFrequent(capacity int, minFrequency int) PromotionPolicy {
+	width := 1
+	for width < capacity {
+		width <<= 1
+	}
+
+	return &frequencyPromotion{
+		minFrequency: minFrequency,
+		counters:     make([]uint8, width*frequencySketchDepth),
+		mask:         uint64(width - 1),
+		sampleSize:   10 * width,
+	}
+}
+
+func (p *frequencyPromotion) ShouldPromote(key any, layer int) bool {
+	hash := fnv.New64a()
+	hash.Write([]byte(getCacheKey(key)))
+	sum := hash.Sum64()
+
+	// Derive the positions of the key in each row from two halves of its hash
+	h1, h2 := sum&0xffffffff, sum>>32
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	width := int(p.mask + 1)
+	frequency := frequencySketchMaxCounter
+
+	for i := 0; i < frequencySketchDepth; i++ {
+		index := i*width + int((h1+uint64(i)*h2)&p.mask)
+		if p.counters[index] < frequencySketchMaxCounter {
+			p.counters[index]++
+		}
+		if int(p.counters[index]) < frequency {
+			frequency = int(p.counters[index])
+		}
+	}
+
+	p.additions++
+	if p.additions >= p.sampleSize {
+		for i := range p.counters {
+			p.counters[i] >>= 1
+		}
+		p.additions = 0
+	}
+
+	return frequency >= p.minFrequency
+}
@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromoteAlways(t *testing.T) {
+	// Given
+	policy := PromoteAlways()
+
+	// When - Then
+	assert.True(t, policy.ShouldPromote("my-key", 1))
+}
+
+func TestPromoteNever(t *testing.T) {
+	// Given
+	policy := PromoteNever()
+
+	// When - Then
+	assert.False(t, policy.ShouldPromote("my-key", 1))
+}
+
+func TestPromoteAfterHits(t *testing.T) {
+	// Given
+	policy := PromoteAfterHits(3, time.Minute)
+
+	// When - Then
+	assert.False(t, policy.ShouldPromote("my-key", 1))
+	assert.False(t, policy.ShouldPromote("my-key", 1))
+	assert.False(t, policy.ShouldPromote("another-key", 1))
+	assert.True(t, policy.ShouldPromote("my-key", 1))
+
+	// Hits are counted again once the key has been promoted
+	assert.False(t, policy.ShouldPromote("my-key", 1))
+}
+
+func TestPromoteAfterHitsWhenWindowIsOver(t *testing.T) {
+	// Given
+	policy := PromoteAfterHits(2, 10*time.Millisecond)
+
+	assert.False(t, policy.ShouldPromote("my-key", 1))
+
+	// When
+	time.Sleep(20 * time.Millisecond)
+
+	// Then
+	assert.False(t, policy.ShouldPromote("my-key", 1))
+	assert.True(t, policy.ShouldPromote("my-key", 1))
+	assert.Len(t, policy.(*hitsPromotion).counts, 0)
+}
+
+func TestPromoteFrequent(t *testing.T) {
+	// Given
+	policy := PromoteFrequent(1000, 3)
+
+	// When - Then
+	assert.False(t, policy.ShouldPromote("my-key", 1))
+	assert.False(t, policy.ShouldPromote("my-key", 1))
+	assert.True(t, policy.ShouldPromote("my-key", 1))
+	assert.True(t, policy.ShouldPromote("my-key", 1))
+
+	assert.False(t, policy.ShouldPromote("another-key", 1))
+}
+
+func TestPromoteFrequentWhenScanningColdKeys(t *testing.T) {
+	// Given
+	policy := PromoteFrequent(1000, 3)
+
+	promoted := 0
+
+	// When
+	for i := 0; i < 1000; i++ {
+		if policy.ShouldPromote(fmt.Sprintf("cold-key-%d", i), 1) {
+			promoted++
+		}
+	}
+
+	// Then
+	assert.Less(t, promoted, 10)
+}
+
+func TestPromoteFrequentHalvesCounters(t *testing.T) {
+	// Given
+	policy := PromoteFrequent(1, 2)
+
+	// The sketch has a single column, so counters are halved every 10 hits
+	for i := 0; i < 9; i++ {
+		policy.ShouldPromote("my-key", 1)
+	}
+	assert.Equal(t, uint8(9), policy.(*frequencyPromotion).counters[0])
+
+	// When
+	policy.ShouldPromote("my-key", 1)
+
+	// Then
+	assert.Equal(t, uint8(5), policy.(*frequencyPromotion).counters[0])
+}
@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// chainLayerResult represents the result of a read made in a cache layer
+type chainLayerResult[T any] struct {
+	layer  int
+	object T
+	ttl    time.Duration
+	err    error
+}
+
+// getLayer returns the object stored in the cache layer at the given position,
+// giving up after the layer timeout if any
+func (c *ChainCache[T]) getLayer(ctx context.Context, i int, key any) (T, time.Duration, error) {
+	ctx, cancel := c.getLayerContext(ctx, i)
+	defer cancel()
+
+	object, ttl, err := c.caches[i].GetWithTTL(ctx, key)
+	if err == nil {
+		c.recordGet(i, 1, 0)
+	} else {
+		c.recordGet(i, 0, 1)
+	}
+
+	return object, ttl, err
+}
+
+// getLayerContext returns the context to use for a call made to the cache layer
+// at the given position, which is cancelled after the layer timeout if any
+func (c *ChainCache[T]) getLayerContext(ctx context.Context, i int) (context.Context, context.CancelFunc) {
+	if timeout := c.options.layer(i).Timeout; timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+
+	return ctx, func() {}
+}
+
+// newReadError returns the error of a read which failed in all cache layers,
+// given their results
+func (c *ChainCache[T]) newReadError(key any, results []chainLayerResult[T]) error {
+	errs := make([]*ChainLayerError, 0, len(results))
+	for _, result := range results {
+		errs = append(errs, c.newLayerError(result.layer, ChainOperationGet, []any{key}, result.err))
+	}
+
+	return &ChainError{Errors: errs}
+}
+
+// getSequentially returns the result of the first cache layer having the object,
+// querying them one after another
+func (c *ChainCache[T]) getSequentially(ctx context.Context, key any) chainLayerResult[T] {
+	misses := make([]chainLayerResult[T], 0, len(c.caches))
+
+	for i := range c.caches {
+		result := chainLayerResult[T]{layer: i}
+		result.object, result.ttl, result.err = c.getLayer(ctx, i, key)
+		if result.err == nil {
+			return result
+		}
+
+		misses = append(misses, result)
+	}
+
+	return chainLayerResult[T]{err: c.newReadError(key, misses)}
+}
+
+// getInParallel returns the result of the first cache layer having the object,
+// querying them all at the same time
+func (c *ChainCache[T]) getInParallel(ctx context.Context, key any) chainLayerResult[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]chan chainLayerResult[T], len(c.caches))
+	for i := range c.caches {
+		results[i] = make(chan chainLayerResult[T], 1)
+
+		go func(i int) {
+			object, ttl, err := c.getLayer(ctx, i, key)
+			results[i] <- chainLayerResult[T]{layer: i, object: object, ttl: ttl, err: err}
+		}(i)
+	}
+
+	// Wait for the layers in order so the highest-priority hit is returned
+	misses := make([]chainLayerResult[T], 0, len(c.caches))
+	for i := range c.caches {
+		result := <-results[i]
+		if result.err == nil {
+			return result
+		}
+
+		misses = append(misses, result)
+	}
+
+	return chainLayerResult[T]{err: c.newReadError(key, misses)}
+}
+
+// getHedged returns the result of the first cache layer answering with the object.
+// Layers are queried one after another, but the next layer is also queried when
+// the previous ones have not answered after the hedge delay.
+func (c *ChainCache[T]) getHedged(ctx context.Context, key any) chainLayerResult[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan chainLayerResult[T], len(c.caches))
+	next, pending := 0, 0
+
+	queryNext := func() {
+		go func(i int) {
+			object, ttl, err := c.getLayer(ctx, i, key)
+			results <- chainLayerResult[T]{layer: i, object: object, ttl: ttl, err: err}
+		}(next)
+
+		next++
+		pending++
+	}
+
+	queryNext()
+
+	timer := time.NewTimer(c.options.HedgeDelay)
+	defer timer.Stop()
+
+	// Keep the misses ordered by layer, as when querying them sequentially
+	misses := make([]chainLayerResult[T], len(c.caches))
+
+	for pending > 0 {
+		select {
+		case result := <-results:
+			pending--
+			if result.err == nil {
+				return result
+			}
+			misses[result.layer] = result
+
+			if next < len(c.caches) {
+				queryNext()
+
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(c.options.HedgeDelay)
+			}
+
+		case <-timer.C:
+			if next < len(c.caches) {
+				queryNext()
+				timer.Reset(c.options.HedgeDelay)
+			}
+		}
+	}
+
+	return chainLayerResult[T]{err: c.newReadError(key, misses)}
+}
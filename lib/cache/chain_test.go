@@ -81,7 +81,7 @@ func TestChainGetWhenAvailableInFirstCache(t *testing.T) {
 	value, err := cache.Get(ctx, "my-key")
 
 	// Wait for data to be processed
-	time.Sleep(100 * time.Millisecond)
+	cache.Flush(ctx)
 
 	// Then
 	assert.Nil(t, err)
@@ -131,13 +131,59 @@ func TestChainGetWhenAvailableInSecondCache(t *testing.T) {
 	value, err := cache.Get(ctx, "my-key")
 
 	// Wait for data to be processed
-	time.Sleep(100 * time.Millisecond)
+	cache.Flush(ctx)
 
 	// Then
 	assert.Nil(t, err)
 	assert.Equal(t, cacheValue, value)
 }
 
+func TestChainGetWithTTL(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().GetType().AnyTimes().Return("store1")
+
+	codec1 := codec.NewMockCodecInterface(ctrl)
+	codec1.EXPECT().GetStore().AnyTimes().Return(store1)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetCodec().AnyTimes().Return(codec1)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return(nil, 0*time.Second,
+		errors.New("unable to find in cache 1"))
+	cache1.EXPECT().Set(context.Background(), "my-key", "a value", store.OptionsMatcher{
+		Expiration: 10 * time.Second,
+	}).AnyTimes().Return(nil)
+
+	// Cache 2
+	store2 := store.NewMockStoreInterface(ctrl)
+	store2.EXPECT().GetType().AnyTimes().Return("store2")
+
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().GetStore().AnyTimes().Return(store2)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().AnyTimes().Return(codec2)
+	cache2.EXPECT().GetWithTTL(ctx, "my-key").Return("a value", 10*time.Second, nil)
+
+	cache := NewChain[any](cache1, cache2)
+
+	// When
+	value, ttl, err := cache.GetWithTTL(ctx, "my-key")
+
+	// Wait for data to be processed
+	cache.Flush(ctx)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "a value", value)
+	assert.Equal(t, 10*time.Second, ttl)
+}
+
 func TestChainGetWhenNotAvailableInAnyCache(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -146,25 +192,25 @@ func TestChainGetWhenNotAvailableInAnyCache(t *testing.T) {
 
 	// Cache 1
 	store1 := store.NewMockStoreInterface(ctrl)
-	store1.EXPECT().GetType().Return("store1")
+	store1.EXPECT().GetType().AnyTimes().Return("store1")
 
 	codec1 := codec.NewMockCodecInterface(ctrl)
-	codec1.EXPECT().GetStore().Return(store1)
+	codec1.EXPECT().GetStore().AnyTimes().Return(store1)
 
 	cache1 := NewMockSetterCacheInterface[any](ctrl)
-	cache1.EXPECT().GetCodec().Return(codec1)
+	cache1.EXPECT().GetCodec().AnyTimes().Return(codec1)
 	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return(nil, 0*time.Second,
 		errors.New("unable to find in cache 1"))
 
 	// Cache 2
 	store2 := store.NewMockStoreInterface(ctrl)
-	store2.EXPECT().GetType().Return("store2")
+	store2.EXPECT().GetType().AnyTimes().Return("store2")
 
 	codec2 := codec.NewMockCodecInterface(ctrl)
-	codec2.EXPECT().GetStore().Return(store2)
+	codec2.EXPECT().GetStore().AnyTimes().Return(store2)
 
 	cache2 := NewMockSetterCacheInterface[any](ctrl)
-	cache2.EXPECT().GetCodec().Return(codec2)
+	cache2.EXPECT().GetCodec().AnyTimes().Return(codec2)
 	cache2.EXPECT().GetWithTTL(ctx, "my-key").Return(nil, 0*time.Second,
 		errors.New("unable to find in cache 2"))
 
@@ -174,10 +220,11 @@ func TestChainGetWhenNotAvailableInAnyCache(t *testing.T) {
 	value, err := cache.Get(ctx, "my-key")
 
 	// Wait for data to be processed
-	time.Sleep(100 * time.Millisecond)
+	cache.Flush(ctx)
 
 	// Then
-	assert.Equal(t, errors.New("unable to find in cache 2"), err)
+	assert.EqualError(t, err, "error 1 of 2: Unable to get item from cache with store 'store1': unable to find in cache 1; "+
+		"error 2 of 2: Unable to get item from cache with store 'store2': unable to find in cache 2")
 	assert.Equal(t, nil, value)
 }
 
@@ -236,7 +283,14 @@ func TestChainSetWhenErrorOnSetting(t *testing.T) {
 	cache1.EXPECT().Set(ctx, "my-key", cacheValue).Return(expectedErr)
 
 	// Cache 2
+	store2 := store.NewMockStoreInterface(ctrl)
+	store2.EXPECT().GetType().Return("store2")
+
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().GetStore().Return(store2)
+
 	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().Return(codec2)
 	cache2.EXPECT().Set(ctx, "my-key", cacheValue)
 
 	cache := NewChain[any](cache1, cache2)
@@ -279,11 +333,25 @@ func TestChainDeleteWhenError(t *testing.T) {
 	ctx := context.Background()
 
 	// Cache 1
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().GetType().AnyTimes().Return("store1")
+
+	codec1 := codec.NewMockCodecInterface(ctrl)
+	codec1.EXPECT().GetStore().AnyTimes().Return(store1)
+
 	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetCodec().AnyTimes().Return(codec1)
 	cache1.EXPECT().Delete(ctx, "my-key").Return(errors.New("an error has occurred while deleting key"))
 
 	// Cache 2
+	store2 := store.NewMockStoreInterface(ctrl)
+	store2.EXPECT().GetType().Return("store2")
+
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().GetStore().Return(store2)
+
 	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().Return(codec2)
 	cache2.EXPECT().Delete(ctx, "my-key").Return(nil)
 
 	cache := NewChain[any](cache1, cache2)
@@ -292,7 +360,7 @@ func TestChainDeleteWhenError(t *testing.T) {
 	err := cache.Delete(ctx, "my-key")
 
 	// Then
-	assert.Nil(t, err)
+	assert.EqualError(t, err, "error 1 of 1: Unable to delete item from cache with store 'store1': an error has occurred while deleting key")
 }
 
 func TestChainInvalidate(t *testing.T) {
@@ -325,11 +393,25 @@ func TestChainInvalidateWhenError(t *testing.T) {
 	ctx := context.Background()
 
 	// Cache 1
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().GetType().AnyTimes().Return("store1")
+
+	codec1 := codec.NewMockCodecInterface(ctrl)
+	codec1.EXPECT().GetStore().AnyTimes().Return(store1)
+
 	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetCodec().AnyTimes().Return(codec1)
 	cache1.EXPECT().Invalidate(ctx).Return(errors.New("an unexpected error has occurred while invalidation data"))
 
 	// Cache 2
+	store2 := store.NewMockStoreInterface(ctrl)
+	store2.EXPECT().GetType().Return("store2")
+
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().GetStore().Return(store2)
+
 	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().Return(codec2)
 	cache2.EXPECT().Invalidate(ctx).Return(nil)
 
 	cache := NewChain[any](cache1, cache2)
@@ -338,7 +420,7 @@ func TestChainInvalidateWhenError(t *testing.T) {
 	err := cache.Invalidate(ctx)
 
 	// Then
-	assert.Nil(t, err)
+	assert.EqualError(t, err, "error 1 of 1: Unable to invalidate cache with store 'store1': an unexpected error has occurred while invalidation data")
 }
 
 func TestChainClear(t *testing.T) {
@@ -371,11 +453,25 @@ func TestChainClearWhenError(t *testing.T) {
 	ctx := context.Background()
 
 	// Cache 1
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().GetType().AnyTimes().Return("store1")
+
+	codec1 := codec.NewMockCodecInterface(ctrl)
+	codec1.EXPECT().GetStore().AnyTimes().Return(store1)
+
 	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetCodec().AnyTimes().Return(codec1)
 	cache1.EXPECT().Clear(ctx).Return(errors.New("an unexpected error has occurred while invalidation data"))
 
 	// Cache 2
+	store2 := store.NewMockStoreInterface(ctrl)
+	store2.EXPECT().GetType().Return("store2")
+
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().GetStore().Return(store2)
+
 	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().Return(codec2)
 	cache2.EXPECT().Clear(ctx).Return(nil)
 
 	cache := NewChain[any](cache1, cache2)
@@ -384,7 +480,7 @@ func TestChainClearWhenError(t *testing.T) {
 	err := cache.Clear(ctx)
 
 	// Then
-	assert.Nil(t, err)
+	assert.EqualError(t, err, "error 1 of 1: Unable to clear cache with store 'store1': an unexpected error has occurred while invalidation data")
 }
 
 func TestChainGetType(t *testing.T) {
@@ -439,6 +535,11 @@ func TestChainSetWhenErrorInChain(t *testing.T) {
 
 	store2 := NewMockSetterCacheInterface[any](ctrl)
 
+	store2.EXPECT().GetType().AnyTimes().Return("store2")
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().GetStore().AnyTimes().Return(store2)
+	store2.EXPECT().GetCodec().AnyTimes().Return(codec2)
+
 	cache := NewChain[any](store1, store2)
 
 	// assert store2 set is called
@@ -449,5 +550,1684 @@ func TestChainSetWhenErrorInChain(t *testing.T) {
 
 	expErr := errors.New("error 1 of 1: Unable to set item into cache with store 'store1': an issue occurred with the cache")
 	// Then
-	assert.Equal(t, expErr, err)
+	assert.EqualError(t, err, expErr.Error())
+	assert.True(t, errors.Is(err, interError))
+}
+
+func TestChainGetManyWhenPartiallyAvailableInCaches(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().GetType().AnyTimes().Return("store1")
+	store1.EXPECT().Get(ctx, "key1").Return("value1", nil)
+	store1.EXPECT().Get(ctx, "key2").Return(nil, store.NotFound{})
+	store1.EXPECT().Get(ctx, "key3").Return(nil, store.NotFound{})
+	store1.EXPECT().Set(context.Background(), "key2", "value2").Return(nil)
+
+	// Cache 2
+	store2 := store.NewMockStoreInterface(ctrl)
+	store2.EXPECT().GetType().AnyTimes().Return("store2")
+	store2.EXPECT().Get(ctx, "key2").Return("value2", nil)
+	store2.EXPECT().Get(ctx, "key3").Return(nil, store.NotFound{})
+
+	cache := NewChain[any](New[any](store1), New[any](store2))
+
+	// When
+	values, err := cache.GetMany(ctx, []any{"key1", "key2", "key3"})
+
+	// Wait for data to be processed
+	cache.Flush(ctx)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key1": "value1", "key2": "value2"}, values)
+}
+
+func TestChainSetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	items := map[any]any{"key1": "value1"}
+
+	// Cache 1
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Set(ctx, "key1", "value1", &store.OptionsMatcher{}).Return(nil)
+
+	// Cache 2
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().Set(ctx, "key1", "value1", &store.OptionsMatcher{}).Return(nil)
+
+	cache := NewChain[any](cache1, cache2)
+
+	// When
+	err := cache.SetMany(ctx, items)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestChainDeleteMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().GetType().Return("store1")
+
+	codec1 := codec.NewMockCodecInterface(ctrl)
+	codec1.EXPECT().GetStore().Return(store1)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetCodec().Return(codec1)
+	cache1.EXPECT().Delete(ctx, "key1").Return(nil)
+	cache1.EXPECT().Delete(ctx, "key2").Return(nil)
+
+	// Cache 2
+	store2 := store.NewMockStoreInterface(ctrl)
+	store2.EXPECT().GetType().AnyTimes().Return("store2")
+
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().GetStore().AnyTimes().Return(store2)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().AnyTimes().Return(codec2)
+	cache2.EXPECT().Delete(ctx, "key1").Return(nil)
+	cache2.EXPECT().Delete(ctx, "key2").Return(errors.New("unable to delete"))
+
+	cache := NewChain[any](cache1, cache2)
+
+	// When
+	err := cache.DeleteMany(ctx, []any{"key1", "key2"})
+
+	// Then
+	assert.EqualError(t, err, "error 1 of 1: Unable to delete items from cache with store 'store2': unable to delete")
+
+	var layerErr *ChainLayerError
+	assert.True(t, errors.As(err, &layerErr))
+	assert.Equal(t, 1, layerErr.Layer)
+	assert.Equal(t, ChainOperationDelete, layerErr.Operation)
+	assert.Equal(t, []any{"key1", "key2"}, layerErr.Keys)
+}
+
+func TestChainCloseSetsBackPendingValues(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().GetType().AnyTimes().Return("store1")
+
+	codec1 := codec.NewMockCodecInterface(ctrl)
+	codec1.EXPECT().GetStore().AnyTimes().Return(store1)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetCodec().AnyTimes().Return(codec1)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return(nil, 0*time.Second,
+		errors.New("unable to find in cache 1"))
+	cache1.EXPECT().Set(context.Background(), "my-key", "my-value", &store.OptionsMatcher{}).Return(nil)
+
+	// Cache 2
+	store2 := store.NewMockStoreInterface(ctrl)
+	store2.EXPECT().GetType().AnyTimes().Return("store2")
+
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().GetStore().AnyTimes().Return(store2)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().AnyTimes().Return(codec2)
+	cache2.EXPECT().GetWithTTL(ctx, "my-key").Return("my-value", 0*time.Second, nil)
+
+	cache := NewChain[any](cache1, cache2)
+
+	_, err := cache.Get(ctx, "my-key")
+	assert.Nil(t, err)
+
+	// When
+	err = cache.Close(ctx)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestChainWhenClosed(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+
+	cache := NewChain[any](cache1)
+	assert.Nil(t, cache.Close(ctx))
+
+	// When
+	_, getErr := cache.Get(ctx, "my-key")
+	_, getManyErr := cache.GetMany(ctx, []any{"my-key"})
+	setErr := cache.Set(ctx, "my-key", "my-value")
+	deleteErr := cache.Delete(ctx, "my-key")
+	flushErr := cache.Flush(ctx)
+	closeErr := cache.Close(ctx)
+
+	// Then
+	assert.ErrorIs(t, getErr, ErrClosed)
+	assert.ErrorIs(t, getManyErr, ErrClosed)
+	assert.ErrorIs(t, setErr, ErrClosed)
+	assert.ErrorIs(t, deleteErr, ErrClosed)
+	assert.ErrorIs(t, flushErr, ErrClosed)
+	assert.ErrorIs(t, closeErr, ErrClosed)
+}
+
+func TestChainFlushWhenContextIsDone(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+
+	cache := NewChain[any](cache1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// When
+	err := cache.Flush(ctx)
+
+	// Then
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestNewChainWithOptions(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+
+	// When
+	cache, err := NewChainWithOptions([]SetterCacheInterface[any]{cache1, cache2}, WithWritePolicy(WriteBehind))
+	assert.Nil(t, err)
+
+	// Then
+	assert.Equal(t, []SetterCacheInterface[any]{cache1, cache2}, cache.caches)
+	assert.Equal(t, WriteBehind, cache.options.WritePolicy)
+	assert.NotNil(t, cache.writer)
+}
+
+func TestChainSetWithWriteThrough(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	release := make(chan struct{})
+
+	// Cache 1
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Set(ctx, "my-key", "my-value").DoAndReturn(func(_ context.Context, _ any, _ any, _ ...store.Option) error {
+		// Only returns once the second cache has been written
+		<-release
+		return nil
+	})
+
+	// Cache 2
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().Set(ctx, "my-key", "my-value").DoAndReturn(func(_ context.Context, _ any, _ any, _ ...store.Option) error {
+		close(release)
+		return nil
+	})
+
+	cache, err := NewChainWithOptions([]SetterCacheInterface[any]{cache1, cache2}, WithWritePolicy(WriteThrough))
+	assert.Nil(t, err)
+
+	// When
+	err = cache.Set(ctx, "my-key", "my-value")
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestChainSetWithWriteThroughWhenError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().GetType().Return("store1")
+
+	codec1 := codec.NewMockCodecInterface(ctrl)
+	codec1.EXPECT().GetStore().Return(store1)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetCodec().Return(codec1)
+	cache1.EXPECT().Set(ctx, "my-key", "my-value").Return(nil)
+
+	// Cache 2
+	store2 := store.NewMockStoreInterface(ctrl)
+	store2.EXPECT().GetType().Return("store2")
+
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().GetStore().Return(store2)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().Return(codec2)
+	cache2.EXPECT().Set(ctx, "my-key", "my-value").Return(errors.New("an unexpected error occurred while setting data"))
+
+	cache, err := NewChainWithOptions([]SetterCacheInterface[any]{cache1, cache2}, WithWritePolicy(WriteThrough))
+	assert.Nil(t, err)
+
+	// When
+	err = cache.Set(ctx, "my-key", "my-value")
+
+	// Then
+	assert.EqualError(t, err, "error 1 of 1: Unable to set item into cache with store 'store2': an unexpected error occurred while setting data")
+}
+
+func TestChainSetWithWriteInvalidate(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+
+	// Cache 2
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+
+	// Cache 3
+	cache3 := NewMockSetterCacheInterface[any](ctrl)
+
+	gomock.InOrder(
+		cache3.EXPECT().Set(ctx, "my-key", "my-value").Return(nil),
+		cache1.EXPECT().Delete(ctx, "my-key").Return(nil),
+		cache2.EXPECT().Delete(ctx, "my-key").Return(nil),
+	)
+
+	cache, err := NewChainWithOptions([]SetterCacheInterface[any]{cache1, cache2, cache3}, WithWritePolicy(WriteInvalidate))
+	assert.Nil(t, err)
+
+	// When
+	err = cache.Set(ctx, "my-key", "my-value")
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestChainSetWithWriteInvalidateWhenError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().GetType().Return("store1")
+
+	codec1 := codec.NewMockCodecInterface(ctrl)
+	codec1.EXPECT().GetStore().Return(store1)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetCodec().Return(codec1)
+
+	// Cache 2
+	store2 := store.NewMockStoreInterface(ctrl)
+	store2.EXPECT().GetType().Return("store2")
+
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().GetStore().Return(store2)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().Return(codec2)
+	cache2.EXPECT().Set(ctx, "my-key", "my-value").Return(errors.New("an unexpected error occurred while setting data"))
+
+	cache, err := NewChainWithOptions([]SetterCacheInterface[any]{cache1, cache2}, WithWritePolicy(WriteInvalidate))
+	assert.Nil(t, err)
+
+	// When
+	err = cache.Set(ctx, "my-key", "my-value")
+
+	// Then
+	assert.EqualError(t, err, "error 1 of 1: Unable to set item into cache with store 'store2': an unexpected error occurred while setting data")
+}
+
+func TestChainSetManyWithWriteBehind(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	items := map[any]any{"key1": "value1"}
+
+	// Cache 1
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Set(ctx, "key1", "value1", &store.OptionsMatcher{Expiration: time.Minute}).Return(nil)
+
+	// Cache 2
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().Set(context.Background(), "key1", "value1", &store.OptionsMatcher{Expiration: time.Minute}).Return(nil)
+
+	cache, err := NewChainWithOptions([]SetterCacheInterface[any]{cache1, cache2}, WithWritePolicy(WriteBehind))
+	assert.Nil(t, err)
+
+	// When
+	err = cache.SetMany(ctx, items, store.WithExpiration(time.Minute))
+
+	// Wait for data to be processed
+	cache.Flush(ctx)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestChainSetWithWriteBehindWhenErrorInFirstCache(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().GetType().Return("store1")
+
+	codec1 := codec.NewMockCodecInterface(ctrl)
+	codec1.EXPECT().GetStore().Return(store1)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetCodec().Return(codec1)
+	cache1.EXPECT().Set(ctx, "my-key", "my-value").Return(errors.New("an unexpected error occurred while setting data"))
+
+	// Cache 2
+	store2 := store.NewMockStoreInterface(ctrl)
+	store2.EXPECT().GetType().Return("store2")
+
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().GetStore().Return(store2)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().Return(codec2)
+
+	cache, err := NewChainWithOptions([]SetterCacheInterface[any]{cache1, cache2}, WithWritePolicy(WriteBehind))
+	assert.Nil(t, err)
+
+	// When
+	err = cache.Set(ctx, "my-key", "my-value")
+
+	cache.Flush(ctx)
+
+	// Then
+	assert.EqualError(t, err, "error 1 of 1: Unable to set item into cache with store 'store1': an unexpected error occurred while setting data")
+}
+
+func TestChainGetWhenAvailableInSecondCacheWithLayerMaxTTL(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().GetType().AnyTimes().Return("store1")
+
+	codec1 := codec.NewMockCodecInterface(ctrl)
+	codec1.EXPECT().GetStore().AnyTimes().Return(store1)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetCodec().AnyTimes().Return(codec1)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return(nil, 0*time.Second,
+		errors.New("unable to find in cache 1"))
+	cache1.EXPECT().Set(context.Background(), "my-key", "my-value", &store.OptionsMatcher{
+		Expiration: 30 * time.Second,
+	}).Return(nil)
+
+	// Cache 2
+	store2 := store.NewMockStoreInterface(ctrl)
+	store2.EXPECT().GetType().AnyTimes().Return("store2")
+
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().GetStore().AnyTimes().Return(store2)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().AnyTimes().Return(codec2)
+	cache2.EXPECT().GetWithTTL(ctx, "my-key").Return("my-value", 24*time.Hour, nil)
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithLayerOptions(0, WithLayerMaxTTL(30*time.Second)),
+	)
+	assert.Nil(t, err)
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	cache.Flush(ctx)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestChainGetWhenAvailableInThirdCacheWithoutLayerReadRepair(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().GetType().AnyTimes().Return("store1")
+
+	codec1 := codec.NewMockCodecInterface(ctrl)
+	codec1.EXPECT().GetStore().AnyTimes().Return(store1)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetCodec().AnyTimes().Return(codec1)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return(nil, 0*time.Second,
+		errors.New("unable to find in cache 1"))
+
+	// Cache 2
+	store2 := store.NewMockStoreInterface(ctrl)
+	store2.EXPECT().GetType().AnyTimes().Return("store2")
+
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().GetStore().AnyTimes().Return(store2)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().AnyTimes().Return(codec2)
+	cache2.EXPECT().GetWithTTL(ctx, "my-key").Return(nil, 0*time.Second,
+		errors.New("unable to find in cache 2"))
+	cache2.EXPECT().Set(context.Background(), "my-key", "my-value", &store.OptionsMatcher{
+		Expiration: time.Hour,
+	}).Return(nil)
+
+	// Cache 3
+	store3 := store.NewMockStoreInterface(ctrl)
+	store3.EXPECT().GetType().AnyTimes().Return("store3")
+
+	codec3 := codec.NewMockCodecInterface(ctrl)
+	codec3.EXPECT().GetStore().AnyTimes().Return(store3)
+
+	cache3 := NewMockSetterCacheInterface[any](ctrl)
+	cache3.EXPECT().GetCodec().AnyTimes().Return(codec3)
+	cache3.EXPECT().GetWithTTL(ctx, "my-key").Return("my-value", time.Hour, nil)
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2, cache3},
+		WithLayerOptions(0, WithLayerReadRepair(false)),
+	)
+	assert.Nil(t, err)
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	cache.Flush(ctx)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestChainSetWithLayerOptions(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Set(ctx, "my-key", "my-value", &store.OptionsMatcher{
+		Expiration: 30 * time.Second,
+	}).Return(nil)
+
+	// Cache 2
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().Set(ctx, "my-key", "my-value", &store.OptionsMatcher{
+		Expiration: time.Hour,
+		Tags:       []string{"my-tag"},
+	}).Return(nil)
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithLayerOptions(0, WithLayerMaxTTL(30*time.Second), WithLayerTagPassthrough(false)),
+		WithLayerOptions(1, WithLayerDefaultTTL(time.Hour)),
+	)
+	assert.Nil(t, err)
+
+	// When
+	err = cache.Set(ctx, "my-key", "my-value", store.WithTags([]string{"my-tag"}))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestChainGetWhenLayersHaveTheSameStoreType(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return(nil, 0*time.Second,
+		errors.New("unable to find in cache 1"))
+	cache1.EXPECT().Set(context.Background(), "my-key", "my-value", &store.OptionsMatcher{
+		Expiration: time.Minute,
+	}).Return(nil)
+
+	// Cache 2
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetWithTTL(ctx, "my-key").Return(nil, 0*time.Second,
+		errors.New("unable to find in cache 2"))
+	cache2.EXPECT().Set(context.Background(), "my-key", "my-value", &store.OptionsMatcher{
+		Expiration: time.Minute,
+	}).Return(nil)
+
+	// Cache 3
+	cache3 := NewMockSetterCacheInterface[any](ctrl)
+	cache3.EXPECT().GetWithTTL(ctx, "my-key").Return("my-value", time.Minute, nil)
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2, cache3},
+		WithLayerOptions(0, WithLayerName("regional")),
+		WithLayerOptions(1, WithLayerName("global")),
+		WithLayerOptions(2, WithLayerName("database")),
+	)
+	assert.Nil(t, err)
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	cache.Flush(ctx)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+
+	assert.Equal(t, []ChainLayerStats{
+		{Name: "regional", Miss: 1, SetBack: 1},
+		{Name: "global", Miss: 1, SetBack: 1},
+		{Name: "database", Hits: 1},
+	}, cache.GetLayerStats())
+}
+
+func TestChainSetWhenErrorInNamedLayer(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Set(ctx, "my-key", "my-value").Return(nil)
+
+	// Cache 2
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().Set(ctx, "my-key", "my-value").Return(errors.New("an unexpected error occurred while setting data"))
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithLayerOptions(0, WithLayerName("regional")),
+		WithLayerOptions(1, WithLayerName("global")),
+	)
+	assert.Nil(t, err)
+
+	// When
+	err = cache.Set(ctx, "my-key", "my-value")
+
+	// Then
+	assert.EqualError(t, err, "error 1 of 1: Unable to set item into cache with store 'global': an unexpected error occurred while setting data")
+	assert.Equal(t, 1, cache.GetLayerStats()[1].SetError)
+}
+
+func TestChainGetLayerNames(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().GetType().Return("store1")
+
+	codec1 := codec.NewMockCodecInterface(ctrl)
+	codec1.EXPECT().GetStore().Return(store1)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetCodec().Return(codec1)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithLayerOptions(1, WithLayerName("global")),
+	)
+	assert.Nil(t, err)
+
+	// When - Then
+	assert.Equal(t, []string{"store1", "global"}, cache.GetLayerNames())
+}
+
+func TestNewChainWithOptionsWhenLayerNamesAreNotUnique(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+
+	// When
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithLayerOptions(0, WithLayerName("local")),
+		WithLayerOptions(1, WithLayerName("local")),
+	)
+
+	// Then
+	assert.Nil(t, cache)
+	assert.True(t, errors.Is(err, ErrDuplicateLayerName))
+	assert.EqualError(t, err, `the same name has been given to several cache layers: cache layers 0 and 1 are named "local"`)
+}
+
+func TestChainGetLayerNamesWhenStoreTypesAreShared(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().GetType().Return("redis")
+
+	codec1 := codec.NewMockCodecInterface(ctrl)
+	codec1.EXPECT().GetStore().Return(store1)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetCodec().Return(codec1)
+
+	store2 := store.NewMockStoreInterface(ctrl)
+	store2.EXPECT().GetType().Return("redis")
+
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().GetStore().Return(store2)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().Return(codec2)
+
+	store3 := store.NewMockStoreInterface(ctrl)
+	store3.EXPECT().GetType().Return("ristretto")
+
+	codec3 := codec.NewMockCodecInterface(ctrl)
+	codec3.EXPECT().GetStore().Return(store3)
+
+	cache3 := NewMockSetterCacheInterface[any](ctrl)
+	cache3.EXPECT().GetCodec().Return(codec3)
+
+	cache := NewChain[any](cache1, cache2, cache3)
+
+	// When - Then the names are resolved once
+	assert.Equal(t, []string{"redis#0", "redis#1", "ristretto"}, cache.GetLayerNames())
+	assert.Equal(t, "redis#1", cache.GetLayerStats()[1].Name)
+}
+
+func TestChainGetLayerNamesWhenStoreTypeIsGivenAsName(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+
+	store2 := store.NewMockStoreInterface(ctrl)
+	store2.EXPECT().GetType().Return("redis")
+
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().GetStore().Return(store2)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().Return(codec2)
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithLayerOptions(0, WithLayerName("redis")),
+	)
+	assert.Nil(t, err)
+
+	// When - Then
+	assert.Equal(t, []string{"redis", "redis#1"}, cache.GetLayerNames())
+}
+
+func TestChainSetWithTagPropagation(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	tagsItems := map[any]any{"gocache_tags_my-key": []byte(`["tag1"]`)}
+
+	// Cache 1
+	codec1 := codec.NewMockCodecInterface(ctrl)
+	codec1.EXPECT().SetMany(ctx, tagsItems, &store.OptionsMatcher{
+		Expiration: 30 * time.Second,
+		Tags:       []string{"tag1"},
+	}).Return(nil)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetCodec().Return(codec1)
+	cache1.EXPECT().Set(ctx, "my-key", "my-value", &store.OptionsMatcher{
+		Expiration: 30 * time.Second,
+		Tags:       []string{"tag1"},
+	}).Return(nil)
+
+	// Cache 2
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().SetMany(ctx, tagsItems, &store.OptionsMatcher{
+		Expiration: time.Hour,
+		Tags:       []string{"tag1"},
+	}).Return(nil)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().Return(codec2)
+	cache2.EXPECT().Set(ctx, "my-key", "my-value", &store.OptionsMatcher{
+		Expiration: time.Hour,
+		Tags:       []string{"tag1"},
+	}).Return(nil)
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithTagPropagation(),
+		WithLayerOptions(0, WithLayerMaxTTL(30*time.Second)),
+	)
+	assert.Nil(t, err)
+
+	// When
+	err = cache.Set(ctx, "my-key", "my-value", store.WithExpiration(time.Hour), store.WithTags([]string{"tag1"}))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestChainGetWhenAvailableInSecondCacheWithTagPropagation(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	codec1 := codec.NewMockCodecInterface(ctrl)
+	codec1.EXPECT().SetMany(context.Background(), map[any]any{"gocache_tags_my-key": []byte(`["tag1","tag2"]`)}, &store.OptionsMatcher{
+		Expiration: time.Minute,
+		Tags:       []string{"tag1", "tag2"},
+	}).Return(nil)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetCodec().Return(codec1)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return(nil, 0*time.Second,
+		errors.New("unable to find in cache 1"))
+	cache1.EXPECT().Set(context.Background(), "my-key", "my-value", &store.OptionsMatcher{
+		Expiration: time.Minute,
+		Tags:       []string{"tag1", "tag2"},
+	}).Return(nil)
+
+	// Cache 2
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().Get(context.Background(), "gocache_tags_my-key").Return(`["tag1","tag2"]`, nil)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().Return(codec2)
+	cache2.EXPECT().GetWithTTL(ctx, "my-key").Return("my-value", time.Minute, nil)
+
+	cache, err := NewChainWithOptions([]SetterCacheInterface[any]{cache1, cache2}, WithTagPropagation())
+	assert.Nil(t, err)
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	cache.Flush(ctx)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestChainGetManyWithTagPropagation(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	codec1 := codec.NewMockCodecInterface(ctrl)
+	codec1.EXPECT().SetMany(context.Background(), map[any]any{"gocache_tags_key1": []byte(`["tag1"]`)}, &store.OptionsMatcher{
+		Tags: []string{"tag1"},
+	}).Return(nil)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetCodec().Return(codec1)
+	cache1.EXPECT().Get(ctx, "key1").Return(nil, store.NotFound{})
+	cache1.EXPECT().Get(ctx, "key2").Return(nil, store.NotFound{})
+	cache1.EXPECT().Set(context.Background(), "key1", "value1", &store.OptionsMatcher{
+		Tags: []string{"tag1"},
+	}).Return(nil)
+	cache1.EXPECT().Set(context.Background(), "key2", "value2", &store.OptionsMatcher{}).Return(nil)
+
+	// Cache 2
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().GetMany(context.Background(), gomock.Any()).Return(map[any]any{
+		"gocache_tags_key1": []byte(`["tag1"]`),
+	}, nil)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().Return(codec2)
+	cache2.EXPECT().Get(ctx, "key1").Return("value1", nil)
+	cache2.EXPECT().Get(ctx, "key2").Return("value2", nil)
+
+	cache, err := NewChainWithOptions([]SetterCacheInterface[any]{cache1, cache2}, WithTagPropagation())
+	assert.Nil(t, err)
+
+	// When
+	values, err := cache.GetMany(ctx, []any{"key1", "key2"})
+
+	cache.Flush(ctx)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key1": "value1", "key2": "value2"}, values)
+}
+
+func TestChainDeleteWithTagPropagation(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	codec1 := codec.NewMockCodecInterface(ctrl)
+	codec1.EXPECT().Delete(ctx, "gocache_tags_my-key").Return(nil)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetCodec().Return(codec1)
+	cache1.EXPECT().Delete(ctx, "my-key").Return(nil)
+
+	// Cache 2
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().Delete(ctx, "gocache_tags_my-key").Return(nil)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().Return(codec2)
+	cache2.EXPECT().Delete(ctx, "my-key").Return(nil)
+
+	cache, err := NewChainWithOptions([]SetterCacheInterface[any]{cache1, cache2}, WithTagPropagation())
+	assert.Nil(t, err)
+
+	// When
+	err = cache.Delete(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestChainSetBackWhenDeletedAfterRead(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Delete(ctx, "my-key").Return(nil)
+
+	// Cache 2
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().Delete(ctx, "my-key").Return(nil)
+
+	cache := NewChain[any](cache1, cache2)
+
+	item := &chainKeyValue[any]{
+		key:      "my-key",
+		value:    "my-value",
+		layer:    1,
+		sequence: cache.tombstones.current(),
+		readAt:   time.Now(),
+	}
+
+	assert.Nil(t, cache.Delete(ctx, "my-key"))
+
+	// When - Then (the value is not set back in cache 1)
+	cache.setBack(item)
+}
+
+func TestChainSetBackWhenInvalidatedAfterRead(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Invalidate(ctx, gomock.Any()).Return(nil)
+
+	// Cache 2
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().Invalidate(ctx, gomock.Any()).Return(nil)
+
+	cache := NewChain[any](cache1, cache2)
+
+	item := &chainKeyValue[any]{
+		key:      "my-key",
+		value:    "my-value",
+		layer:    1,
+		sequence: cache.tombstones.current(),
+		readAt:   time.Now(),
+	}
+
+	assert.Nil(t, cache.Invalidate(ctx, store.WithInvalidateTags([]string{"tag1"})))
+
+	// When - Then (the value is not set back in cache 1)
+	cache.setBack(item)
+}
+
+func TestChainSetBackWhenWrittenWithWriteInvalidateAfterRead(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Delete(ctx, "my-key").Return(nil)
+
+	// Cache 2
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().Set(ctx, "my-key", "my-new-value").Return(nil)
+
+	cache, err := NewChainWithOptions([]SetterCacheInterface[any]{cache1, cache2}, WithWritePolicy(WriteInvalidate))
+	assert.Nil(t, err)
+
+	item := &chainKeyValue[any]{
+		key:      "my-key",
+		value:    "my-value",
+		layer:    1,
+		sequence: cache.tombstones.current(),
+		readAt:   time.Now(),
+	}
+
+	assert.Nil(t, cache.Set(ctx, "my-key", "my-new-value"))
+
+	// When - Then (the old value is not set back in cache 1)
+	cache.setBack(item)
+}
+
+func TestChainSetBackWhenReadAfterDeletion(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Delete(ctx, "my-key").Return(nil)
+	cache1.EXPECT().Set(context.Background(), "my-key", "my-value", &store.OptionsMatcher{
+		Expiration: time.Minute,
+	}).Return(nil)
+
+	// Cache 2
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().Delete(ctx, "my-key").Return(nil)
+
+	cache := NewChain[any](cache1, cache2)
+
+	assert.Nil(t, cache.Delete(ctx, "my-key"))
+
+	item := &chainKeyValue[any]{
+		key:      "my-key",
+		value:    "my-value",
+		ttl:      time.Minute,
+		layer:    1,
+		sequence: cache.tombstones.current(),
+		readAt:   time.Now(),
+	}
+
+	// When - Then (the value is set back in cache 1)
+	cache.setBack(item)
+}
+
+func TestChainSetBackManyWhenSomeKeysAreDeletedAfterRead(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Delete(ctx, "key1").Return(nil)
+	cache1.EXPECT().Set(context.Background(), "key2", "value2", &store.OptionsMatcher{}).Return(nil)
+
+	// Cache 2
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().Delete(ctx, "key1").Return(nil)
+
+	cache := NewChain[any](cache1, cache2)
+
+	item := &chainKeyValue[any]{
+		items:    map[any]any{"key1": "value1", "key2": "value2"},
+		layer:    1,
+		sequence: cache.tombstones.current(),
+		readAt:   time.Now(),
+	}
+
+	assert.Nil(t, cache.DeleteMany(ctx, []any{"key1"}))
+
+	// When - Then (only key2 is set back in cache 1)
+	cache.setBack(item)
+}
+
+func TestChainDeleteWithWriteBehind(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Set(ctx, "my-key", "my-value").Return(nil)
+	cache1.EXPECT().Delete(ctx, "my-key").Return(nil)
+
+	// Cache 2
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	gomock.InOrder(
+		cache2.EXPECT().Set(context.Background(), "my-key", "my-value").DoAndReturn(func(_ context.Context, _ any, _ any, _ ...store.Option) error {
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		}),
+		cache2.EXPECT().Delete(ctx, "my-key").Return(nil),
+	)
+
+	cache, err := NewChainWithOptions([]SetterCacheInterface[any]{cache1, cache2}, WithWritePolicy(WriteBehind))
+	assert.Nil(t, err)
+
+	assert.Nil(t, cache.Set(ctx, "my-key", "my-value"))
+
+	// When
+	err = cache.Delete(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestChainGetWithParallelReads(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1 answers after cache 2 but has the priority
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(gomock.Any(), "my-key").DoAndReturn(func(_ context.Context, _ any) (any, time.Duration, error) {
+		time.Sleep(20 * time.Millisecond)
+		return "value-1", time.Minute, nil
+	})
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetWithTTL(gomock.Any(), "my-key").Return("value-2", time.Minute, nil)
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithParallelReads(),
+	)
+	assert.Nil(t, err)
+
+	// When
+	value, ttl, err := cache.GetWithTTL(ctx, "my-key")
+
+	cache.Flush(ctx)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "value-1", value)
+	assert.Equal(t, time.Minute, ttl)
+}
+
+func TestChainGetWithParallelReadsWhenAvailableInSecondCache(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(gomock.Any(), "my-key").Return(nil, 0*time.Second,
+		errors.New("unable to find in cache 1"))
+	cache1.EXPECT().Set(ctx, "my-key", "my-value", &store.OptionsMatcher{
+		Expiration: time.Minute,
+	}).Return(nil)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetWithTTL(gomock.Any(), "my-key").Return("my-value", time.Minute, nil)
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithParallelReads(),
+	)
+	assert.Nil(t, err)
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	cache.Flush(ctx)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestChainGetWithParallelReadsWhenNotAvailableInAnyCache(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().GetType().AnyTimes().Return("store1")
+
+	codec1 := codec.NewMockCodecInterface(ctrl)
+	codec1.EXPECT().GetStore().AnyTimes().Return(store1)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetCodec().AnyTimes().Return(codec1)
+	cache1.EXPECT().GetWithTTL(gomock.Any(), "my-key").Return(nil, 0*time.Second,
+		errors.New("unable to find in cache 1"))
+
+	store2 := store.NewMockStoreInterface(ctrl)
+	store2.EXPECT().GetType().AnyTimes().Return("store2")
+
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().GetStore().AnyTimes().Return(store2)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().AnyTimes().Return(codec2)
+	cache2.EXPECT().GetWithTTL(gomock.Any(), "my-key").Return(nil, 0*time.Second,
+		errors.New("unable to find in cache 2"))
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithParallelReads(),
+	)
+	assert.Nil(t, err)
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, value)
+	assert.EqualError(t, err, "error 1 of 2: Unable to get item from cache with store 'store1': unable to find in cache 1; "+
+		"error 2 of 2: Unable to get item from cache with store 'store2': unable to find in cache 2")
+}
+
+func TestChainGetWithHedgedReads(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1 does not answer before the read is done
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(gomock.Any(), "my-key").DoAndReturn(func(ctx context.Context, _ any) (any, time.Duration, error) {
+		<-ctx.Done()
+		return nil, 0, ctx.Err()
+	})
+	cache1.EXPECT().Set(ctx, "my-key", "my-value", &store.OptionsMatcher{
+		Expiration: time.Minute,
+	}).Return(nil)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetWithTTL(gomock.Any(), "my-key").Return("my-value", time.Minute, nil)
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithHedgedReads(10*time.Millisecond),
+	)
+	assert.Nil(t, err)
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	cache.Flush(ctx)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestChainGetWithHedgedReadsWhenAvailableInFirstCache(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(gomock.Any(), "my-key").Return("my-value", time.Minute, nil)
+
+	// Cache 2 is not queried as cache 1 answers before the hedge delay
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithHedgedReads(time.Second),
+	)
+	assert.Nil(t, err)
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	cache.Flush(ctx)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestChainGetWithHedgedReadsWhenNotAvailableInAnyCache(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().GetType().AnyTimes().Return("store1")
+
+	codec1 := codec.NewMockCodecInterface(ctrl)
+	codec1.EXPECT().GetStore().AnyTimes().Return(store1)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetCodec().AnyTimes().Return(codec1)
+	cache1.EXPECT().GetWithTTL(gomock.Any(), "my-key").Return(nil, 0*time.Second,
+		errors.New("unable to find in cache 1"))
+
+	store2 := store.NewMockStoreInterface(ctrl)
+	store2.EXPECT().GetType().AnyTimes().Return("store2")
+
+	codec2 := codec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().GetStore().AnyTimes().Return(store2)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().AnyTimes().Return(codec2)
+	cache2.EXPECT().GetWithTTL(gomock.Any(), "my-key").Return(nil, 0*time.Second,
+		errors.New("unable to find in cache 2"))
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithHedgedReads(time.Second),
+	)
+	assert.Nil(t, err)
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, value)
+	assert.EqualError(t, err, "error 1 of 2: Unable to get item from cache with store 'store1': unable to find in cache 1; "+
+		"error 2 of 2: Unable to get item from cache with store 'store2': unable to find in cache 2")
+}
+
+func TestChainGetWhenLayerTimeoutIsReached(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(gomock.Any(), "my-key").DoAndReturn(func(ctx context.Context, _ any) (any, time.Duration, error) {
+		<-ctx.Done()
+		return nil, 0, ctx.Err()
+	})
+	cache1.EXPECT().Set(ctx, "my-key", "my-value", &store.OptionsMatcher{
+		Expiration: time.Minute,
+	}).Return(nil)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetWithTTL(ctx, "my-key").Return("my-value", time.Minute, nil)
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithLayerOptions(0, WithLayerName("slow"), WithLayerTimeout(10*time.Millisecond)),
+		WithLayerOptions(1, WithLayerName("fast")),
+	)
+	assert.Nil(t, err)
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	cache.Flush(ctx)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, 1, cache.GetLayerStats()[0].Miss)
+}
+
+func TestChainSetWithFailFastErrorPolicy(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Set(ctx, "my-key", "my-value").Return(errors.New("an unexpected error occurred while setting data"))
+
+	// Cache 2 is not written as cache 1 failed
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithErrorPolicy(ErrorFailFast),
+		WithLayerOptions(0, WithLayerName("local")),
+	)
+	assert.Nil(t, err)
+
+	// When
+	err = cache.Set(ctx, "my-key", "my-value")
+
+	// Then
+	assert.EqualError(t, err, "error 1 of 1: Unable to set item into cache with store 'local': an unexpected error occurred while setting data")
+}
+
+func TestChainDeleteWithRequiredSuccesses(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Delete(ctx, "my-key").Return(errors.New("an error has occurred while deleting key"))
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().Delete(ctx, "my-key").Return(nil)
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithRequiredSuccesses(1),
+		WithLayerOptions(0, WithLayerName("local")),
+	)
+	assert.Nil(t, err)
+
+	// When
+	err = cache.Delete(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestChainDeleteWhenRequiredSuccessesAreNotReached(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Delete(ctx, "my-key").Return(errors.New("an error has occurred while deleting key"))
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().Delete(ctx, "my-key").Return(nil)
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithRequiredSuccesses(2),
+		WithLayerOptions(0, WithLayerName("local")),
+	)
+	assert.Nil(t, err)
+
+	// When
+	err = cache.Delete(ctx, "my-key")
+
+	// Then
+	var chainErr *ChainError
+	assert.True(t, errors.As(err, &chainErr))
+	assert.Len(t, chainErr.Errors, 1)
+	assert.Equal(t, "local", chainErr.Errors[0].Name)
+	assert.Equal(t, ChainOperationDelete, chainErr.Errors[0].Operation)
+}
+
+type bulkSetterCache struct {
+	*MockSetterCacheInterface[any]
+	*MockBulkCacheInterface[any]
+}
+
+func TestChainGetManyWhenErrorInCache(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	layerErr := errors.New("connection refused")
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "key1").Return("value1", nil)
+	cache1.EXPECT().Get(ctx, "key2").Return(nil, store.NotFound{})
+
+	// Cache 2 supports bulk operations
+	bulk2 := NewMockBulkCacheInterface[any](ctrl)
+	bulk2.EXPECT().GetMany(ctx, []any{"key2"}).Return(nil, layerErr)
+
+	cache2 := &bulkSetterCache{NewMockSetterCacheInterface[any](ctrl), bulk2}
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithLayerOptions(1, WithLayerName("remote")),
+	)
+	assert.Nil(t, err)
+
+	// When
+	values, err := cache.GetMany(ctx, []any{"key1", "key2"})
+
+	// Then
+	assert.Equal(t, map[any]any{"key1": "value1"}, values)
+	assert.True(t, errors.Is(err, layerErr))
+
+	var chainErr *ChainError
+	assert.True(t, errors.As(err, &chainErr))
+	assert.Equal(t, 1, chainErr.Errors[0].Layer)
+	assert.Equal(t, []any{"key2"}, chainErr.Errors[0].Keys)
+}
+
+func TestChainGetWhenAvailableInSecondCacheWithPromoteNever(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1 is not set back
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return(nil, 0*time.Second,
+		errors.New("unable to find in cache 1"))
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetWithTTL(ctx, "my-key").Return("my-value", time.Minute, nil)
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithPromotionPolicy(PromoteNever()),
+	)
+	assert.Nil(t, err)
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	cache.Flush(ctx)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestChainGetWhenAvailableInSecondCacheWithPromoteAfterHits(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Times(2).Return(nil, 0*time.Second,
+		errors.New("unable to find in cache 1"))
+
+	// Cache 1 is only set back on the second hit
+	cache1.EXPECT().Set(ctx, "my-key", "my-value", &store.OptionsMatcher{
+		Expiration: time.Minute,
+	}).Return(nil)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetWithTTL(ctx, "my-key").Times(2).Return("my-value", time.Minute, nil)
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithPromotionPolicy(PromoteAfterHits(2, time.Minute)),
+	)
+	assert.Nil(t, err)
+
+	// When
+	cache.Get(ctx, "my-key")
+	cache.Flush(ctx)
+
+	value, err := cache.Get(ctx, "my-key")
+	cache.Flush(ctx)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestChainGetManyWithPromotionPolicy(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "key1").Return(nil, store.NotFound{})
+	cache1.EXPECT().Get(ctx, "key2").Return(nil, store.NotFound{})
+
+	// Only the hot key is set back
+	cache1.EXPECT().Set(ctx, "key1", "value1").Return(nil)
+
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().Get(ctx, "key1").Return("value1", nil)
+	cache2.EXPECT().Get(ctx, "key2").Return("value2", nil)
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{cache1, cache2},
+		WithPromotionPolicy(&keysPromotion{keys: map[any]bool{"key1": true}}),
+	)
+	assert.Nil(t, err)
+
+	// When
+	values, err := cache.GetMany(ctx, []any{"key1", "key2"})
+
+	cache.Flush(ctx)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key1": "value1", "key2": "value2"}, values)
+}
+
+// keysPromotion promotes the given keys only
+type keysPromotion struct {
+	keys map[any]bool
+}
+
+func (p *keysPromotion) ShouldPromote(key any, layer int) bool {
+	return p.keys[key]
+}
+
+type tagIndexSetterCache struct {
+	*MockSetterCacheInterface[any]
+	*MockTagIndexCacheInterface
+}
+
+func TestChainKeysForTag(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	tagIndex1 := NewMockTagIndexCacheInterface(ctrl)
+	tagIndex1.EXPECT().KeysForTag(ctx, "tag1").Return([]string{"key1", "key2"}, nil)
+
+	// Cache 2 does not keep an index of its tags
+	cache2 := NewMockSetterCacheInterface[any](ctrl)
+
+	tagIndex3 := NewMockTagIndexCacheInterface(ctrl)
+	tagIndex3.EXPECT().KeysForTag(ctx, "tag1").Return([]string{"key2", "key3"}, nil)
+
+	tagIndex4 := NewMockTagIndexCacheInterface(ctrl)
+	tagIndex4.EXPECT().KeysForTag(ctx, "tag1").Return(nil, store.NotSupportedOperation("mock", "KeysForTag"))
+
+	cache := NewChain[any](
+		&tagIndexSetterCache{NewMockSetterCacheInterface[any](ctrl), tagIndex1},
+		cache2,
+		&tagIndexSetterCache{NewMockSetterCacheInterface[any](ctrl), tagIndex3},
+		&tagIndexSetterCache{NewMockSetterCacheInterface[any](ctrl), tagIndex4},
+	)
+
+	// When
+	keys, err := cache.KeysForTag(ctx, "tag1")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"key1", "key2", "key3"}, keys)
+}
+
+func TestChainKeysForTagWhenNotSupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache := NewChain[any](NewMockSetterCacheInterface[any](ctrl))
+
+	// When
+	keys, err := cache.KeysForTag(ctx, "tag1")
+
+	// Then
+	assert.Nil(t, keys)
+
+	var notSupported *store.NotSupported
+	assert.True(t, errors.As(err, &notSupported))
+	assert.Equal(t, ChainType, notSupported.StoreType())
+	assert.Equal(t, "KeysForTag", notSupported.Operation())
+}
+
+func TestChainTagsForKeyWhenErrorInCache(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	layerErr := errors.New("connection refused")
+
+	tagIndex1 := NewMockTagIndexCacheInterface(ctrl)
+	tagIndex1.EXPECT().TagsForKey(ctx, "my-key").Return([]string{"tag1"}, nil)
+
+	tagIndex2 := NewMockTagIndexCacheInterface(ctrl)
+	tagIndex2.EXPECT().TagsForKey(ctx, "my-key").Return(nil, layerErr)
+
+	cache, err := NewChainWithOptions(
+		[]SetterCacheInterface[any]{
+			&tagIndexSetterCache{NewMockSetterCacheInterface[any](ctrl), tagIndex1},
+			&tagIndexSetterCache{NewMockSetterCacheInterface[any](ctrl), tagIndex2},
+		},
+		WithLayerOptions(1, WithLayerName("remote")),
+	)
+	assert.Nil(t, err)
+
+	// When
+	tags, err := cache.TagsForKey(ctx, "my-key")
+
+	// Then the tags of the available layers are returned along with the error
+	assert.Equal(t, []string{"tag1"}, tags)
+	assert.True(t, errors.Is(err, layerErr))
+
+	var chainErr *ChainError
+	assert.True(t, errors.As(err, &chainErr))
+	assert.Equal(t, "remote", chainErr.Errors[0].Name)
+	assert.Equal(t, ChainOperationInspectTags, chainErr.Errors[0].Operation)
+	assert.Equal(t, []any{"my-key"}, chainErr.Errors[0].Keys)
 }
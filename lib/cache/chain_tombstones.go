@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// chainTombstoneTTL is the duration during which removed keys are remembered.
+// Values waiting longer than that to be set back are dropped.
+const chainTombstoneTTL = time.Minute
+
+type chainTombstone struct {
+	sequence uint64
+	at       time.Time
+}
+
+// chainTombstones keeps track of the keys recently removed from a chain cache, so the
+// values read before their removal are not set back in the cache layers afterwards
+type chainTombstones struct {
+	// sequence is accessed atomically and has to stay 64-bit aligned
+	sequence      uint64
+	mu            sync.Mutex
+	keys          map[string]chainTombstone
+	invalidatedAt uint64
+	lastPrune     time.Time
+}
+
+func newChainTombstones() *chainTombstones {
+	return &chainTombstones{
+		keys:      make(map[string]chainTombstone),
+		lastPrune: time.Now(),
+	}
+}
+
+// current returns the sequence to attach to the values read from now on
+func (t *chainTombstones) current() uint64 {
+	return atomic.LoadUint64(&t.sequence)
+}
+
+// add records the removal of the given keys
+func (t *chainTombstones) add(keys []any) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	sequence := atomic.AddUint64(&t.sequence, 1)
+
+	for _, key := range keys {
+		t.keys[getCacheKey(key)] = chainTombstone{sequence: sequence, at: now}
+	}
+
+	if now.Sub(t.lastPrune) > chainTombstoneTTL {
+		for key, tombstone := range t.keys {
+			if now.Sub(tombstone.at) > chainTombstoneTTL {
+				delete(t.keys, key)
+			}
+		}
+		t.lastPrune = now
+	}
+}
+
+// addAll records the removal of all the keys
+func (t *chainTombstones) addAll() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.invalidatedAt = atomic.AddUint64(&t.sequence, 1)
+}
+
+// isRemoved returns true if the given key has been removed after a value has been
+// read for it with the given sequence at the given time
+func (t *chainTombstones) isRemoved(key any, sequence uint64, readAt time.Time) bool {
+	if time.Since(readAt) > chainTombstoneTTL {
+		return true
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.invalidatedAt > sequence {
+		return true
+	}
+
+	tombstone, ok := t.keys[getCacheKey(key)]
+
+	return ok && tombstone.sequence > sequence
+}
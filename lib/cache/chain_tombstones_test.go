@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainTombstonesIsRemoved(t *testing.T) {
+	// Given
+	tombstones := newChainTombstones()
+
+	before := tombstones.current()
+	tombstones.add([]any{"key1"})
+	after := tombstones.current()
+
+	// When - Then
+	assert.True(t, tombstones.isRemoved("key1", before, time.Now()))
+	assert.False(t, tombstones.isRemoved("key1", after, time.Now()))
+	assert.False(t, tombstones.isRemoved("key2", before, time.Now()))
+}
+
+func TestChainTombstonesIsRemovedWhenAllKeysAreRemoved(t *testing.T) {
+	// Given
+	tombstones := newChainTombstones()
+
+	before := tombstones.current()
+	tombstones.addAll()
+	after := tombstones.current()
+
+	// When - Then
+	assert.True(t, tombstones.isRemoved("key1", before, time.Now()))
+	assert.False(t, tombstones.isRemoved("key1", after, time.Now()))
+}
+
+func TestChainTombstonesIsRemovedWhenReadIsTooOld(t *testing.T) {
+	// Given
+	tombstones := newChainTombstones()
+
+	// When - Then
+	assert.True(t, tombstones.isRemoved("key1", tombstones.current(), time.Now().Add(-2*chainTombstoneTTL)))
+}
+
+func TestChainTombstonesAddPrunesExpiredTombstones(t *testing.T) {
+	// Given
+	tombstones := newChainTombstones()
+	tombstones.keys["key1"] = chainTombstone{sequence: 1, at: time.Now().Add(-2 * chainTombstoneTTL)}
+	tombstones.lastPrune = time.Now().Add(-2 * chainTombstoneTTL)
+
+	// When
+	tombstones.add([]any{"key2"})
+
+	// Then
+	assert.Len(t, tombstones.keys, 1)
+	assert.Contains(t, tombstones.keys, "key2")
+}
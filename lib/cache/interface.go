@@ -18,6 +18,31 @@ type CacheInterface[T any] interface {
 	GetType() string
 }
 
+// BulkCacheInterface represents the interface for caches able to get, set or
+// delete several keys at once
+type BulkCacheInterface[T any] interface {
+	GetMany(ctx context.Context, keys []any) (map[any]T, error)
+	SetMany(ctx context.Context, items map[any]T, options ...store.Option) error
+	DeleteMany(ctx context.Context, keys []any) error
+}
+
+// AtomicCacheInterface represents the interface for caches able to update
+// counters and conditionally write items atomically
+type AtomicCacheInterface[T any] interface {
+	Increment(ctx context.Context, key any, delta int64) (int64, error)
+	Decrement(ctx context.Context, key any, delta int64) (int64, error)
+	SetIfNotExists(ctx context.Context, key any, object T, options ...store.Option) (bool, error)
+	CompareAndSwap(ctx context.Context, key any, oldObject T, newObject T, options ...store.Option) (bool, error)
+	CompareAndDelete(ctx context.Context, key any, oldObject T) (bool, error)
+}
+
+// TagIndexCacheInterface represents the interface for caches able to tell
+// which keys are associated to a tag and which tags to a key
+type TagIndexCacheInterface interface {
+	KeysForTag(ctx context.Context, tag string) ([]string, error)
+	TagsForKey(ctx context.Context, key any) ([]string, error)
+}
+
 type CacheKeyGenerator interface {
 	GetCacheKey() string
 }
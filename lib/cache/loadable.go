@@ -2,103 +2,943 @@ package cache
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
+	"time"
 
+	"github.com/eko/gocache/lib/v4/codec"
+	"github.com/eko/gocache/lib/v4/lock"
 	"github.com/eko/gocache/lib/v4/store"
 )
 
 const (
 	// LoadableType represents the loadable cache type as a string value
 	LoadableType = "loadable"
+	// LoadableStaleKeyPattern represents the key pattern used to keep a copy of loaded values
+	// during the stale-if-error grace window
+	LoadableStaleKeyPattern = "gocache_stale_%s"
+	// LoadableNotFoundKeyPattern represents the key pattern used to cache not found results
+	LoadableNotFoundKeyPattern = "gocache_not_found_%s"
+	// LoadableNotFoundMarker represents the value stored for not found results
+	LoadableNotFoundMarker = "gocache_not_found"
 )
 
+// ErrNegativelyCached is the cause of the not found error returned for keys that
+// the load function previously reported as absent
+var ErrNegativelyCached = errors.New("value has been cached as not found")
+
 type loadableKeyValue[T any] struct {
-	key   any
-	value T
+	key      any
+	value    T
+	options  []store.Option
+	notFound bool
+	items    map[any]T
 }
 
 type LoadFunction[T any] func(ctx context.Context, key any) (T, error)
 
+// LoadWithOptionsFunction loads the value of the given key along with the store
+// options (expiration, tags, ...) to use when putting it back in cache. The returned
+// expiration is replaced by the hard TTL when WithStaleWhileRevalidate is used.
+type LoadWithOptionsFunction[T any] func(ctx context.Context, key any) (T, []store.Option, error)
+
+// BatchLoadFunction loads values of the given keys at once. Keys missing from
+// the returned map are considered as not found.
+type BatchLoadFunction[T any] func(ctx context.Context, keys []any) (map[any]T, error)
+
+// LoadableStats allows to returns some statistics of loadable cache usage
+type LoadableStats struct {
+	LoadSuccess      int
+	LoadError        int
+	LoadDeduplicated int
+	Refresh          int
+	StaleServed      int
+	NegativeHit      int
+	LockWait         int
+	LockFallback     int
+}
+
+// StaleValueError is returned along with a previously loaded value when the load
+// function fails during the stale-if-error grace window
+type StaleValueError struct {
+	Err error
+}
+
+func (e *StaleValueError) Error() string {
+	return fmt.Sprintf("stale value returned, load function failed: %v", e.Err)
+}
+
+func (e *StaleValueError) Unwrap() error { return e.Err }
+
+// loadableStaleKey is the key used to keep a copy of a loaded value
+type loadableStaleKey struct {
+	key any
+}
+
+func (k loadableStaleKey) GetCacheKey() string {
+	return fmt.Sprintf(LoadableStaleKeyPattern, getCacheKey(k.key))
+}
+
+// loadableNotFoundKey is the key used to cache a not found result
+type loadableNotFoundKey struct {
+	key any
+}
+
+func (k loadableNotFoundKey) GetCacheKey() string {
+	return fmt.Sprintf(LoadableNotFoundKeyPattern, getCacheKey(k.key))
+}
+
+// ttlCacheInterface represents a cache able to return the remaining TTL of its items
+type ttlCacheInterface[T any] interface {
+	GetWithTTL(ctx context.Context, key any) (T, time.Duration, error)
+}
+
 // LoadableCache represents a cache that uses a function to load data
 type LoadableCache[T any] struct {
-	loadFunc   LoadFunction[T]
-	cache      CacheInterface[T]
-	setChannel chan *loadableKeyValue[T]
-	setterWg   *sync.WaitGroup
+	loadFunc            LoadFunction[T]
+	loadWithOptionsFunc LoadWithOptionsFunction[T]
+	batchLoadFunc       BatchLoadFunction[T]
+	cache               CacheInterface[T]
+	setter              *asyncSetter[loadableKeyValue[T]]
+	refreshWg           sync.WaitGroup
+	closeMtx            sync.RWMutex
+	closed              bool
+	loadGroup           *loadGroup[T]
+	stats               *LoadableStats
+	statsMtx            sync.Mutex
+	options             *LoadableOptions
 }
 
 // NewLoadable instanciates a new cache that uses a function to load data
-func NewLoadable[T any](loadFunc LoadFunction[T], cache CacheInterface[T]) *LoadableCache[T] {
+func NewLoadable[T any](loadFunc LoadFunction[T], cache CacheInterface[T], options ...LoadableOption) *LoadableCache[T] {
 	loadable := &LoadableCache[T]{
-		loadFunc:   loadFunc,
-		cache:      cache,
-		setChannel: make(chan *loadableKeyValue[T], 10000),
-		setterWg:   &sync.WaitGroup{},
+		loadFunc: loadFunc,
+		loadWithOptionsFunc: func(ctx context.Context, key any) (T, []store.Option, error) {
+			object, err := loadFunc(ctx, key)
+			return object, nil, err
+		},
+		cache:     cache,
+		options:   applyLoadableOptions(options...),
+		loadGroup: newLoadGroup[T](),
+		stats:     &LoadableStats{},
 	}
 
-	loadable.setterWg.Add(1)
-	go loadable.setter()
+	loadable.setter = newAsyncSetter(loadable.options.SetBackQueue, func(item *loadableKeyValue[T]) {
+		loadable.apply(context.Background(), item)
+	})
+
+	return loadable
+}
+
+// NewLoadableWithOptions instanciates a new cache that uses a function to load data
+// and the store options to use when putting it back in cache
+func NewLoadableWithOptions[T any](loadFunc LoadWithOptionsFunction[T], cache CacheInterface[T], options ...LoadableOption) *LoadableCache[T] {
+	loadable := NewLoadable(func(ctx context.Context, key any) (T, error) {
+		object, _, err := loadFunc(ctx, key)
+		return object, err
+	}, cache, options...)
+	loadable.loadWithOptionsFunc = loadFunc
 
 	return loadable
 }
 
-func (c *LoadableCache[T]) setter() {
-	defer c.setterWg.Done()
+// NewBatchLoadable instanciates a new cache that uses a function to load data of
+// several keys at once. Get calls load their single key using this function.
+func NewBatchLoadable[T any](batchLoadFunc BatchLoadFunction[T], cache CacheInterface[T], options ...LoadableOption) *LoadableCache[T] {
+	loadFunc := func(ctx context.Context, key any) (T, error) {
+		objects, err := batchLoadFunc(ctx, []any{key})
+		if err != nil {
+			return *new(T), err
+		}
 
-	for item := range c.setChannel {
-		c.Set(context.Background(), item.key, item.value)
+		object, ok := objects[key]
+		if !ok {
+			return *new(T), store.NotFoundWithCause(errors.New("value not returned by batch load function"))
+		}
+
+		return object, nil
 	}
+
+	loadable := NewLoadable(loadFunc, cache, options...)
+	loadable.batchLoadFunc = batchLoadFunc
+
+	return loadable
+}
+
+// apply puts the given loaded item back in cache
+func (c *LoadableCache[T]) apply(ctx context.Context, item *loadableKeyValue[T]) {
+	if item.notFound {
+		c.setNotFound(ctx, item.key)
+		return
+	}
+
+	if item.items != nil {
+		c.setMany(ctx, item.items, item.options...)
+		return
+	}
+
+	c.set(ctx, item.key, item.value, item.options...)
 }
 
 // Get returns the object stored in cache if it exists
 func (c *LoadableCache[T]) Get(ctx context.Context, key any) (T, error) {
-	var err error
+	if c.isClosed() {
+		return *new(T), ErrClosed
+	}
+
+	if ttlCache, ok := c.cache.(ttlCacheInterface[T]); ok && c.options.isRefreshAheadEnabled() {
+		return c.getWithRefresh(ctx, ttlCache, key)
+	}
+
+	return c.get(ctx, key)
+}
 
+// get returns the object stored in cache if it exists, or loads it otherwise
+func (c *LoadableCache[T]) get(ctx context.Context, key any) (T, error) {
 	object, err := c.cache.Get(ctx, key)
 	if err == nil {
 		return object, err
 	}
 
 	// Unable to find in cache, try to load it from load function
-	object, err = c.loadFunc(ctx, key)
+	return c.load(ctx, key)
+}
+
+// GetMany returns the objects of the given keys, stored in cache or loaded at once
+// for the missing ones, using the batch load function if any. Keys that cannot be
+// found are absent from the returned map. An error is returned along with the
+// objects found so far when loading fails. The loadable options apply as for Get:
+// concurrent loads of the same keys are shared, the distributed lock is used and
+// stale values are returned along with a *StaleValueError.
+func (c *LoadableCache[T]) GetMany(ctx context.Context, keys []any) (map[any]T, error) {
+	if c.isClosed() {
+		return nil, ErrClosed
+	}
+
+	objects, err := getMany(ctx, c.cache, keys)
+	if err != nil {
+		objects = make(map[any]T, len(keys))
+	}
+
+	missingKeys := make([]any, 0, len(keys))
+	seenKeys := make(map[any]struct{}, len(keys))
+
+	for _, key := range keys {
+		if _, ok := objects[key]; ok {
+			continue
+		}
+		if _, ok := seenKeys[key]; ok {
+			continue
+		}
+		seenKeys[key] = struct{}{}
+
+		if c.options.NegativeTTL > 0 && c.isNotFound(ctx, key) {
+			c.statsMtx.Lock()
+			c.stats.NegativeHit++
+			c.statsMtx.Unlock()
+			continue
+		}
+
+		missingKeys = append(missingKeys, key)
+	}
+
+	if len(missingKeys) == 0 {
+		return objects, nil
+	}
+
+	// Unable to find them all in cache, try to load them from load function
+	results := c.loadMany(ctx, missingKeys)
+
+	var loadErr error
+	for _, key := range missingKeys {
+		result := results[key]
+
+		var staleErr *StaleValueError
+		if result.err == nil || errors.As(result.err, &staleErr) {
+			objects[key] = result.value
+		}
+		if result.err != nil && loadErr == nil && !isNotFoundError(result.err) {
+			loadErr = result.err
+		}
+	}
+
+	return objects, loadErr
+}
+
+// loadMany loads the given keys using the batch load function if any, or one by one
+// otherwise. As for Get, concurrent calls for the same keys share the load function
+// calls, the distributed lock is used and stale values are returned on errors.
+func (c *LoadableCache[T]) loadMany(ctx context.Context, keys []any) map[any]loadResult[T] {
+	results := make(map[any]loadResult[T], len(keys))
+
+	if c.batchLoadFunc == nil {
+		for _, key := range keys {
+			object, err := c.load(ctx, key)
+			results[key] = loadResult[T]{value: object, err: err}
+		}
+
+		return results
+	}
+
+	cacheKeys := make([]string, 0, len(keys))
+	keysByCacheKey := make(map[string]any, len(keys))
+	for _, key := range keys {
+		cacheKey := getCacheKey(key)
+		cacheKeys = append(cacheKeys, cacheKey)
+		keysByCacheKey[cacheKey] = key
+	}
+
+	groupResults := c.loadGroup.doMany(ctx, cacheKeys, func(cacheKeys []string) map[string]loadResult[T] {
+		batchKeys := make([]any, 0, len(cacheKeys))
+		for _, cacheKey := range cacheKeys {
+			batchKeys = append(batchKeys, keysByCacheKey[cacheKey])
+		}
+
+		batchResults := make(map[string]loadResult[T], len(batchKeys))
+		for key, result := range c.batchLoad(ctx, batchKeys) {
+			batchResults[getCacheKey(key)] = result
+		}
+
+		return batchResults
+	})
+
+	for cacheKey, result := range groupResults {
+		key := keysByCacheKey[cacheKey]
+
+		if result.shared {
+			if isContextError(result.err) && ctx.Err() == nil {
+				// The joined call may have been cancelled by its own caller
+				object, err := c.load(ctx, key)
+				result = loadResult[T]{value: object, err: err}
+			} else {
+				c.statsMtx.Lock()
+				c.stats.LoadDeduplicated++
+				c.statsMtx.Unlock()
+			}
+		}
+
+		results[key] = result
+	}
+
+	return results
+}
+
+// batchLoad calls the batch load function for the given keys. With a distributed lock,
+// loaded values are put back in cache before releasing the locks of their keys, and
+// the keys locked by other instances are loaded one by one once released, as by Get.
+func (c *LoadableCache[T]) batchLoad(ctx context.Context, keys []any) map[any]loadResult[T] {
+	if c.options.Locker == nil {
+		return c.callBatchLoadFunc(ctx, keys, c.setter.send)
+	}
+
+	keys, waitingKeys, locks := c.obtainLocks(ctx, keys)
+
+	results := func() map[any]loadResult[T] {
+		defer func() {
+			for _, l := range locks {
+				l.Release(context.Background())
+			}
+		}()
+
+		return c.callBatchLoadFunc(ctx, keys, func(item *loadableKeyValue[T]) {
+			c.apply(context.Background(), item)
+		})
+	}()
+
+	for _, key := range waitingKeys {
+		object, err := c.loadWithLock(ctx, key)
+		if err != nil && c.options.StaleIfErrorGrace > 0 && !isNotFoundError(err) {
+			object, err = c.getStale(ctx, key, err)
+		}
+
+		results[key] = loadResult[T]{value: object, err: err}
+	}
+
+	return results
+}
+
+// obtainLocks tries to obtain the distributed locks of the given keys, and returns
+// the keys to load along with the obtained locks, and the keys locked by other instances
+func (c *LoadableCache[T]) obtainLocks(ctx context.Context, keys []any) ([]any, []any, []*lock.Lock) {
+	loadKeys := make([]any, 0, len(keys))
+	locks := make([]*lock.Lock, 0, len(keys))
+
+	var waitingKeys []any
+	for _, key := range keys {
+		l, err := c.options.Locker.TryObtain(ctx, getCacheKey(key), c.options.LockTTL)
+		if errors.Is(err, lock.ErrNotObtained) {
+			waitingKeys = append(waitingKeys, key)
+			continue
+		}
+
+		// A lock which cannot be obtained does not prevent the value from being loaded
+		if err == nil {
+			locks = append(locks, l)
+		}
+		loadKeys = append(loadKeys, key)
+	}
+
+	return loadKeys, waitingKeys, locks
+}
+
+// callBatchLoadFunc calls the batch load function for the given keys and puts the loaded
+// values back in cache using the given function. Stale values are returned on errors.
+func (c *LoadableCache[T]) callBatchLoadFunc(ctx context.Context, keys []any, setBack func(item *loadableKeyValue[T])) map[any]loadResult[T] {
+	results := make(map[any]loadResult[T], len(keys))
+	if len(keys) == 0 {
+		return results
+	}
+
+	loaded, err := c.batchLoadFunc(ctx, keys)
+	c.recordLoad(err)
 	if err != nil {
+		for _, key := range keys {
+			if c.options.StaleIfErrorGrace > 0 && !isNotFoundError(err) {
+				object, staleErr := c.getStale(ctx, key, err)
+				results[key] = loadResult[T]{value: object, err: staleErr}
+				continue
+			}
+
+			results[key] = loadResult[T]{err: err}
+		}
+
+		return results
+	}
+
+	items := make(map[any]T, len(loaded))
+	for _, key := range keys {
+		object, ok := loaded[key]
+		if !ok {
+			if item := c.newSetBackItem(key, object, store.NotFound{}); item != nil {
+				setBack(item)
+			}
+			results[key] = loadResult[T]{err: store.NotFound{}}
+			continue
+		}
+
+		items[key] = object
+		results[key] = loadResult[T]{value: object}
+	}
+
+	// Then, put them back in cache at once
+	if len(items) > 0 {
+		setBack(&loadableKeyValue[T]{items: items, options: c.getSetBackOptions()})
+	}
+
+	return results
+}
+
+// getWithRefresh returns the object stored in cache if it exists and triggers
+// a background reload when it is older than the soft TTL. Values are read without
+// being refreshed when the store cannot return their TTL.
+func (c *LoadableCache[T]) getWithRefresh(ctx context.Context, cache ttlCacheInterface[T], key any) (T, error) {
+	object, ttl, err := cache.GetWithTTL(ctx, key)
+	if isNotFoundError(err) {
+		return c.load(ctx, key)
+	}
+	if err != nil {
+		return c.get(ctx, key)
+	}
+
+	if ttl > 0 && ttl <= c.options.HardTTL-c.options.SoftTTL && c.addRefresh() {
+		started := c.loadGroup.doInBackground(getCacheKey(key), func() (T, error) {
+			defer c.refreshWg.Done()
+
+			if c.options.Locker != nil {
+				l, err := c.options.Locker.TryObtain(context.Background(), getCacheKey(key), c.options.LockTTL)
+				if errors.Is(err, lock.ErrNotObtained) {
+					// Another instance is already refreshing the value
+					return object, nil
+				}
+				if err == nil {
+					defer l.Release(context.Background())
+				}
+			}
+
+			return c.loadAndSet(context.Background(), key)
+		})
+
+		if started {
+			c.statsMtx.Lock()
+			c.stats.Refresh++
+			c.statsMtx.Unlock()
+		} else {
+			c.refreshWg.Done()
+		}
+	}
+
+	return object, nil
+}
+
+// load calls the load function for the given key and puts the loaded value
+// back in cache. Concurrent calls for the same key share a single load function call.
+func (c *LoadableCache[T]) load(ctx context.Context, key any) (T, error) {
+	for {
+		object, shared, err := c.loadGroup.do(ctx, getCacheKey(key), func() (T, error) {
+			if c.options.NegativeTTL > 0 && c.isNotFound(ctx, key) {
+				c.statsMtx.Lock()
+				c.stats.NegativeHit++
+				c.statsMtx.Unlock()
+
+				return *new(T), store.NotFoundWithCause(ErrNegativelyCached)
+			}
+
+			var object T
+			var err error
+			if c.options.Locker != nil {
+				object, err = c.loadWithLock(ctx, key)
+			} else {
+				object, err = c.loadAndSet(ctx, key)
+			}
+			if err != nil && c.options.StaleIfErrorGrace > 0 && !isNotFoundError(err) {
+				return c.getStale(ctx, key, err)
+			}
+
+			return object, err
+		})
+
+		if !shared {
+			return object, err
+		}
+
+		if isContextError(err) {
+			// The joined call may have been cancelled by its own caller: retry it
+			// as long as the current context is still alive
+			if ctx.Err() == nil {
+				continue
+			}
+
+			return object, err
+		}
+
+		c.statsMtx.Lock()
+		c.stats.LoadDeduplicated++
+		c.statsMtx.Unlock()
+
 		return object, err
 	}
+}
 
-	// Then, put it back in cache
-	c.setChannel <- &loadableKeyValue[T]{key, object}
+// loadAndSet calls the load function and puts the loaded value back in cache
+func (c *LoadableCache[T]) loadAndSet(ctx context.Context, key any) (T, error) {
+	object, options, err := c.loadWithOptionsFunc(ctx, key)
+	c.recordLoad(err)
+
+	c.setBack(key, object, err, options...)
 
 	return object, err
 }
 
+// loadWithLock calls the load function only once the distributed lock is obtained for
+// the given key, and puts the loaded value back in cache before releasing it.
+// Otherwise, it waits for the value loaded by the lock holder to appear in cache and
+// falls back to calling the load function when it is not available before the wait timeout.
+func (c *LoadableCache[T]) loadWithLock(ctx context.Context, key any) (T, error) {
+	deadline := time.Now().Add(c.options.LockWaitTimeout)
+	waited := false
+
+	for {
+		l, err := c.options.Locker.TryObtain(ctx, getCacheKey(key), c.options.LockTTL)
+		if err == nil {
+			defer l.Release(context.Background())
+
+			if waited {
+				// The value may have been loaded by the previous lock holder
+				if object, err := c.cache.Get(ctx, key); err == nil {
+					return object, nil
+				}
+			}
+
+			object, options, err := c.loadWithOptionsFunc(ctx, key)
+			c.recordLoad(err)
+
+			if item := c.newSetBackItem(key, object, err, options...); item != nil {
+				c.apply(context.Background(), item)
+			}
+
+			return object, err
+		}
+
+		if !errors.Is(err, lock.ErrNotObtained) {
+			// The lock cannot be obtained, do not prevent the value from being loaded
+			return c.loadAndSet(ctx, key)
+		}
+
+		if !waited {
+			waited = true
+
+			c.statsMtx.Lock()
+			c.stats.LockWait++
+			c.statsMtx.Unlock()
+		}
+
+		if !time.Now().Before(deadline) {
+			c.statsMtx.Lock()
+			c.stats.LockFallback++
+			c.statsMtx.Unlock()
+
+			return c.loadAndSet(ctx, key)
+		}
+
+		timer := time.NewTimer(c.options.LockPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return *new(T), ctx.Err()
+		case <-timer.C:
+		}
+
+		if object, err := c.cache.Get(ctx, key); err == nil {
+			return object, nil
+		}
+
+		if c.options.NegativeTTL > 0 && c.isNotFound(ctx, key) {
+			return *new(T), store.NotFoundWithCause(ErrNegativelyCached)
+		}
+	}
+}
+
+// setBack asynchronously puts a loaded value back in cache using the given options,
+// or caches the not found result when the load function reported the value as absent
+func (c *LoadableCache[T]) setBack(key any, object T, loadErr error, loadOptions ...store.Option) {
+	if item := c.newSetBackItem(key, object, loadErr, loadOptions...); item != nil {
+		c.setter.send(item)
+	}
+}
+
+// newSetBackItem returns the item to put back in cache after a load, or nil if there is none
+func (c *LoadableCache[T]) newSetBackItem(key any, object T, loadErr error, loadOptions ...store.Option) *loadableKeyValue[T] {
+	if loadErr != nil {
+		if c.options.NegativeTTL > 0 && isNotFoundError(loadErr) {
+			return &loadableKeyValue[T]{key: key, notFound: true}
+		}
+
+		return nil
+	}
+
+	// The hard TTL takes precedence over the expiration returned by the load function,
+	// as values are considered older than the soft TTL from it
+	options := append(append([]store.Option{}, loadOptions...), c.getSetBackOptions()...)
+
+	return &loadableKeyValue[T]{key: key, value: object, options: options}
+}
+
+// getSetBackOptions returns the store options used to put loaded values back in cache
+func (c *LoadableCache[T]) getSetBackOptions() []store.Option {
+	var options []store.Option
+	if c.options.isRefreshAheadEnabled() {
+		options = append(options, store.WithExpiration(c.options.HardTTL))
+	}
+
+	return options
+}
+
+// recordLoad updates load statistics from the given load function error
+func (c *LoadableCache[T]) recordLoad(err error) {
+	c.statsMtx.Lock()
+	defer c.statsMtx.Unlock()
+
+	if err == nil {
+		c.stats.LoadSuccess++
+	} else {
+		c.stats.LoadError++
+	}
+}
+
+// getStale returns the copy of a previously loaded value, if any, along with
+// the given load function error
+func (c *LoadableCache[T]) getStale(ctx context.Context, key any, loadErr error) (T, error) {
+	object, err := c.cache.Get(ctx, loadableStaleKey{key})
+	if err != nil {
+		return *new(T), loadErr
+	}
+
+	c.statsMtx.Lock()
+	c.stats.StaleServed++
+	c.statsMtx.Unlock()
+
+	return object, &StaleValueError{Err: loadErr}
+}
+
+// isNotFound returns true if the load function previously reported the given key as absent
+func (c *LoadableCache[T]) isNotFound(ctx context.Context, key any) bool {
+	for _, codec := range c.getCodecs() {
+		value, err := codec.Get(ctx, loadableNotFoundKey{key}.GetCacheKey())
+		if err != nil {
+			continue
+		}
+
+		switch v := value.(type) {
+		case []byte:
+			return string(v) == LoadableNotFoundMarker
+		case string:
+			return v == LoadableNotFoundMarker
+		}
+	}
+
+	return false
+}
+
+// setNotFound caches a not found result for the given key. The marker is stored
+// as bytes, bypassing the cache value type, so it is supported by all stores.
+func (c *LoadableCache[T]) setNotFound(ctx context.Context, key any) {
+	for _, codec := range c.getCodecs() {
+		codec.Set(ctx, loadableNotFoundKey{key}.GetCacheKey(), []byte(LoadableNotFoundMarker),
+			store.WithExpiration(c.options.NegativeTTL))
+	}
+}
+
+// getCodecs returns the codecs of the underlying cache layers
+func (c *LoadableCache[T]) getCodecs() []codec.CodecInterface {
+	switch current := c.cache.(type) {
+	case *ChainCache[T]:
+		codecs := make([]codec.CodecInterface, 0, len(current.GetCaches()))
+		for _, cache := range current.GetCaches() {
+			codecs = append(codecs, cache.GetCodec())
+		}
+		return codecs
+
+	case SetterCacheInterface[T]:
+		return []codec.CodecInterface{current.GetCodec()}
+	}
+
+	return nil
+}
+
 // Set sets a value in available caches
 func (c *LoadableCache[T]) Set(ctx context.Context, key any, object T, options ...store.Option) error {
-	return c.cache.Set(ctx, key, object, options...)
+	if c.isClosed() {
+		return ErrClosed
+	}
+
+	return c.set(ctx, key, object, options...)
+}
+
+func (c *LoadableCache[T]) set(ctx context.Context, key any, object T, options ...store.Option) error {
+	err := c.cache.Set(ctx, key, object, options...)
+	if err != nil {
+		return err
+	}
+
+	if c.options.StaleIfErrorGrace > 0 {
+		c.cache.Set(ctx, loadableStaleKey{key}, object, c.getStaleOptions(options...)...)
+	}
+
+	return nil
+}
+
+// getStaleOptions returns the store options used to keep a copy of a value
+// which outlives it by the stale-if-error grace window. Values written without
+// expiration expire after the default one of the stores, if they can tell it,
+// otherwise the copy is written without expiration too.
+func (c *LoadableCache[T]) getStaleOptions(options ...store.Option) []store.Option {
+	expiration := store.ApplyOptions(options...).Expiration
+	if expiration <= 0 {
+		expiration = c.getDefaultExpiration()
+	}
+	if expiration <= 0 {
+		return options
+	}
+
+	return append(append([]store.Option{}, options...), store.WithExpiration(expiration+c.options.StaleIfErrorGrace))
+}
+
+// getDefaultExpiration returns the longest default expiration of the stores of the
+// underlying cache layers, zero if none of them can tell it
+func (c *LoadableCache[T]) getDefaultExpiration() time.Duration {
+	var expiration time.Duration
+	for _, codec := range c.getCodecs() {
+		if storeExpiration := store.GetDefaultExpiration(codec.GetStore()); storeExpiration > expiration {
+			expiration = storeExpiration
+		}
+	}
+
+	return expiration
+}
+
+// SetMany sets several values in available caches
+func (c *LoadableCache[T]) SetMany(ctx context.Context, items map[any]T, options ...store.Option) error {
+	if c.isClosed() {
+		return ErrClosed
+	}
+
+	return c.setMany(ctx, items, options...)
+}
+
+func (c *LoadableCache[T]) setMany(ctx context.Context, items map[any]T, options ...store.Option) error {
+	err := setMany(ctx, c.cache, items, options...)
+	if err != nil {
+		return err
+	}
+
+	if grace := c.options.StaleIfErrorGrace; grace > 0 {
+		staleItems := make(map[any]T, len(items))
+		for key, object := range items {
+			staleItems[loadableStaleKey{key}] = object
+		}
+
+		setMany(ctx, c.cache, staleItems, c.getStaleOptions(options...)...)
+	}
+
+	return nil
+}
+
+// DeleteMany removes several values from cache
+func (c *LoadableCache[T]) DeleteMany(ctx context.Context, keys []any) error {
+	if c.isClosed() {
+		return ErrClosed
+	}
+
+	err := deleteMany(ctx, c.cache, keys)
+
+	if c.options.StaleIfErrorGrace > 0 {
+		staleKeys := make([]any, 0, len(keys))
+		for _, key := range keys {
+			staleKeys = append(staleKeys, loadableStaleKey{key})
+		}
+
+		deleteMany(ctx, c.cache, staleKeys)
+	}
+
+	if c.options.NegativeTTL > 0 {
+		notFoundKeys := make([]any, 0, len(keys))
+		for _, key := range keys {
+			notFoundKeys = append(notFoundKeys, loadableNotFoundKey{key}.GetCacheKey())
+		}
+
+		for _, codec := range c.getCodecs() {
+			codec.DeleteMany(ctx, notFoundKeys)
+		}
+	}
+
+	return err
 }
 
 // Delete removes a value from cache
 func (c *LoadableCache[T]) Delete(ctx context.Context, key any) error {
-	return c.cache.Delete(ctx, key)
+	if c.isClosed() {
+		return ErrClosed
+	}
+
+	err := c.cache.Delete(ctx, key)
+
+	if c.options.StaleIfErrorGrace > 0 {
+		c.cache.Delete(ctx, loadableStaleKey{key})
+	}
+
+	if c.options.NegativeTTL > 0 {
+		for _, codec := range c.getCodecs() {
+			codec.Delete(ctx, loadableNotFoundKey{key}.GetCacheKey())
+		}
+	}
+
+	return err
 }
 
 // Invalidate invalidates cache item from given options
 func (c *LoadableCache[T]) Invalidate(ctx context.Context, options ...store.InvalidateOption) error {
+	if c.isClosed() {
+		return ErrClosed
+	}
+
 	return c.cache.Invalidate(ctx, options...)
 }
 
 // Clear resets all cache data
 func (c *LoadableCache[T]) Clear(ctx context.Context) error {
+	if c.isClosed() {
+		return ErrClosed
+	}
+
 	return c.cache.Clear(ctx)
 }
 
+// GetSetBackQueueStats returns some statistics about the queue of loaded values
+// waiting to be set in the cache
+func (c *LoadableCache[T]) GetSetBackQueueStats() QueueStats {
+	return c.setter.getStats()
+}
+
+// GetStats returns some statistics about the current loadable cache
+func (c *LoadableCache[T]) GetStats() *LoadableStats {
+	c.statsMtx.Lock()
+	defer c.statsMtx.Unlock()
+	stats := *c.stats
+	return &stats
+}
+
 // GetType returns the cache type
 func (c *LoadableCache[T]) GetType() string {
 	return LoadableType
 }
 
-func (c *LoadableCache[T]) Close() error {
-	close(c.setChannel)
-	c.setterWg.Wait()
+// Flush waits until the loaded values have been put back in cache
+func (c *LoadableCache[T]) Flush(ctx context.Context) error {
+	if c.isClosed() {
+		return ErrClosed
+	}
 
-	return nil
+	return c.setter.flush(ctx)
+}
+
+// Close waits for the background reloads, puts the pending loaded values back in
+// cache and stops the background goroutine. Calls made after closing return ErrClosed.
+func (c *LoadableCache[T]) Close(ctx context.Context) error {
+	c.closeMtx.Lock()
+	if c.closed {
+		c.closeMtx.Unlock()
+		return ErrClosed
+	}
+	c.closed = true
+	c.closeMtx.Unlock()
+
+	refreshed := make(chan struct{})
+	go func() {
+		c.refreshWg.Wait()
+		close(refreshed)
+	}()
+
+	select {
+	case <-refreshed:
+	case <-ctx.Done():
+		// The background goroutine is still stopped, without waiting for the pending
+		// loaded values to be put back in cache
+		c.setter.close(ctx)
+		return ctx.Err()
+	}
+
+	return c.setter.close(ctx)
+}
+
+// isClosed returns true once the cache has been closed
+func (c *LoadableCache[T]) isClosed() bool {
+	c.closeMtx.RLock()
+	defer c.closeMtx.RUnlock()
+
+	return c.closed
+}
+
+// addRefresh registers a background reload unless the cache has been closed
+func (c *LoadableCache[T]) addRefresh() bool {
+	c.closeMtx.RLock()
+	defer c.closeMtx.RUnlock()
+
+	if c.closed {
+		return false
+	}
+
+	c.refreshWg.Add(1)
+
+	return true
+}
+
+// isContextError returns true if the given error results from a context cancellation
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// isNotFoundError returns true if the given error reports an absent value
+func isNotFoundError(err error) bool {
+	return errors.Is(err, &store.NotFound{})
 }
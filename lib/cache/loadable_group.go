@@ -0,0 +1,161 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// errLoadAborted is shared with waiting callers when the load function panics
+var errLoadAborted = errors.New("load function call has been aborted")
+
+// loadCall represents an in-flight or completed load function call
+type loadCall[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// loadResult represents the result of a load function call for one of several keys
+type loadResult[T any] struct {
+	value T
+	err   error
+	// shared is true when the result has been produced by another caller
+	shared bool
+}
+
+// loadGroup collapses concurrent load function calls made for the same key
+// into a single one, which result is shared by all callers
+type loadGroup[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*loadCall[T]
+}
+
+func newLoadGroup[T any]() *loadGroup[T] {
+	return &loadGroup[T]{
+		calls: make(map[string]*loadCall[T]),
+	}
+}
+
+// do executes the given function once for all concurrent callers of the same key.
+// Callers joining an in-flight call wait for its result until their own context is done.
+// The returned boolean reports whether the result was produced by another caller.
+func (g *loadGroup[T]) do(ctx context.Context, key string, fn func() (T, error)) (T, bool, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+
+		select {
+		case <-call.done:
+			return call.value, true, call.err
+		case <-ctx.Done():
+			return *new(T), true, ctx.Err()
+		}
+	}
+
+	call := g.register(key)
+	g.mu.Unlock()
+
+	g.run(key, call, fn)
+
+	return call.value, false, call.err
+}
+
+// doInBackground executes the given function in a new goroutine unless a call
+// is already in-flight for the same key. It returns whether the function has been started.
+func (g *loadGroup[T]) doInBackground(key string, fn func() (T, error)) bool {
+	g.mu.Lock()
+	if _, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		return false
+	}
+
+	call := g.register(key)
+	g.mu.Unlock()
+
+	go g.run(key, call, fn)
+
+	return true
+}
+
+// doMany executes the given function once for the keys which are not being loaded yet,
+// given in the same order, and waits for the in-flight calls of the other ones until the
+// context is done. The function returns the results of the keys it has been given.
+func (g *loadGroup[T]) doMany(ctx context.Context, keys []string, fn func(keys []string) map[string]loadResult[T]) map[string]loadResult[T] {
+	ownKeys := make([]string, 0, len(keys))
+	ownCalls := make(map[string]*loadCall[T], len(keys))
+	joinedCalls := make(map[string]*loadCall[T])
+
+	g.mu.Lock()
+	for _, key := range keys {
+		if call, ok := g.calls[key]; ok {
+			joinedCalls[key] = call
+			continue
+		}
+
+		ownKeys = append(ownKeys, key)
+		ownCalls[key] = g.register(key)
+	}
+	g.mu.Unlock()
+
+	results := make(map[string]loadResult[T], len(keys))
+
+	if len(ownKeys) > 0 {
+		g.runMany(ownKeys, ownCalls, fn)
+
+		for key, call := range ownCalls {
+			results[key] = loadResult[T]{value: call.value, err: call.err}
+		}
+	}
+
+	for key, call := range joinedCalls {
+		select {
+		case <-call.done:
+			results[key] = loadResult[T]{value: call.value, err: call.err, shared: true}
+		case <-ctx.Done():
+			results[key] = loadResult[T]{err: ctx.Err(), shared: true}
+		}
+	}
+
+	return results
+}
+
+// register adds a new in-flight call for the given key, the group lock must be held
+func (g *loadGroup[T]) register(key string) *loadCall[T] {
+	call := &loadCall[T]{done: make(chan struct{}), err: errLoadAborted}
+	g.calls[key] = call
+
+	return call
+}
+
+func (g *loadGroup[T]) run(key string, call *loadCall[T], fn func() (T, error)) {
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+
+		close(call.done)
+	}()
+
+	call.value, call.err = fn()
+}
+
+func (g *loadGroup[T]) runMany(keys []string, calls map[string]*loadCall[T], fn func(keys []string) map[string]loadResult[T]) {
+	defer func() {
+		g.mu.Lock()
+		for _, key := range keys {
+			delete(g.calls, key)
+		}
+		g.mu.Unlock()
+
+		for _, key := range keys {
+			close(calls[key].done)
+		}
+	}()
+
+	for key, result := range fn(keys) {
+		if call, ok := calls[key]; ok {
+			call.value, call.err = result.value, result.err
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/eko/gocache/lib/v4/lock"
+)
+
+const defaultLockPollInterval = 50 * time.Millisecond
+
+// LoadableOption represents a loadable cache option function.
+type LoadableOption func(o *LoadableOptions)
+
+type LoadableOptions struct {
+	SoftTTL           time.Duration
+	HardTTL           time.Duration
+	StaleIfErrorGrace time.Duration
+	NegativeTTL       time.Duration
+	Locker            *lock.Locker
+	LockTTL           time.Duration
+	LockWaitTimeout   time.Duration
+	LockPollInterval  time.Duration
+	SetBackQueue      *QueueOptions
+}
+
+func (o *LoadableOptions) isRefreshAheadEnabled() bool {
+	return o.SoftTTL > 0 && o.HardTTL > o.SoftTTL
+}
+
+func applyLoadableOptions(opts ...LoadableOption) *LoadableOptions {
+	o := &LoadableOptions{
+		SetBackQueue: applyQueueOptions(),
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.Locker != nil && o.LockPollInterval <= 0 {
+		o.LockPollInterval = defaultLockPollInterval
+	}
+
+	return o
+}
+
+// WithStaleWhileRevalidate allows to keep serving cached values while they are
+// reloaded in background. Loaded values are stored with the hard TTL expiration, which
+// takes precedence over the one returned by a load function with options, and, once
+// they are older than the soft TTL, Get returns them immediately and triggers a single
+// background reload.
+// The cache needs to support GetWithTTL (a SetterCacheInterface or a ChainCache) and
+// hard TTL has to be greater than soft TTL, otherwise this option has no effect.
+// Values of stores unable to return their TTL, such as bigcache, are never refreshed.
+func WithStaleWhileRevalidate(softTTL, hardTTL time.Duration) LoadableOption {
+	return func(o *LoadableOptions) {
+		o.SoftTTL = softTTL
+		o.HardTTL = hardTTL
+	}
+}
+
+// WithStaleIfError allows to return a previously loaded value when the load function fails.
+// A copy of each value set in cache is kept for its expiration time plus the given grace
+// window (or only the grace window if no expiration is given) and is returned along with
+// a *StaleValueError wrapping the load function error.
+func WithStaleIfError(grace time.Duration) LoadableOption {
+	return func(o *LoadableOptions) {
+		o.StaleIfErrorGrace = grace
+	}
+}
+
+// WithNegativeCaching allows to cache not found results for the given TTL.
+// The load function reports a key as definitively absent by returning a store.NotFound
+// error, then the following Get calls return a store.NotFound error without calling it
+// until the TTL expires. The cache needs to be a SetterCacheInterface or a ChainCache.
+func WithNegativeCaching(ttl time.Duration) LoadableOption {
+	return func(o *LoadableOptions) {
+		o.NegativeTTL = ttl
+	}
+}
+
+// WithDistributedLock allows to protect the load function against concurrent calls made by
+// several instances sharing the same cache store. Before loading a missing key, a lock is
+// obtained for the given TTL using the given locker (which store has to be shared as well).
+// Instances that cannot obtain it wait for the value loaded by the lock holder to appear in
+// cache and fall back to calling the load function themselves after the wait timeout.
+func WithDistributedLock(locker *lock.Locker, lockTTL, waitTimeout time.Duration) LoadableOption {
+	return func(o *LoadableOptions) {
+		o.Locker = locker
+		o.LockTTL = lockTTL
+		o.LockWaitTimeout = waitTimeout
+	}
+}
+
+// WithDistributedLockPollInterval allows to specify how often instances waiting for the
+// lock holder check whether the value has been loaded (50ms by default).
+func WithDistributedLockPollInterval(interval time.Duration) LoadableOption {
+	return func(o *LoadableOptions) {
+		o.LockPollInterval = interval
+	}
+}
+
+// WithSetBackQueue allows to configure the queue of loaded values waiting to be set
+// in the cache. By default, Get calls block while it is full.
+func WithSetBackQueue(options ...QueueOption) LoadableOption {
+	return func(o *LoadableOptions) {
+		for _, opt := range options {
+			opt(o.SetBackQueue)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eko/gocache/lib/v4/lock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyLoadableOptions(t *testing.T) {
+	// When
+	options := applyLoadableOptions(WithStaleWhileRevalidate(time.Minute, 5*time.Minute))
+
+	// Then
+	assert.Equal(t, time.Minute, options.SoftTTL)
+	assert.Equal(t, 5*time.Minute, options.HardTTL)
+	assert.True(t, options.isRefreshAheadEnabled())
+}
+
+func TestLoadableOptionsIsRefreshAheadEnabledWhenHardTTLIsLower(t *testing.T) {
+	// Given
+	options := applyLoadableOptions(WithStaleWhileRevalidate(5*time.Minute, time.Minute))
+
+	// When - Then
+	assert.False(t, options.isRefreshAheadEnabled())
+}
+
+func TestApplyLoadableOptionsWithStaleIfError(t *testing.T) {
+	// When
+	options := applyLoadableOptions(WithStaleIfError(time.Hour))
+
+	// Then
+	assert.Equal(t, time.Hour, options.StaleIfErrorGrace)
+	assert.False(t, options.isRefreshAheadEnabled())
+}
+
+func TestApplyLoadableOptionsWithNegativeCaching(t *testing.T) {
+	// When
+	options := applyLoadableOptions(WithNegativeCaching(30 * time.Second))
+
+	// Then
+	assert.Equal(t, 30*time.Second, options.NegativeTTL)
+}
+
+func TestApplyLoadableOptionsWithDistributedLock(t *testing.T) {
+	// Given
+	locker := lock.NewInMemory()
+
+	// When
+	options := applyLoadableOptions(WithDistributedLock(locker, 10*time.Second, 5*time.Second))
+
+	// Then
+	assert.Equal(t, locker, options.Locker)
+	assert.Equal(t, 10*time.Second, options.LockTTL)
+	assert.Equal(t, 5*time.Second, options.LockWaitTimeout)
+	assert.Equal(t, defaultLockPollInterval, options.LockPollInterval)
+}
+
+func TestApplyLoadableOptionsWithSetBackQueue(t *testing.T) {
+	// When
+	options := applyLoadableOptions(WithSetBackQueue(WithQueueSize(100), WithQueueWorkers(2), WithQueueOverflowPolicy(OverflowSync)))
+
+	// Then
+	assert.Equal(t, &QueueOptions{Size: 100, Workers: 2, OverflowPolicy: OverflowSync}, options.SetBackQueue)
+	assert.Equal(t, &QueueOptions{Size: defaultQueueSize, Workers: 1}, applyLoadableOptions().SetBackQueue)
+}
@@ -3,9 +3,13 @@ package cache
 import (
 	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/eko/gocache/lib/v4/lock"
+	"github.com/eko/gocache/lib/v4/store"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
@@ -30,93 +34,1121 @@ func TestNewLoadable(t *testing.T) {
 	assert.Equal(t, cache1, cache.cache)
 }
 
+func TestNewLoadableWhenOptionsAreGiven(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return "test data loaded", nil
+	}
+
+	// When
+	cache := NewLoadable[any](loadFunc, cache1, WithStaleWhileRevalidate(time.Minute, 2*time.Minute))
+
+	// Then
+	assert.Equal(t, time.Minute, cache.options.SoftTTL)
+	assert.Equal(t, 2*time.Minute, cache.options.HardTTL)
+}
+
 func TestLoadableGetWhenAlreadyInCache(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
 
 	ctx := context.Background()
 
-	cacheValue := &struct {
-		Hello string
-	}{
-		Hello: "world",
+	cacheValue := &struct {
+		Hello string
+	}{
+		Hello: "world",
+	}
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(cacheValue, nil)
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return nil, errors.New("should not be called")
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1)
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, cacheValue, value)
+}
+
+func TestLoadableGetWhenNotAvailableInLoadFunc(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(nil, errors.New("unable to find in cache 1"))
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return nil, errors.New("an error has occurred while loading data from custom source")
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1)
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, value)
+	assert.Equal(t, errors.New("an error has occurred while loading data from custom source"), err)
+}
+
+func TestLoadableGetWhenAvailableInLoadFunc(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheValue := &struct {
+		Hello string
+	}{
+		Hello: "world",
+	}
+
+	// Cache 1
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(nil, errors.New("unable to find in cache 1"))
+	cache1.EXPECT().Set(ctx, "my-key", cacheValue).AnyTimes().Return(nil)
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return cacheValue, nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1)
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Wait for data to be processed
+	cache.Flush(ctx)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, cacheValue, value)
+}
+
+func TestLoadableGetWhenConcurrentMissesAreDeduplicated(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheValue := &struct {
+		Hello string
+	}{
+		Hello: "world",
+	}
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").AnyTimes().Return(nil, errors.New("unable to find in cache 1"))
+	cache1.EXPECT().Set(context.Background(), "my-key", cacheValue).Return(nil)
+
+	var calls int32
+	release := make(chan struct{})
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return cacheValue, nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1)
+
+	// When
+	wg := &sync.WaitGroup{}
+	values := make([]any, 10)
+	errs := make([]error, 10)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			values[i], errs[i] = cache.Get(ctx, "my-key")
+		}(i)
+	}
+
+	// Wait for all callers to wait for the load function result
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	cache.Close(context.Background())
+
+	// Then
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, errs[i])
+		assert.Equal(t, cacheValue, values[i])
+	}
+
+	stats := cache.GetStats()
+	assert.Equal(t, 1, stats.LoadSuccess)
+	assert.Equal(t, 9, stats.LoadDeduplicated)
+}
+
+func TestLoadableGetWhenConcurrentMissesShareLoadError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := errors.New("an error has occurred while loading data from custom source")
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Times(2).Return(nil, errors.New("unable to find in cache 1"))
+
+	release := make(chan struct{})
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		<-release
+		return nil, expectedErr
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1)
+
+	// When
+	wg := &sync.WaitGroup{}
+	errs := make([]error, 2)
+
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = cache.Get(ctx, "my-key")
+		}(i)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// Then
+	assert.Equal(t, expectedErr, errs[0])
+	assert.Equal(t, expectedErr, errs[1])
+
+	stats := cache.GetStats()
+	assert.Equal(t, 1, stats.LoadError)
+	assert.Equal(t, 1, stats.LoadDeduplicated)
+}
+
+func TestLoadableGetWhenWaiterContextIsCancelled(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+	waiterCtx, cancel := context.WithCancel(ctx)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(nil, errors.New("unable to find in cache 1"))
+	cache1.EXPECT().Get(waiterCtx, "my-key").Return(nil, errors.New("unable to find in cache 1"))
+	cache1.EXPECT().Set(context.Background(), "my-key", "a value").Return(nil)
+
+	release := make(chan struct{})
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		<-release
+		return "a value", nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cache.Get(ctx, "my-key")
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+
+	// When
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	value, err := cache.Get(waiterCtx, "my-key")
+
+	close(release)
+	<-done
+	cache.Close(context.Background())
+
+	// Then
+	assert.Nil(t, value)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestLoadableGetWhenFreshWithStaleWhileRevalidate(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return("a value", 90*time.Second, nil)
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return nil, errors.New("should not be called")
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithStaleWhileRevalidate(time.Minute, 2*time.Minute))
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "a value", value)
+	assert.Equal(t, 0, cache.GetStats().Refresh)
+}
+
+func TestLoadableGetWhenStaleWithStaleWhileRevalidate(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	refreshed := make(chan struct{})
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Times(2).Return("a stale value", 30*time.Second, nil)
+	cache1.EXPECT().Set(context.Background(), "my-key", "a fresh value", store.OptionsMatcher{
+		Expiration: 2 * time.Minute,
+	}).DoAndReturn(func(_ context.Context, _ any, _ any, _ ...store.Option) error {
+		close(refreshed)
+		return nil
+	})
+
+	var calls int32
+	release := make(chan struct{})
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "a fresh value", nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithStaleWhileRevalidate(time.Minute, 2*time.Minute))
+
+	// When
+	value1, err1 := cache.Get(ctx, "my-key")
+	value2, err2 := cache.Get(ctx, "my-key")
+
+	close(release)
+
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("value has not been refreshed")
+	}
+
+	// Then
+	assert.Nil(t, err1)
+	assert.Equal(t, "a stale value", value1)
+	assert.Nil(t, err2)
+	assert.Equal(t, "a stale value", value2)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, 1, cache.GetStats().Refresh)
+}
+
+func TestLoadableGetWhenMissingWithStaleWhileRevalidate(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return(nil, 0*time.Second, store.NotFound{})
+	cache1.EXPECT().Set(context.Background(), "my-key", "a value", store.OptionsMatcher{
+		Expiration: 2 * time.Minute,
+	}).Return(nil)
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return "a value", nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithStaleWhileRevalidate(time.Minute, 2*time.Minute))
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	cache.Close(context.Background())
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "a value", value)
+}
+
+func TestLoadableGetWhenTTLNotSupportedWithStaleWhileRevalidate(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return(nil, 0*time.Second, errors.New("method not implemented for codec, use Get() instead"))
+	cache1.EXPECT().Get(ctx, "my-key").Return("a value", nil)
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return nil, errors.New("should not be called")
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithStaleWhileRevalidate(time.Minute, 2*time.Minute))
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "a value", value)
+	assert.Equal(t, 0, cache.GetStats().Refresh)
+}
+
+func TestLoadableGetWhenLoadFuncFailsWithStaleIfError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	loadErr := errors.New("an error has occurred while loading data from custom source")
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(nil, errors.New("unable to find in cache 1"))
+	cache1.EXPECT().Get(ctx, loadableStaleKey{"my-key"}).Return("a stale value", nil)
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return nil, loadErr
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithStaleIfError(time.Hour))
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Equal(t, "a stale value", value)
+
+	staleErr := &StaleValueError{}
+	assert.ErrorAs(t, err, &staleErr)
+	assert.ErrorIs(t, err, loadErr)
+
+	assert.Equal(t, 1, cache.GetStats().StaleServed)
+}
+
+func TestLoadableGetWhenLoadFuncFailsWithStaleIfErrorAndNoStaleValue(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	loadErr := errors.New("an error has occurred while loading data from custom source")
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(nil, errors.New("unable to find in cache 1"))
+	cache1.EXPECT().Get(ctx, loadableStaleKey{"my-key"}).Return(nil, errors.New("unable to find in cache 1"))
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return nil, loadErr
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithStaleIfError(time.Hour))
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, value)
+	assert.Equal(t, loadErr, err)
+	assert.Equal(t, 0, cache.GetStats().StaleServed)
+}
+
+func TestLoadableSetWithStaleIfError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Set(ctx, "my-key", "a value", store.OptionsMatcher{
+		Expiration: 5 * time.Minute,
+		Tags:       []string{"tag1"},
+	}).Return(nil)
+	cache1.EXPECT().Set(ctx, loadableStaleKey{"my-key"}, "a value", store.OptionsMatcher{
+		Expiration: 65 * time.Minute,
+		Tags:       []string{"tag1"},
+	}).Return(nil)
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return "a value", nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithStaleIfError(time.Hour))
+
+	// When
+	err := cache.Set(ctx, "my-key", "a value", store.WithExpiration(5*time.Minute), store.WithTags([]string{"tag1"}))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestLoadableSetWithStaleIfErrorAndNoExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().Set(ctx, "my-key", "a value", store.OptionsMatcher{
+		Tags: []string{"tag1"},
+	}).Return(nil)
+	store1.EXPECT().Set(ctx, "gocache_stale_my-key", "a value", store.OptionsMatcher{
+		Tags: []string{"tag1"},
+	}).Return(nil)
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return "a value", nil
+	}
+
+	cache := NewLoadable[any](loadFunc, New[any](store1), WithStaleIfError(time.Hour))
+
+	// When
+	err := cache.Set(ctx, "my-key", "a value", store.WithTags([]string{"tag1"}))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+type defaultExpirationStore struct {
+	*store.MockStoreInterface
+	expiration time.Duration
+}
+
+func (s *defaultExpirationStore) GetDefaultExpiration() time.Duration {
+	return s.expiration
+}
+
+func TestLoadableSetWithStaleIfErrorAndStoreDefaultExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store1 := &defaultExpirationStore{MockStoreInterface: store.NewMockStoreInterface(ctrl), expiration: 10 * time.Minute}
+	store1.EXPECT().Set(ctx, "my-key", "a value", store.OptionsMatcher{}).Return(nil)
+	store1.EXPECT().Set(ctx, "gocache_stale_my-key", "a value", store.OptionsMatcher{
+		Expiration: 70 * time.Minute,
+	}).Return(nil)
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return "a value", nil
+	}
+
+	cache := NewLoadable[any](loadFunc, New[any](store1), WithStaleIfError(time.Hour))
+
+	// When
+	err := cache.Set(ctx, "my-key", "a value")
+
+	// Then the copy outlives the value expiring after the default expiration of the store
+	assert.Nil(t, err)
+}
+
+func TestLoadableDeleteWithStaleIfError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Delete(ctx, "my-key").Return(nil)
+	cache1.EXPECT().Delete(ctx, loadableStaleKey{"my-key"}).Return(nil)
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return "a value", nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithStaleIfError(time.Hour))
+
+	// When
+	err := cache.Delete(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestLoadableGetWhenNegativelyCached(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().Get(ctx, "my-key").Return(nil, store.NotFound{})
+	store1.EXPECT().Get(ctx, "gocache_not_found_my-key").Return([]byte("gocache_not_found"), nil)
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return nil, errors.New("should not be called")
+	}
+
+	cache := NewLoadable[any](loadFunc, New[any](store1), WithNegativeCaching(30*time.Second))
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, value)
+	assert.ErrorIs(t, err, &store.NotFound{})
+	assert.ErrorIs(t, err, ErrNegativelyCached)
+	assert.Equal(t, 1, cache.GetStats().NegativeHit)
+}
+
+func TestLoadableGetWhenLoadFuncReturnsNotFoundWithNegativeCaching(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	loadErr := store.NotFoundWithCause(errors.New("book does not exist"))
+
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().Get(ctx, "my-key").Return(nil, store.NotFound{})
+	store1.EXPECT().Get(ctx, "gocache_not_found_my-key").Return(nil, store.NotFound{})
+	store1.EXPECT().Set(context.Background(), "gocache_not_found_my-key", []byte("gocache_not_found"), store.OptionsMatcher{
+		Expiration: 30 * time.Second,
+	}).Return(nil)
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return nil, loadErr
+	}
+
+	cache := NewLoadable[any](loadFunc, New[any](store1), WithNegativeCaching(30*time.Second))
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	cache.Close(context.Background())
+
+	// Then
+	assert.Nil(t, value)
+	assert.Equal(t, loadErr, err)
+	assert.Equal(t, 0, cache.GetStats().NegativeHit)
+}
+
+func TestLoadableGetWhenLoadFuncReturnsNotFoundWithStaleIfError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(nil, errors.New("unable to find in cache 1"))
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return nil, store.NotFound{}
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithStaleIfError(time.Hour))
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, value)
+	assert.Equal(t, store.NotFound{}, err)
+}
+
+func TestLoadableDeleteWithNegativeCaching(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().Delete(ctx, "my-key").Return(nil)
+	store1.EXPECT().Delete(ctx, "gocache_not_found_my-key").Return(nil)
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return "a value", nil
+	}
+
+	cache := NewLoadable[any](loadFunc, New[any](store1), WithNegativeCaching(30*time.Second))
+
+	// When
+	err := cache.Delete(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestLoadableGetWithDistributedLock(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().Get(ctx, "my-key").Return(nil, store.NotFound{})
+	store1.EXPECT().Set(context.Background(), "my-key", "my-value").Return(nil)
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return "my-value", nil
+	}
+
+	locker := lock.NewInMemory()
+
+	cache := NewLoadable[any](loadFunc, New[any](store1), WithDistributedLock(locker, time.Second, time.Second))
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, 0, cache.GetStats().LockWait)
+
+	_, err = locker.TryObtain(ctx, "my-key", time.Second)
+	assert.Nil(t, err)
+}
+
+func TestLoadableGetWithDistributedLockWhenHeldByAnotherInstance(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store1 := store.NewMockStoreInterface(ctrl)
+	gomock.InOrder(
+		store1.EXPECT().Get(ctx, "my-key").Return(nil, store.NotFound{}),
+		store1.EXPECT().Get(ctx, "my-key").Return("my-value", nil),
+	)
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return nil, errors.New("should not be called")
+	}
+
+	locker := lock.NewInMemory()
+
+	_, err := locker.TryObtain(ctx, "my-key", time.Second)
+	assert.Nil(t, err)
+
+	cache := NewLoadable[any](
+		loadFunc,
+		New[any](store1),
+		WithDistributedLock(locker, time.Second, time.Second),
+		WithDistributedLockPollInterval(time.Millisecond),
+	)
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, 1, cache.GetStats().LockWait)
+	assert.Equal(t, 0, cache.GetStats().LockFallback)
+	assert.Equal(t, 0, cache.GetStats().LoadSuccess)
+}
+
+func TestLoadableGetWithDistributedLockWhenWaitTimeoutIsReached(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().Get(ctx, "my-key").Return(nil, store.NotFound{}).MinTimes(2)
+	store1.EXPECT().Set(context.Background(), "my-key", "my-value").Return(nil)
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return "my-value", nil
 	}
 
+	locker := lock.NewInMemory()
+
+	_, err := locker.TryObtain(ctx, "my-key", time.Second)
+	assert.Nil(t, err)
+
+	cache := NewLoadable[any](
+		loadFunc,
+		New[any](store1),
+		WithDistributedLock(locker, time.Second, 10*time.Millisecond),
+		WithDistributedLockPollInterval(time.Millisecond),
+	)
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	cache.Close(context.Background())
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, 1, cache.GetStats().LockWait)
+	assert.Equal(t, 1, cache.GetStats().LockFallback)
+	assert.Equal(t, 1, cache.GetStats().LoadSuccess)
+}
+
+func TestNewLoadableWithOptions(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
 	cache1 := NewMockSetterCacheInterface[any](ctrl)
-	cache1.EXPECT().Get(ctx, "my-key").Return(cacheValue, nil)
 
-	loadFunc := func(_ context.Context, key any) (any, error) {
-		return nil, errors.New("should not be called")
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		return "test data loaded", []store.Option{store.WithExpiration(5 * time.Minute)}, nil
 	}
 
-	cache := NewLoadable[any](loadFunc, cache1)
+	// When
+	cache := NewLoadableWithOptions[any](loadFunc, cache1)
+
+	// Then
+	assert.IsType(t, new(LoadableCache[any]), cache)
+	assert.IsType(t, new(LoadWithOptionsFunction[any]), &cache.loadWithOptionsFunc)
+	assert.Equal(t, cache1, cache.cache)
+}
+
+func TestLoadableWithOptionsGetWhenAvailableInLoadFunc(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "user-42").Return(nil, errors.New("unable to find in cache 1"))
+	cache1.EXPECT().Set(context.Background(), "user-42", "a user profile", store.OptionsMatcher{
+		Expiration: 5 * time.Minute,
+		Tags:       []string{"user:42"},
+	}).Return(nil)
+
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		return "a user profile", []store.Option{
+			store.WithExpiration(5 * time.Minute),
+			store.WithTags([]string{"user:42"}),
+		}, nil
+	}
+
+	cache := NewLoadableWithOptions[any](loadFunc, cache1)
 
 	// When
-	value, err := cache.Get(ctx, "my-key")
+	value, err := cache.Get(ctx, "user-42")
+
+	cache.Close(context.Background())
 
 	// Then
 	assert.Nil(t, err)
-	assert.Equal(t, cacheValue, value)
+	assert.Equal(t, "a user profile", value)
 }
 
-func TestLoadableGetWhenNotAvailableInLoadFunc(t *testing.T) {
+func TestLoadableWithOptionsGetWhenLoadFuncReturnsExpirationWithStaleWhileRevalidate(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "user-42").Return(nil, 0*time.Second, store.NotFound{})
+	cache1.EXPECT().Set(context.Background(), "user-42", "a user profile", store.OptionsMatcher{
+		Expiration: 2 * time.Minute,
+		Tags:       []string{"user:42"},
+	}).Return(nil)
+
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		return "a user profile", []store.Option{
+			store.WithExpiration(5 * time.Minute),
+			store.WithTags([]string{"user:42"}),
+		}, nil
+	}
+
+	cache := NewLoadableWithOptions[any](loadFunc, cache1, WithStaleWhileRevalidate(time.Minute, 2*time.Minute))
+
+	// When
+	value, err := cache.Get(ctx, "user-42")
+
+	cache.Close(context.Background())
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "a user profile", value)
+}
+
+func TestNewBatchLoadable(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+
+	batchLoadFunc := func(_ context.Context, keys []any) (map[any]any, error) {
+		return map[any]any{}, nil
+	}
+
+	// When
+	cache := NewBatchLoadable[any](batchLoadFunc, cache1)
+
+	// Then
+	assert.IsType(t, new(LoadableCache[any]), cache)
+	assert.NotNil(t, cache.loadFunc)
+	assert.NotNil(t, cache.batchLoadFunc)
+}
+
+func TestBatchLoadableGetWhenNotReturnedByBatchLoadFunc(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
 
 	ctx := context.Background()
 
-	// Cache
 	cache1 := NewMockSetterCacheInterface[any](ctrl)
 	cache1.EXPECT().Get(ctx, "my-key").Return(nil, errors.New("unable to find in cache 1"))
 
-	loadFunc := func(_ context.Context, key any) (any, error) {
-		return nil, errors.New("an error has occurred while loading data from custom source")
+	batchLoadFunc := func(_ context.Context, keys []any) (map[any]any, error) {
+		assert.Equal(t, []any{"my-key"}, keys)
+		return map[any]any{}, nil
 	}
 
-	cache := NewLoadable[any](loadFunc, cache1)
+	cache := NewBatchLoadable[any](batchLoadFunc, cache1)
 
 	// When
 	value, err := cache.Get(ctx, "my-key")
 
 	// Then
 	assert.Nil(t, value)
-	assert.Equal(t, errors.New("an error has occurred while loading data from custom source"), err)
+	assert.ErrorIs(t, err, &store.NotFound{})
 }
 
-func TestLoadableGetWhenAvailableInLoadFunc(t *testing.T) {
+func TestLoadableGetManyWithBatchLoadFunc(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
 
 	ctx := context.Background()
 
-	cacheValue := &struct {
-		Hello string
-	}{
-		Hello: "world",
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "key1").Return("value1", nil)
+	cache1.EXPECT().Get(ctx, "key2").Return(nil, errors.New("unable to find in cache 1"))
+	cache1.EXPECT().Get(ctx, "key3").Return(nil, errors.New("unable to find in cache 1"))
+	cache1.EXPECT().Get(ctx, "key4").Return(nil, errors.New("unable to find in cache 1"))
+	cache1.EXPECT().Set(context.Background(), "key2", "value2").Return(nil)
+	cache1.EXPECT().Set(context.Background(), "key3", "value3").Return(nil)
+
+	var calls int32
+
+	batchLoadFunc := func(_ context.Context, keys []any) (map[any]any, error) {
+		atomic.AddInt32(&calls, 1)
+		assert.Equal(t, []any{"key2", "key3", "key4"}, keys)
+
+		return map[any]any{"key2": "value2", "key3": "value3"}, nil
 	}
 
-	// Cache 1
+	cache := NewBatchLoadable[any](batchLoadFunc, cache1)
+
+	// When
+	values, err := cache.GetMany(ctx, []any{"key1", "key2", "key3", "key4"})
+
+	cache.Close(context.Background())
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key1": "value1", "key2": "value2", "key3": "value3"}, values)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, 1, cache.GetStats().LoadSuccess)
+}
+
+func TestLoadableGetManyWhenBatchLoadFuncFails(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := errors.New("an error has occurred while loading data from custom source")
+
 	cache1 := NewMockSetterCacheInterface[any](ctrl)
-	cache1.EXPECT().Get(ctx, "my-key").Return(nil, errors.New("unable to find in cache 1"))
-	cache1.EXPECT().Set(ctx, "my-key", cacheValue).AnyTimes().Return(nil)
+	cache1.EXPECT().Get(ctx, "key1").Return("value1", nil)
+	cache1.EXPECT().Get(ctx, "key2").Return(nil, errors.New("unable to find in cache 1"))
+
+	batchLoadFunc := func(_ context.Context, keys []any) (map[any]any, error) {
+		return nil, expectedErr
+	}
+
+	cache := NewBatchLoadable[any](batchLoadFunc, cache1)
+
+	// When
+	values, err := cache.GetMany(ctx, []any{"key1", "key2"})
+
+	// Then
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, map[any]any{"key1": "value1"}, values)
+	assert.Equal(t, 1, cache.GetStats().LoadError)
+}
+
+func TestLoadableGetManyWithoutBatchLoadFunc(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "key1").Return(nil, errors.New("unable to find in cache 1"))
+	cache1.EXPECT().Get(ctx, "key2").Return(nil, errors.New("unable to find in cache 1"))
+	cache1.EXPECT().Set(context.Background(), "key1", "value1").Return(nil)
 
 	loadFunc := func(_ context.Context, key any) (any, error) {
-		return cacheValue, nil
+		if key == "key1" {
+			return "value1", nil
+		}
+		return nil, store.NotFound{}
 	}
 
 	cache := NewLoadable[any](loadFunc, cache1)
 
 	// When
-	value, err := cache.Get(ctx, "my-key")
+	values, err := cache.GetMany(ctx, []any{"key1", "key2"})
 
-	// Wait for data to be processed
-	for len(cache.setChannel) > 0 {
-		time.Sleep(1 * time.Millisecond)
+	cache.Close(context.Background())
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key1": "value1"}, values)
+}
+
+func TestLoadableGetManyWithBatchLoadFuncSharesLoadWithGet(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "key1").Return(nil, errors.New("unable to find in cache 1")).Times(2)
+	cache1.EXPECT().Set(context.Background(), "key1", "value1").Return(nil).AnyTimes()
+
+	var calls int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	batchLoadFunc := func(_ context.Context, keys []any) (map[any]any, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(started)
+			<-release
+		}
+
+		return map[any]any{"key1": "value1"}, nil
 	}
 
+	cache := NewBatchLoadable[any](batchLoadFunc, cache1)
+
+	go func() {
+		<-started
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+	}()
+
+	var value any
+	var getErr error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		value, getErr = cache.Get(ctx, "key1")
+	}()
+	<-started
+
+	// When
+	values, err := cache.GetMany(ctx, []any{"key1"})
+	<-done
+
+	cache.Close(context.Background())
+
 	// Then
 	assert.Nil(t, err)
-	assert.Equal(t, cacheValue, value)
+	assert.Equal(t, map[any]any{"key1": "value1"}, values)
+	assert.Nil(t, getErr)
+	assert.Equal(t, "value1", value)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, 1, cache.GetStats().LoadDeduplicated)
+}
+
+func TestLoadableGetManyWithBatchLoadFuncAndDistributedLock(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().Get(ctx, "key1").Return(nil, store.NotFound{})
+	gomock.InOrder(
+		store1.EXPECT().Get(ctx, "key2").Return(nil, store.NotFound{}),
+		store1.EXPECT().Get(ctx, "key2").Return("value2", nil),
+	)
+	store1.EXPECT().Set(context.Background(), "key1", "value1").Return(nil)
+
+	batchLoadFunc := func(_ context.Context, keys []any) (map[any]any, error) {
+		assert.Equal(t, []any{"key1"}, keys)
+		return map[any]any{"key1": "value1"}, nil
+	}
+
+	locker := lock.NewInMemory()
+
+	// key2 is being loaded by another instance
+	_, err := locker.TryObtain(ctx, "key2", time.Second)
+	assert.Nil(t, err)
+
+	cache := NewBatchLoadable[any](
+		batchLoadFunc,
+		New[any](store1),
+		WithDistributedLock(locker, time.Second, time.Second),
+		WithDistributedLockPollInterval(time.Millisecond),
+	)
+
+	// When
+	values, err := cache.GetMany(ctx, []any{"key1", "key2"})
+
+	// Then the loaded value has been put back before releasing its lock
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key1": "value1", "key2": "value2"}, values)
+	assert.Equal(t, 1, cache.GetStats().LockWait)
+	assert.Equal(t, 1, cache.GetStats().LoadSuccess)
+
+	_, err = locker.TryObtain(ctx, "key1", time.Second)
+	assert.Nil(t, err)
+}
+
+func TestLoadableGetManyWhenBatchLoadFuncFailsWithStaleIfError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	loadErr := errors.New("an error has occurred while loading data from custom source")
+
+	store1 := store.NewMockStoreInterface(ctrl)
+	store1.EXPECT().Get(ctx, "key1").Return(nil, store.NotFound{})
+	store1.EXPECT().Get(ctx, "key2").Return(nil, store.NotFound{})
+	store1.EXPECT().Get(ctx, "gocache_stale_key1").Return("a stale value", nil)
+	store1.EXPECT().Get(ctx, "gocache_stale_key2").Return(nil, store.NotFound{})
+
+	batchLoadFunc := func(_ context.Context, keys []any) (map[any]any, error) {
+		return nil, loadErr
+	}
+
+	cache := NewBatchLoadable[any](batchLoadFunc, New[any](store1), WithStaleIfError(time.Hour))
+
+	// When
+	values, err := cache.GetMany(ctx, []any{"key1", "key2"})
+
+	// Then
+	var staleErr *StaleValueError
+	assert.ErrorAs(t, err, &staleErr)
+	assert.ErrorIs(t, err, loadErr)
+	assert.Equal(t, map[any]any{"key1": "a stale value"}, values)
+	assert.Equal(t, 1, cache.GetStats().StaleServed)
 }
 
 func TestLoadableDelete(t *testing.T) {
@@ -272,3 +1304,112 @@ func TestLoadableGetType(t *testing.T) {
 	// When - Then
 	assert.Equal(t, LoadableType, cache.GetType())
 }
+
+func TestLoadableCloseWaitsForBackgroundRefresh(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return("a stale value", 30*time.Second, nil)
+	cache1.EXPECT().Set(context.Background(), "my-key", "a fresh value", store.OptionsMatcher{
+		Expiration: 2 * time.Minute,
+	}).Return(nil)
+
+	release := make(chan struct{})
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		<-release
+		return "a fresh value", nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithStaleWhileRevalidate(time.Minute, 2*time.Minute))
+
+	value, err := cache.Get(ctx, "my-key")
+	assert.Nil(t, err)
+	assert.Equal(t, "a stale value", value)
+
+	// When
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+	}()
+
+	err = cache.Close(ctx)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 1, cache.GetStats().LoadSuccess)
+}
+
+func TestLoadableCloseWhenContextIsDone(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return("a stale value", 30*time.Second, nil)
+
+	release := make(chan struct{})
+	defer close(release)
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		<-release
+		return nil, errors.New("an error has occurred while loading data from custom source")
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithStaleWhileRevalidate(time.Minute, 2*time.Minute))
+
+	_, err := cache.Get(ctx, "my-key")
+	assert.Nil(t, err)
+
+	closeCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	// When
+	err = cache.Close(closeCtx)
+
+	// Then the background goroutine is stopped anyway
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, ErrClosed, cache.setter.close(ctx))
+	<-cache.setter.done
+}
+
+func TestLoadableWhenClosed(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+
+	loadFunc := func(_ context.Context, key any) (any, error) {
+		return nil, errors.New("should not be called")
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1)
+	assert.Nil(t, cache.Close(ctx))
+
+	// When
+	_, getErr := cache.Get(ctx, "my-key")
+	_, getManyErr := cache.GetMany(ctx, []any{"my-key"})
+	setErr := cache.Set(ctx, "my-key", "my-value")
+	deleteErr := cache.Delete(ctx, "my-key")
+	flushErr := cache.Flush(ctx)
+	closeErr := cache.Close(ctx)
+
+	// Then
+	assert.ErrorIs(t, getErr, ErrClosed)
+	assert.ErrorIs(t, getManyErr, ErrClosed)
+	assert.ErrorIs(t, setErr, ErrClosed)
+	assert.ErrorIs(t, deleteErr, ErrClosed)
+	assert.ErrorIs(t, flushErr, ErrClosed)
+	assert.ErrorIs(t, closeErr, ErrClosed)
+}
+
+func TestLoadableStaleKeyGetCacheKey(t *testing.T) {
+	// When - Then
+	assert.Equal(t, "gocache_stale_my-key", loadableStaleKey{"my-key"}.GetCacheKey())
+}
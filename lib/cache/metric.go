@@ -10,6 +10,13 @@ import (
 const (
 	// MetricType represents the metric cache type as a string value
 	MetricType = "metric"
+
+	// ChainReadRepairQueue is the name of the chain cache read-repair queue in metrics
+	ChainReadRepairQueue = "chain_read_repair"
+	// ChainWriteBehindQueue is the name of the chain cache write-behind queue in metrics
+	ChainWriteBehindQueue = "chain_write_behind"
+	// LoadableSetBackQueue is the name of the loadable cache set back queue in metrics
+	LoadableSetBackQueue = "loadable_set_back"
 )
 
 // MetricCache is the struct that specifies metrics available for different caches
@@ -63,11 +70,27 @@ func (c *MetricCache[T]) updateMetrics(cache CacheInterface[T]) {
 			c.updateMetrics(cache)
 		}
 
+		c.recordQueue(ChainReadRepairQueue, current.GetReadRepairQueueStats())
+		if current.writer != nil {
+			c.recordQueue(ChainWriteBehindQueue, current.GetWriteBehindQueueStats())
+		}
+
+	case *LoadableCache[T]:
+		c.recordQueue(LoadableSetBackQueue, current.GetSetBackQueueStats())
+
 	case SetterCacheInterface[T]:
 		c.metrics.RecordFromCodec(current.GetCodec())
 	}
 }
 
+// recordQueue records the statistics of the given cache queue, if the metrics
+// provider supports it
+func (c *MetricCache[T]) recordQueue(name string, stats QueueStats) {
+	if queueMetrics, ok := c.metrics.(metrics.QueueMetricsInterface); ok {
+		queueMetrics.RecordFromQueue(name, stats)
+	}
+}
+
 // GetType returns the cache type
 func (c *MetricCache[T]) GetType() string {
 	return MetricType
@@ -98,6 +98,71 @@ func TestMetricGetWhenChainCache(t *testing.T) {
 	assert.Equal(t, cacheValue, value)
 }
 
+type queueMetrics struct {
+	*metrics.MockMetricsInterface
+	*metrics.MockQueueMetricsInterface
+}
+
+func TestMetricGetWhenChainCacheRecordsQueues(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	codec1 := codec.NewMockCodecInterface(ctrl)
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return("my-value", 0*time.Second, nil)
+	cache1.EXPECT().GetCodec().AnyTimes().Return(codec1)
+
+	chainCache, err := NewChainWithOptions([]SetterCacheInterface[any]{cache1}, WithWritePolicy(WriteBehind))
+	assert.Nil(t, err)
+	defer chainCache.Close(ctx)
+
+	codecMetrics := metrics.NewMockMetricsInterface(ctrl)
+	codecMetrics.EXPECT().RecordFromCodec(codec1)
+
+	queueMetricsMock := metrics.NewMockQueueMetricsInterface(ctrl)
+	queueMetricsMock.EXPECT().RecordFromQueue(ChainReadRepairQueue, QueueStats{Size: 10000})
+	queueMetricsMock.EXPECT().RecordFromQueue(ChainWriteBehindQueue, QueueStats{Size: 10000})
+
+	cache := NewMetric[any](&queueMetrics{codecMetrics, queueMetricsMock}, chainCache)
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestMetricGetWhenLoadableCacheRecordsQueue(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return("my-value", nil)
+
+	loadable := NewLoadable[any](func(_ context.Context, _ any) (any, error) {
+		return nil, errors.New("should not be called")
+	}, cache1)
+	defer loadable.Close(ctx)
+
+	queueMetricsMock := metrics.NewMockQueueMetricsInterface(ctrl)
+	queueMetricsMock.EXPECT().RecordFromQueue(LoadableSetBackQueue, QueueStats{Size: 10000})
+
+	cache := NewMetric[any](&queueMetrics{metrics.NewMockMetricsInterface(ctrl), queueMetricsMock}, loadable)
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+}
+
 func TestMetricSet(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
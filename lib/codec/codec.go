@@ -128,6 +128,55 @@ func (c *Codec) Clear(ctx context.Context) error {
 	return err
 }
 
+// GetMany allows to retrieve the values of several key identifiers at once,
+// in a single round trip when the store supports it
+func (c *Codec) GetMany(ctx context.Context, keys []any) (map[any]any, error) {
+	values, err := store.GetMany(ctx, c.store, keys)
+
+	c.statsMtx.Lock()
+	defer c.statsMtx.Unlock()
+	if err == nil {
+		c.stats.Hits += len(values)
+		c.stats.Miss += len(keys) - len(values)
+	} else {
+		c.stats.Miss += len(keys)
+	}
+
+	return values, err
+}
+
+// SetMany allows to set several values at once, in a single round trip when
+// the store supports it
+func (c *Codec) SetMany(ctx context.Context, items map[any]any, options ...store.Option) error {
+	err := store.SetMany(ctx, c.store, items, options...)
+
+	c.statsMtx.Lock()
+	defer c.statsMtx.Unlock()
+	if err == nil {
+		c.stats.SetSuccess += len(items)
+	} else {
+		c.stats.SetError += len(items)
+	}
+
+	return err
+}
+
+// DeleteMany allows to remove several values at once, in a single round trip
+// when the store supports it
+func (c *Codec) DeleteMany(ctx context.Context, keys []any) error {
+	err := store.DeleteMany(ctx, c.store, keys)
+
+	c.statsMtx.Lock()
+	defer c.statsMtx.Unlock()
+	if err == nil {
+		c.stats.DeleteSuccess += len(keys)
+	} else {
+		c.stats.DeleteError += len(keys)
+	}
+
+	return err
+}
+
 // GetStore returns the store associated to this codec
 func (c *Codec) GetStore() store.StoreInterface {
 	return c.store
@@ -64,6 +64,20 @@ func (mr *MockCodecInterfaceMockRecorder) Delete(ctx, key interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCodecInterface)(nil).Delete), ctx, key)
 }
 
+// DeleteMany mocks base method.
+func (m *MockCodecInterface) DeleteMany(ctx context.Context, keys []any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMany", ctx, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMany indicates an expected call of DeleteMany.
+func (mr *MockCodecInterfaceMockRecorder) DeleteMany(ctx, keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockCodecInterface)(nil).DeleteMany), ctx, keys)
+}
+
 // Get mocks base method.
 func (m *MockCodecInterface) Get(ctx context.Context, key any) (any, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +93,21 @@ func (mr *MockCodecInterfaceMockRecorder) Get(ctx, key interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCodecInterface)(nil).Get), ctx, key)
 }
 
+// GetMany mocks base method.
+func (m *MockCodecInterface) GetMany(ctx context.Context, keys []any) (map[any]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMany", ctx, keys)
+	ret0, _ := ret[0].(map[any]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMany indicates an expected call of GetMany.
+func (mr *MockCodecInterfaceMockRecorder) GetMany(ctx, keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockCodecInterface)(nil).GetMany), ctx, keys)
+}
+
 // GetStats mocks base method.
 func (m *MockCodecInterface) GetStats() *Stats {
 	m.ctrl.T.Helper()
@@ -160,3 +189,22 @@ func (mr *MockCodecInterfaceMockRecorder) Set(ctx, key, value interface{}, optio
 	varargs := append([]interface{}{ctx, key, value}, options...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCodecInterface)(nil).Set), varargs...)
 }
+
+// SetMany mocks base method.
+func (m *MockCodecInterface) SetMany(ctx context.Context, items map[any]any, options ...store.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, items}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetMany", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMany indicates an expected call of SetMany.
+func (mr *MockCodecInterfaceMockRecorder) SetMany(ctx, items interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, items}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMany", reflect.TypeOf((*MockCodecInterface)(nil).SetMany), varargs...)
+}
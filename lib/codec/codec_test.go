@@ -446,3 +446,94 @@ func TestGetStats(t *testing.T) {
 	expectedStats := &Stats{}
 	assert.Equal(t, expectedStats, codec.GetStats())
 }
+
+func TestGetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := store.NewMockStoreInterface(ctrl)
+	store.EXPECT().Get(ctx, "key1").Return("value1", nil)
+	store.EXPECT().Get(ctx, "key2").Return(nil, errors.New("unable to find"))
+	store.EXPECT().Get(ctx, "key3").Return("value3", nil)
+
+	codec := New(store)
+
+	// When
+	values, err := codec.GetMany(ctx, []any{"key1", "key2", "key3"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key1": "value1", "key3": "value3"}, values)
+
+	assert.Equal(t, 2, codec.GetStats().Hits)
+	assert.Equal(t, 1, codec.GetStats().Miss)
+}
+
+func TestSetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := store.NewMockStoreInterface(ctrl)
+	store.EXPECT().Set(ctx, "key1", "value1").Return(nil)
+	store.EXPECT().Set(ctx, "key2", "value2").Return(nil)
+
+	codec := New(store)
+
+	// When
+	err := codec.SetMany(ctx, map[any]any{"key1": "value1", "key2": "value2"})
+
+	// Then
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, codec.GetStats().SetSuccess)
+	assert.Equal(t, 0, codec.GetStats().SetError)
+}
+
+func TestSetManyWhenError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := errors.New("unable to set value in store")
+
+	store := store.NewMockStoreInterface(ctrl)
+	store.EXPECT().Set(ctx, "key1", "value1").Return(expectedErr)
+
+	codec := New(store)
+
+	// When
+	err := codec.SetMany(ctx, map[any]any{"key1": "value1"})
+
+	// Then
+	assert.Equal(t, expectedErr, err)
+
+	assert.Equal(t, 0, codec.GetStats().SetSuccess)
+	assert.Equal(t, 1, codec.GetStats().SetError)
+}
+
+func TestDeleteMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := store.NewMockStoreInterface(ctrl)
+	store.EXPECT().Delete(ctx, "key1").Return(nil)
+	store.EXPECT().Delete(ctx, "key2").Return(nil)
+
+	codec := New(store)
+
+	// When
+	err := codec.DeleteMany(ctx, []any{"key1", "key2"})
+
+	// Then
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, codec.GetStats().DeleteSuccess)
+	assert.Equal(t, 0, codec.GetStats().DeleteError)
+}
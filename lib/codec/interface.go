@@ -16,6 +16,10 @@ type CodecInterface interface {
 	Invalidate(ctx context.Context, options ...store.InvalidateOption) error
 	Clear(ctx context.Context) error
 
+	GetMany(ctx context.Context, keys []any) (map[any]any, error)
+	SetMany(ctx context.Context, items map[any]any, options ...store.Option) error
+	DeleteMany(ctx context.Context, keys []any) error
+
 	GetStore() store.StoreInterface
 	GetStats() *Stats
 }
@@ -0,0 +1,143 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eko/gocache/lib/v4/store"
+)
+
+const (
+	// LockKeyPattern represents the pattern used to build the store key of a lock
+	LockKeyPattern = "gocache_lock_%s"
+)
+
+var (
+	// ErrNotObtained is returned when the lock is held by another owner
+	ErrNotObtained = errors.New("lock not obtained")
+	// ErrNotHeld is returned when releasing or refreshing a lock that has expired
+	// or has been obtained by another owner in the meantime
+	ErrNotHeld = errors.New("lock not held")
+	// ErrInvalidTTL is returned when obtaining or refreshing a lock without a positive TTL
+	ErrInvalidTTL = errors.New("lock TTL must be greater than zero")
+)
+
+// Locker obtains lease-based locks stored in a store supporting atomic operations.
+// Each lock is identified by a random owner token so only its owner can refresh
+// or release it, and it expires automatically after its TTL.
+type Locker struct {
+	store   store.AtomicStoreInterface
+	options *Options
+}
+
+// New instantiates a new locker on top of the given store
+func New(store store.AtomicStoreInterface, options ...Option) *Locker {
+	return &Locker{
+		store:   store,
+		options: applyOptions(options...),
+	}
+}
+
+// Obtain tries to obtain the lock for the given key, which is held until it is released
+// or the TTL expires. ErrNotObtained is returned when the lock is held by another owner.
+func (l *Locker) Obtain(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	return l.obtain(ctx, key, ttl, l.options.RetryInterval)
+}
+
+// TryObtain tries to obtain the lock for the given key once, regardless of the retry interval.
+// ErrNotObtained is returned when the lock is held by another owner.
+func (l *Locker) TryObtain(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	return l.obtain(ctx, key, ttl, 0)
+}
+
+func (l *Locker) obtain(ctx context.Context, key string, ttl time.Duration, retryInterval time.Duration) (*Lock, error) {
+	if ttl <= 0 {
+		return nil, ErrInvalidTTL
+	}
+
+	token, err := l.options.TokenGenerator()
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &Lock{
+		locker:   l,
+		key:      key,
+		storeKey: fmt.Sprintf(LockKeyPattern, key),
+		token:    token,
+	}
+
+	for {
+		obtained, err := l.store.SetIfNotExists(ctx, lock.storeKey, token, store.WithExpiration(ttl))
+		if err != nil {
+			return nil, err
+		}
+		if obtained {
+			return lock, nil
+		}
+
+		if retryInterval <= 0 {
+			return nil, ErrNotObtained
+		}
+
+		timer := time.NewTimer(retryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("%w: %v", ErrNotObtained, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// Lock represents an obtained lock
+type Lock struct {
+	locker   *Locker
+	key      string
+	storeKey string
+	token    string
+}
+
+// Key returns the key the lock has been obtained for
+func (l *Lock) Key() string {
+	return l.key
+}
+
+// Token returns the owner token of the lock
+func (l *Lock) Token() string {
+	return l.token
+}
+
+// Refresh extends the lock for the given TTL.
+// ErrNotHeld is returned when the lock has expired or is held by another owner.
+func (l *Lock) Refresh(ctx context.Context, ttl time.Duration) error {
+	if ttl <= 0 {
+		return ErrInvalidTTL
+	}
+
+	refreshed, err := l.locker.store.CompareAndSwap(ctx, l.storeKey, l.token, l.token, store.WithExpiration(ttl))
+	if err != nil {
+		return err
+	}
+	if !refreshed {
+		return ErrNotHeld
+	}
+
+	return nil
+}
+
+// Release releases the lock so it can be obtained by another owner.
+// ErrNotHeld is returned when the lock has expired or is held by another owner.
+func (l *Lock) Release(ctx context.Context) error {
+	released, err := l.locker.store.CompareAndDelete(ctx, l.storeKey, l.token)
+	if err != nil {
+		return err
+	}
+	if !released {
+		return ErrNotHeld
+	}
+
+	return nil
+}
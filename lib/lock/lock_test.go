@@ -0,0 +1,251 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eko/gocache/lib/v4/store"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func staticToken() (string, error) {
+	return "my-token", nil
+}
+
+func TestNew(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	atomicStore := store.NewMockAtomicStoreInterface(ctrl)
+
+	// When
+	locker := New(atomicStore, WithRetryInterval(10*time.Millisecond))
+
+	// Then
+	assert.IsType(t, new(Locker), locker)
+	assert.Equal(t, atomicStore, locker.store)
+	assert.Equal(t, 10*time.Millisecond, locker.options.RetryInterval)
+	assert.NotNil(t, locker.options.TokenGenerator)
+}
+
+func TestLockerObtain(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	atomicStore := store.NewMockAtomicStoreInterface(ctrl)
+	atomicStore.EXPECT().SetIfNotExists(ctx, "gocache_lock_my-job", "my-token", store.OptionsMatcher{
+		Expiration: 5 * time.Second,
+	}).Return(true, nil)
+
+	locker := New(atomicStore, WithTokenGenerator(staticToken))
+
+	// When
+	lock, err := locker.Obtain(ctx, "my-job", 5*time.Second)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-job", lock.Key())
+	assert.Equal(t, "my-token", lock.Token())
+}
+
+func TestLockerObtainWhenHeldByAnotherOwner(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	atomicStore := store.NewMockAtomicStoreInterface(ctrl)
+	atomicStore.EXPECT().SetIfNotExists(ctx, "gocache_lock_my-job", gomock.Any(), gomock.Any()).Return(false, nil)
+
+	locker := New(atomicStore)
+
+	// When
+	lock, err := locker.Obtain(ctx, "my-job", 5*time.Second)
+
+	// Then
+	assert.Equal(t, ErrNotObtained, err)
+	assert.Nil(t, lock)
+}
+
+func TestLockerObtainWhenStoreError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := errors.New("unable to reach store")
+
+	atomicStore := store.NewMockAtomicStoreInterface(ctrl)
+	atomicStore.EXPECT().SetIfNotExists(ctx, "gocache_lock_my-job", gomock.Any(), gomock.Any()).Return(false, expectedErr)
+
+	locker := New(atomicStore)
+
+	// When
+	lock, err := locker.Obtain(ctx, "my-job", 5*time.Second)
+
+	// Then
+	assert.Equal(t, expectedErr, err)
+	assert.Nil(t, lock)
+}
+
+func TestLockerObtainWhenInvalidTTL(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	locker := New(store.NewMockAtomicStoreInterface(ctrl))
+
+	// When
+	lock, err := locker.Obtain(context.Background(), "my-job", 0)
+
+	// Then
+	assert.Equal(t, ErrInvalidTTL, err)
+	assert.Nil(t, lock)
+}
+
+func TestLockerObtainWithRetry(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	atomicStore := store.NewMockAtomicStoreInterface(ctrl)
+	gomock.InOrder(
+		atomicStore.EXPECT().SetIfNotExists(ctx, "gocache_lock_my-job", "my-token", gomock.Any()).Return(false, nil).Times(2),
+		atomicStore.EXPECT().SetIfNotExists(ctx, "gocache_lock_my-job", "my-token", gomock.Any()).Return(true, nil),
+	)
+
+	locker := New(atomicStore, WithTokenGenerator(staticToken), WithRetryInterval(time.Millisecond))
+
+	// When
+	lock, err := locker.Obtain(ctx, "my-job", 5*time.Second)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-token", lock.Token())
+}
+
+func TestLockerObtainWithRetryWhenContextIsDone(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	atomicStore := store.NewMockAtomicStoreInterface(ctrl)
+	atomicStore.EXPECT().SetIfNotExists(ctx, "gocache_lock_my-job", gomock.Any(), gomock.Any()).Return(false, nil).MinTimes(1)
+
+	locker := New(atomicStore, WithRetryInterval(5*time.Millisecond))
+
+	// When
+	lock, err := locker.Obtain(ctx, "my-job", 5*time.Second)
+
+	// Then
+	assert.True(t, errors.Is(err, ErrNotObtained))
+	assert.Nil(t, lock)
+}
+
+func TestLockerTryObtainIgnoresRetryInterval(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	atomicStore := store.NewMockAtomicStoreInterface(ctrl)
+	atomicStore.EXPECT().SetIfNotExists(ctx, "gocache_lock_my-job", gomock.Any(), gomock.Any()).Return(false, nil)
+
+	locker := New(atomicStore, WithRetryInterval(time.Millisecond))
+
+	// When
+	lock, err := locker.TryObtain(ctx, "my-job", 5*time.Second)
+
+	// Then
+	assert.Equal(t, ErrNotObtained, err)
+	assert.Nil(t, lock)
+}
+
+func TestLockRefresh(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	atomicStore := store.NewMockAtomicStoreInterface(ctrl)
+	atomicStore.EXPECT().SetIfNotExists(ctx, "gocache_lock_my-job", "my-token", gomock.Any()).Return(true, nil)
+	atomicStore.EXPECT().CompareAndSwap(ctx, "gocache_lock_my-job", "my-token", "my-token", store.OptionsMatcher{
+		Expiration: 10 * time.Second,
+	}).Return(true, nil)
+
+	locker := New(atomicStore, WithTokenGenerator(staticToken))
+	lock, _ := locker.Obtain(ctx, "my-job", 5*time.Second)
+
+	// When
+	err := lock.Refresh(ctx, 10*time.Second)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestLockRefreshWhenNotHeld(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	atomicStore := store.NewMockAtomicStoreInterface(ctrl)
+	atomicStore.EXPECT().SetIfNotExists(ctx, "gocache_lock_my-job", "my-token", gomock.Any()).Return(true, nil)
+	atomicStore.EXPECT().CompareAndSwap(ctx, "gocache_lock_my-job", "my-token", "my-token", gomock.Any()).Return(false, nil)
+
+	locker := New(atomicStore, WithTokenGenerator(staticToken))
+	lock, _ := locker.Obtain(ctx, "my-job", 5*time.Second)
+
+	// When
+	err := lock.Refresh(ctx, 10*time.Second)
+
+	// Then
+	assert.Equal(t, ErrNotHeld, err)
+}
+
+func TestLockRelease(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	atomicStore := store.NewMockAtomicStoreInterface(ctrl)
+	atomicStore.EXPECT().SetIfNotExists(ctx, "gocache_lock_my-job", "my-token", gomock.Any()).Return(true, nil)
+	atomicStore.EXPECT().CompareAndDelete(ctx, "gocache_lock_my-job", "my-token").Return(true, nil)
+
+	locker := New(atomicStore, WithTokenGenerator(staticToken))
+	lock, _ := locker.Obtain(ctx, "my-job", 5*time.Second)
+
+	// When
+	err := lock.Release(ctx)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestLockReleaseWhenNotHeld(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	atomicStore := store.NewMockAtomicStoreInterface(ctrl)
+	atomicStore.EXPECT().SetIfNotExists(ctx, "gocache_lock_my-job", "my-token", gomock.Any()).Return(true, nil)
+	atomicStore.EXPECT().CompareAndDelete(ctx, "gocache_lock_my-job", "my-token").Return(false, nil)
+
+	locker := New(atomicStore, WithTokenGenerator(staticToken))
+	lock, _ := locker.Obtain(ctx, "my-job", 5*time.Second)
+
+	// When
+	err := lock.Release(ctx)
+
+	// Then
+	assert.Equal(t, ErrNotHeld, err)
+}
@@ -0,0 +1,120 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/eko/gocache/lib/v4/store"
+)
+
+// NewInMemory instantiates a new locker keeping its locks in memory.
+// Locks are only shared by the current process, which makes it useful for tests.
+func NewInMemory(options ...Option) *Locker {
+	return New(newMemoryStore(), options...)
+}
+
+type memoryItem struct {
+	value     any
+	expiresAt time.Time
+}
+
+// memoryStore is a minimal in-memory store supporting atomic operations
+type memoryStore struct {
+	mu    sync.Mutex
+	items map[any]memoryItem
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		items: make(map[any]memoryItem),
+	}
+}
+
+// get returns the item stored for the given key, the lock must be held
+func (s *memoryStore) get(key any) (memoryItem, bool) {
+	item, ok := s.items[key]
+	if ok && !item.expiresAt.IsZero() && !time.Now().Before(item.expiresAt) {
+		delete(s.items, key)
+		return memoryItem{}, false
+	}
+
+	return item, ok
+}
+
+// set stores the given value, the lock must be held
+func (s *memoryStore) set(key any, value any, options ...store.Option) {
+	opts := store.ApplyOptions(options...)
+
+	item := memoryItem{value: value}
+	if opts.Expiration > 0 {
+		item.expiresAt = time.Now().Add(opts.Expiration)
+	}
+
+	s.items[key] = item
+}
+
+func (s *memoryStore) Increment(_ context.Context, key any, delta int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.get(key)
+	if !ok {
+		item = memoryItem{value: int64(0)}
+	}
+
+	counter, isCounter := item.value.(int64)
+	if !isCounter {
+		return 0, fmt.Errorf("value of type %T is not a counter", item.value)
+	}
+
+	item.value = counter + delta
+	s.items[key] = item
+
+	return counter + delta, nil
+}
+
+func (s *memoryStore) Decrement(ctx context.Context, key any, delta int64) (int64, error) {
+	return s.Increment(ctx, key, -delta)
+}
+
+func (s *memoryStore) SetIfNotExists(_ context.Context, key any, value any, options ...store.Option) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.get(key); ok {
+		return false, nil
+	}
+
+	s.set(key, value, options...)
+
+	return true, nil
+}
+
+func (s *memoryStore) CompareAndSwap(_ context.Context, key any, oldValue any, newValue any, options ...store.Option) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if item, ok := s.get(key); !ok || !reflect.DeepEqual(item.value, oldValue) {
+		return false, nil
+	}
+
+	s.set(key, newValue, options...)
+
+	return true, nil
+}
+
+func (s *memoryStore) CompareAndDelete(_ context.Context, key any, oldValue any) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if item, ok := s.get(key); !ok || !reflect.DeepEqual(item.value, oldValue) {
+		return false, nil
+	}
+
+	delete(s.items, key)
+
+	return true, nil
+}
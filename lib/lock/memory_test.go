@@ -0,0 +1,65 @@
+package lock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryLocker(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	locker := NewInMemory()
+
+	// When - Then
+	lock, err := locker.Obtain(ctx, "my-job", time.Minute)
+	assert.Nil(t, err)
+
+	_, err = locker.Obtain(ctx, "my-job", time.Minute)
+	assert.Equal(t, ErrNotObtained, err)
+
+	assert.Nil(t, lock.Refresh(ctx, time.Minute))
+	assert.Nil(t, lock.Release(ctx))
+	assert.Equal(t, ErrNotHeld, lock.Release(ctx))
+
+	otherLock, err := locker.Obtain(ctx, "my-job", time.Minute)
+	assert.Nil(t, err)
+	assert.NotEqual(t, lock.Token(), otherLock.Token())
+}
+
+func TestInMemoryLockerWhenLockExpires(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	locker := NewInMemory()
+
+	lock, err := locker.Obtain(ctx, "my-job", 10*time.Millisecond)
+	assert.Nil(t, err)
+
+	// When
+	time.Sleep(20 * time.Millisecond)
+
+	// Then
+	assert.Equal(t, ErrNotHeld, lock.Refresh(ctx, time.Minute))
+
+	_, err = locker.Obtain(ctx, "my-job", time.Minute)
+	assert.Nil(t, err)
+}
+
+func TestMemoryStoreIncrement(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	store := newMemoryStore()
+
+	// When
+	_, _ = store.Increment(ctx, "my-counter", 5)
+	value, err := store.Decrement(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), value)
+}
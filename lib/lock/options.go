@@ -0,0 +1,53 @@
+package lock
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// Option represents a locker option function.
+type Option func(o *Options)
+
+type Options struct {
+	RetryInterval  time.Duration
+	TokenGenerator func() (string, error)
+}
+
+func applyOptions(opts ...Option) *Options {
+	o := &Options{
+		TokenGenerator: generateToken,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithRetryInterval allows to retry obtaining a lock held by another owner at the
+// given interval, until it is obtained or the context is done.
+// By default, Obtain returns ErrNotObtained immediately.
+func WithRetryInterval(interval time.Duration) Option {
+	return func(o *Options) {
+		o.RetryInterval = interval
+	}
+}
+
+// WithTokenGenerator allows to specify how owner tokens are generated.
+// By default, tokens are 16 random bytes encoded as hexadecimal.
+func WithTokenGenerator(generator func() (string, error)) Option {
+	return func(o *Options) {
+		o.TokenGenerator = generator
+	}
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
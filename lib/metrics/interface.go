@@ -6,3 +6,21 @@ import "github.com/eko/gocache/lib/v4/codec"
 type MetricsInterface interface {
 	RecordFromCodec(codec codec.CodecInterface)
 }
+
+// QueueStats represents the statistics of a queue of values applied in background
+type QueueStats struct {
+	// Depth is the number of values waiting to be applied
+	Depth int
+	// Size is the number of values the queue can hold
+	Size int
+	// Dropped is the number of values dropped because the queue was full or closed
+	Dropped int
+	// Synchronous is the number of values applied synchronously because the queue was full
+	Synchronous int
+}
+
+// QueueMetricsInterface represents the providers also able to record the statistics
+// of the queues used by caches to apply values in background
+type QueueMetricsInterface interface {
+	RecordFromQueue(name string, stats QueueStats)
+}
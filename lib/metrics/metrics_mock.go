@@ -45,3 +45,38 @@ func (mr *MockMetricsInterfaceMockRecorder) RecordFromCodec(codec interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFromCodec", reflect.TypeOf((*MockMetricsInterface)(nil).RecordFromCodec), codec)
 }
+
+// MockQueueMetricsInterface is a mock of QueueMetricsInterface interface.
+type MockQueueMetricsInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockQueueMetricsInterfaceMockRecorder
+}
+
+// MockQueueMetricsInterfaceMockRecorder is the mock recorder for MockQueueMetricsInterface.
+type MockQueueMetricsInterfaceMockRecorder struct {
+	mock *MockQueueMetricsInterface
+}
+
+// NewMockQueueMetricsInterface creates a new mock instance.
+func NewMockQueueMetricsInterface(ctrl *gomock.Controller) *MockQueueMetricsInterface {
+	mock := &MockQueueMetricsInterface{ctrl: ctrl}
+	mock.recorder = &MockQueueMetricsInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQueueMetricsInterface) EXPECT() *MockQueueMetricsInterfaceMockRecorder {
+	return m.recorder
+}
+
+// RecordFromQueue mocks base method.
+func (m *MockQueueMetricsInterface) RecordFromQueue(name string, stats QueueStats) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordFromQueue", name, stats)
+}
+
+// RecordFromQueue indicates an expected call of RecordFromQueue.
+func (mr *MockQueueMetricsInterfaceMockRecorder) RecordFromQueue(name, stats interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFromQueue", reflect.TypeOf((*MockQueueMetricsInterface)(nil).RecordFromQueue), name, stats)
+}
@@ -1,7 +1,11 @@
 package metrics
 
 import (
+	"context"
+	"sync"
+
 	"github.com/eko/gocache/lib/v4/codec"
+	"github.com/eko/gocache/lib/v4/store"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -12,11 +16,24 @@ const (
 
 var cacheCollector *prometheus.GaugeVec = initCacheCollector(namespaceCache)
 
+var queueCollector *prometheus.GaugeVec = initQueueCollector(namespaceCache)
+
+// codecRecord is either a codec which metrics have to be recorded or a flush barrier
+type codecRecord struct {
+	codec   codec.CodecInterface
+	flushed chan struct{}
+}
+
 // Prometheus represents the prometheus struct for collecting metrics
 type Prometheus struct {
-	service      string
-	collector    *prometheus.GaugeVec
-	codecChannel chan codec.CodecInterface
+	service        string
+	collector      *prometheus.GaugeVec
+	queueCollector *prometheus.GaugeVec
+	codecChannel   chan codecRecord
+	stop           chan struct{}
+	done           chan struct{}
+	closeMtx       sync.Mutex
+	closed         bool
 }
 
 func initCacheCollector(namespace string) *prometheus.GaugeVec {
@@ -31,12 +48,27 @@ func initCacheCollector(namespace string) *prometheus.GaugeVec {
 	return c
 }
 
+func initQueueCollector(namespace string) *prometheus.GaugeVec {
+	c := promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      "queue",
+			Namespace: namespace,
+			Help:      "This represent the statistics of the queues used by caches to apply values in background",
+		},
+		[]string{"service", "queue", "metric"},
+	)
+	return c
+}
+
 // NewPrometheus initializes a new prometheus metric instance
 func NewPrometheus(service string) *Prometheus {
 	prometheus := &Prometheus{
-		service:      service,
-		collector:    cacheCollector,
-		codecChannel: make(chan codec.CodecInterface, 10000),
+		service:        service,
+		collector:      cacheCollector,
+		queueCollector: queueCollector,
+		codecChannel:   make(chan codecRecord, 10000),
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
 	}
 
 	go prometheus.recorder()
@@ -51,25 +83,131 @@ func (m *Prometheus) record(store, metric string, value float64) {
 
 // Recorder records metrics in prometheus by retrieving values from the codec channel
 func (m *Prometheus) recorder() {
-	for codec := range m.codecChannel {
-		stats := codec.GetStats()
-		storeType := codec.GetStore().GetType()
-
-		m.record(storeType, "hit_count", float64(stats.Hits))
-		m.record(storeType, "miss_count", float64(stats.Miss))
+	defer close(m.done)
 
-		m.record(storeType, "set_success", float64(stats.SetSuccess))
-		m.record(storeType, "set_error", float64(stats.SetError))
+	for {
+		select {
+		case record := <-m.codecChannel:
+			m.handle(record)
 
-		m.record(storeType, "delete_success", float64(stats.DeleteSuccess))
-		m.record(storeType, "delete_error", float64(stats.DeleteError))
+		case <-m.stop:
+			// Record the codecs sent before stopping
+			for {
+				select {
+				case record := <-m.codecChannel:
+					m.handle(record)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
 
-		m.record(storeType, "invalidate_success", float64(stats.InvalidateSuccess))
-		m.record(storeType, "invalidate_error", float64(stats.InvalidateError))
+func (m *Prometheus) handle(record codecRecord) {
+	if record.flushed != nil {
+		close(record.flushed)
+		return
 	}
+
+	m.recordCodec(record.codec)
+}
+
+// recordCodec records the current statistics of the given codec
+func (m *Prometheus) recordCodec(codec codec.CodecInterface) {
+	stats := codec.GetStats()
+	storeType := codec.GetStore().GetType()
+
+	m.record(storeType, "hit_count", float64(stats.Hits))
+	m.record(storeType, "miss_count", float64(stats.Miss))
+
+	m.record(storeType, "set_success", float64(stats.SetSuccess))
+	m.record(storeType, "set_error", float64(stats.SetError))
+
+	m.record(storeType, "delete_success", float64(stats.DeleteSuccess))
+	m.record(storeType, "delete_error", float64(stats.DeleteError))
+
+	m.record(storeType, "invalidate_success", float64(stats.InvalidateSuccess))
+	m.record(storeType, "invalidate_error", float64(stats.InvalidateError))
 }
 
-// RecordFromCodec sends the given codec into the codec channel to be read from recorder
+// RecordFromCodec sends the given codec into the codec channel to be read from recorder.
+// The codec is ignored once the recorder has been closed.
 func (m *Prometheus) RecordFromCodec(codec codec.CodecInterface) {
-	m.codecChannel <- codec
+	select {
+	case m.codecChannel <- codecRecord{codec: codec}:
+	case <-m.stop:
+	}
+}
+
+// RecordFromQueue records the current statistics of the cache queue with the given name,
+// labelled by queue in the cache_queue metric. They are ignored once the recorder has
+// been closed.
+func (m *Prometheus) RecordFromQueue(name string, stats QueueStats) {
+	m.closeMtx.Lock()
+	defer m.closeMtx.Unlock()
+
+	if m.closed {
+		return
+	}
+
+	m.recordQueue(name, "depth", float64(stats.Depth))
+	m.recordQueue(name, "size", float64(stats.Size))
+	m.recordQueue(name, "dropped", float64(stats.Dropped))
+	m.recordQueue(name, "synchronous", float64(stats.Synchronous))
+}
+
+// recordQueue records a metric of the queue with the given name
+func (m *Prometheus) recordQueue(queue, metric string, value float64) {
+	m.queueCollector.WithLabelValues(m.service, queue, metric).Set(value)
+}
+
+// Flush waits until the metrics of the codecs sent so far have been recorded
+func (m *Prometheus) Flush(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	flushed := make(chan struct{})
+
+	select {
+	case m.codecChannel <- codecRecord{flushed: flushed}:
+	case <-m.stop:
+		return store.ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-flushed:
+		return nil
+	case <-m.done:
+		select {
+		case <-flushed:
+			return nil
+		default:
+			return store.ErrClosed
+		}
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close records the metrics of the pending codecs and stops the recorder goroutine
+func (m *Prometheus) Close(ctx context.Context) error {
+	m.closeMtx.Lock()
+	if m.closed {
+		m.closeMtx.Unlock()
+		return store.ErrClosed
+	}
+	m.closed = true
+	close(m.stop)
+	m.closeMtx.Unlock()
+
+	select {
+	case <-m.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
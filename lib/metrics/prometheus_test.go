@@ -1,8 +1,8 @@
 package metrics
 
 import (
+	"context"
 	"testing"
-	"time"
 
 	"github.com/eko/gocache/lib/v4/codec"
 	"github.com/eko/gocache/lib/v4/store"
@@ -71,9 +71,7 @@ func TestRecordFromCodec(t *testing.T) {
 	metrics.RecordFromCodec(testCodec)
 
 	// Wait for data to be processed
-	for len(metrics.codecChannel) > 0 {
-		time.Sleep(1 * time.Millisecond)
-	}
+	metrics.Flush(context.Background())
 
 	// Then
 	testCases := []struct {
@@ -124,3 +122,81 @@ func TestRecordFromCodec(t *testing.T) {
 		assert.Equal(t, tc.expected, v)
 	}
 }
+
+func TestRecordFromQueue(t *testing.T) {
+	// Given
+	metrics := NewPrometheus("my-test-service-name")
+
+	// When
+	metrics.RecordFromQueue("chain_read_repair", QueueStats{Depth: 12, Size: 1000, Dropped: 3, Synchronous: 5})
+
+	// Then
+	testCases := []struct {
+		metricName string
+		expected   float64
+	}{
+		{metricName: "depth", expected: 12},
+		{metricName: "size", expected: 1000},
+		{metricName: "dropped", expected: 3},
+		{metricName: "synchronous", expected: 5},
+	}
+
+	for _, tc := range testCases {
+		metric, err := metrics.queueCollector.GetMetricWithLabelValues("my-test-service-name", "chain_read_repair", tc.metricName)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		assert.Equal(t, tc.expected, testutil.ToFloat64(metric))
+	}
+}
+
+func TestPrometheusCloseRecordsPendingCodecs(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	memcacheStore := store.NewMockStoreInterface(ctrl)
+	memcacheStore.EXPECT().GetType().Return("memcache")
+
+	testCodec := codec.NewMockCodecInterface(ctrl)
+	testCodec.EXPECT().GetStats().Return(&codec.Stats{Hits: 7})
+	testCodec.EXPECT().GetStore().Return(memcacheStore)
+
+	metrics := NewPrometheus("my-test-service-name")
+	metrics.RecordFromCodec(testCodec)
+
+	// When
+	err := metrics.Close(ctx)
+
+	// Then
+	assert.Nil(t, err)
+
+	metric, err := metrics.collector.GetMetricWithLabelValues("my-test-service-name", "memcache", "hit_count")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.Equal(t, float64(7), testutil.ToFloat64(metric))
+}
+
+func TestPrometheusWhenClosed(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	testCodec := codec.NewMockCodecInterface(ctrl)
+
+	metrics := NewPrometheus("my-test-service-name")
+	assert.Nil(t, metrics.Close(ctx))
+
+	// When
+	metrics.RecordFromCodec(testCodec)
+	flushErr := metrics.Flush(ctx)
+	closeErr := metrics.Close(ctx)
+
+	// Then
+	assert.ErrorIs(t, flushErr, store.ErrClosed)
+	assert.ErrorIs(t, closeErr, store.ErrClosed)
+}
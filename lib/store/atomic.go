@@ -0,0 +1,86 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// Increment atomically adds delta to the counter stored at the given key, which is
+// created with a zero value when missing, and returns its new value.
+// A *NotSupported error is returned if the store lacks the atomic capability.
+func Increment(ctx context.Context, store StoreInterface, key any, delta int64) (int64, error) {
+	atomicStore, ok := store.(AtomicStoreInterface)
+	if !ok {
+		return 0, NotSupportedOperation(store.GetType(), "Increment")
+	}
+
+	return atomicStore.Increment(ctx, key, delta)
+}
+
+// Decrement atomically subtracts delta from the counter stored at the given key, which is
+// created with a zero value when missing, and returns its new value.
+// A *NotSupported error is returned if the store lacks the atomic capability.
+func Decrement(ctx context.Context, store StoreInterface, key any, delta int64) (int64, error) {
+	atomicStore, ok := store.(AtomicStoreInterface)
+	if !ok {
+		return 0, NotSupportedOperation(store.GetType(), "Decrement")
+	}
+
+	return atomicStore.Decrement(ctx, key, delta)
+}
+
+// SetIfNotExists defines the value only if the key does not exist yet and returns
+// whether the value has been set.
+// A *NotSupported error is returned if the store lacks the atomic capability.
+func SetIfNotExists(ctx context.Context, store StoreInterface, key any, value any, options ...Option) (bool, error) {
+	atomicStore, ok := store.(AtomicStoreInterface)
+	if !ok {
+		return false, NotSupportedOperation(store.GetType(), "SetIfNotExists")
+	}
+
+	return atomicStore.SetIfNotExists(ctx, key, value, options...)
+}
+
+// CompareAndSwap replaces the value only if the current one equals oldValue and returns
+// whether the value has been swapped.
+// A *NotSupported error is returned if the store lacks the atomic capability.
+func CompareAndSwap(ctx context.Context, store StoreInterface, key any, oldValue any, newValue any, options ...Option) (bool, error) {
+	atomicStore, ok := store.(AtomicStoreInterface)
+	if !ok {
+		return false, NotSupportedOperation(store.GetType(), "CompareAndSwap")
+	}
+
+	return atomicStore.CompareAndSwap(ctx, key, oldValue, newValue, options...)
+}
+
+// CompareAndDelete removes the key only if its current value equals oldValue and returns
+// whether the key has been deleted.
+// A *NotSupported error is returned if the store lacks the atomic capability.
+func CompareAndDelete(ctx context.Context, store StoreInterface, key any, oldValue any) (bool, error) {
+	atomicStore, ok := store.(AtomicStoreInterface)
+	if !ok {
+		return false, NotSupportedOperation(store.GetType(), "CompareAndDelete")
+	}
+
+	return atomicStore.CompareAndDelete(ctx, key, oldValue)
+}
+
+// ToInt64 converts the stored value of a counter to an integer, as stores may return
+// counters as integers, strings or bytes depending on how they have been written
+func ToInt64(value any) (int64, error) {
+	switch v := value.(type) {
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	case []byte:
+		return strconv.ParseInt(string(v), 10, 64)
+	}
+
+	return 0, fmt.Errorf("value of type %T is not a counter", value)
+}
@@ -0,0 +1,192 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type atomicStore struct {
+	*MockStoreInterface
+	*MockAtomicStoreInterface
+}
+
+func TestIncrementWhenAtomicStore(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	atomic := NewMockAtomicStoreInterface(ctrl)
+	atomic.EXPECT().Increment(ctx, "my-counter", int64(2)).Return(int64(5), nil)
+
+	store := &atomicStore{NewMockStoreInterface(ctrl), atomic}
+
+	// When
+	value, err := Increment(ctx, store, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), value)
+}
+
+func TestIncrementWhenNotAtomicStore(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := NewMockStoreInterface(ctrl)
+	store.EXPECT().GetType().Return("mock")
+
+	// When
+	value, err := Increment(ctx, store, "my-counter", 2)
+
+	// Then
+	assert.True(t, errors.Is(err, &NotSupported{}))
+	assert.Equal(t, int64(0), value)
+
+	var notSupported *NotSupported
+	assert.True(t, errors.As(err, &notSupported))
+	assert.Equal(t, "mock", notSupported.StoreType())
+	assert.Equal(t, "Increment", notSupported.Operation())
+}
+
+func TestDecrementWhenAtomicStore(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	atomic := NewMockAtomicStoreInterface(ctrl)
+	atomic.EXPECT().Decrement(ctx, "my-counter", int64(2)).Return(int64(3), nil)
+
+	store := &atomicStore{NewMockStoreInterface(ctrl), atomic}
+
+	// When
+	value, err := Decrement(ctx, store, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), value)
+}
+
+func TestSetIfNotExistsWhenAtomicStore(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	atomic := NewMockAtomicStoreInterface(ctrl)
+	atomic.EXPECT().SetIfNotExists(ctx, "my-key", "my-value", OptionsMatcher{
+		Expiration: 5 * time.Second,
+	}).Return(true, nil)
+
+	store := &atomicStore{NewMockStoreInterface(ctrl), atomic}
+
+	// When
+	set, err := SetIfNotExists(ctx, store, "my-key", "my-value", WithExpiration(5*time.Second))
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, set)
+}
+
+func TestSetIfNotExistsWhenNotAtomicStore(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := NewMockStoreInterface(ctrl)
+	store.EXPECT().GetType().Return("mock")
+
+	// When
+	set, err := SetIfNotExists(ctx, store, "my-key", "my-value")
+
+	// Then
+	assert.True(t, errors.Is(err, &NotSupported{}))
+	assert.False(t, set)
+}
+
+func TestCompareAndSwapWhenAtomicStore(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	atomic := NewMockAtomicStoreInterface(ctrl)
+	atomic.EXPECT().CompareAndSwap(ctx, "my-key", "old-value", "new-value").Return(true, nil)
+
+	store := &atomicStore{NewMockStoreInterface(ctrl), atomic}
+
+	// When
+	swapped, err := CompareAndSwap(ctx, store, "my-key", "old-value", "new-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, swapped)
+}
+
+func TestCompareAndDeleteWhenAtomicStore(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	atomic := NewMockAtomicStoreInterface(ctrl)
+	atomic.EXPECT().CompareAndDelete(ctx, "my-key", "old-value").Return(false, nil)
+
+	store := &atomicStore{NewMockStoreInterface(ctrl), atomic}
+
+	// When
+	deleted, err := CompareAndDelete(ctx, store, "my-key", "old-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, deleted)
+}
+
+func TestCompareAndDeleteWhenNotAtomicStore(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := NewMockStoreInterface(ctrl)
+	store.EXPECT().GetType().Return("mock")
+
+	// When
+	deleted, err := CompareAndDelete(ctx, store, "my-key", "old-value")
+
+	// Then
+	assert.True(t, errors.Is(err, &NotSupported{}))
+	assert.False(t, deleted)
+}
+
+func TestToInt64(t *testing.T) {
+	for _, value := range []any{int64(42), 42, int32(42), "42", []byte("42")} {
+		// When
+		counter, err := ToInt64(value)
+
+		// Then
+		assert.Nil(t, err)
+		assert.Equal(t, int64(42), counter)
+	}
+}
+
+func TestToInt64WhenValueIsNotACounter(t *testing.T) {
+	// When
+	counter, err := ToInt64(4.2)
+
+	// Then
+	assert.EqualError(t, err, "value of type float64 is not a counter")
+	assert.Equal(t, int64(0), counter)
+
+	_, err = ToInt64("not a number")
+	assert.Error(t, err)
+}
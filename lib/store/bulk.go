@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+)
+
+// GetMany returns values stored for the given keys, using the store bulk capability
+// when available or one Get call per key otherwise. Keys that cannot be found are
+// absent from the returned map.
+func GetMany(ctx context.Context, store StoreInterface, keys []any) (map[any]any, error) {
+	if bulkStore, ok := store.(BulkStoreInterface); ok {
+		return bulkStore.GetMany(ctx, keys)
+	}
+
+	values := make(map[any]any, len(keys))
+	for _, key := range keys {
+		value, err := store.Get(ctx, key)
+		if err != nil {
+			continue
+		}
+
+		values[key] = value
+	}
+
+	return values, nil
+}
+
+// SetMany defines the given items, using the store bulk capability when available
+// or one Set call per item otherwise. The first error encountered is returned.
+func SetMany(ctx context.Context, store StoreInterface, items map[any]any, options ...Option) error {
+	if bulkStore, ok := store.(BulkStoreInterface); ok {
+		return bulkStore.SetMany(ctx, items, options...)
+	}
+
+	var firstErr error
+	for key, value := range items {
+		if err := store.Set(ctx, key, value, options...); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// DeleteMany removes the given keys, using the store bulk capability when available
+// or one Delete call per key otherwise. The first error encountered is returned.
+func DeleteMany(ctx context.Context, store StoreInterface, keys []any) error {
+	if bulkStore, ok := store.(BulkStoreInterface); ok {
+		return bulkStore.DeleteMany(ctx, keys)
+	}
+
+	var firstErr error
+	for _, key := range keys {
+		if err := store.Delete(ctx, key); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
@@ -0,0 +1,126 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type bulkStore struct {
+	*MockStoreInterface
+	*MockBulkStoreInterface
+}
+
+func TestGetManyWhenBulkStore(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	bulk := NewMockBulkStoreInterface(ctrl)
+	bulk.EXPECT().GetMany(ctx, []any{"key1", "key2"}).Return(map[any]any{"key1": "value1"}, nil)
+
+	store := &bulkStore{NewMockStoreInterface(ctrl), bulk}
+
+	// When
+	values, err := GetMany(ctx, store, []any{"key1", "key2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key1": "value1"}, values)
+}
+
+func TestGetManyWhenNotBulkStore(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := NewMockStoreInterface(ctrl)
+	store.EXPECT().Get(ctx, "key1").Return("value1", nil)
+	store.EXPECT().Get(ctx, "key2").Return(nil, NotFound{})
+
+	// When
+	values, err := GetMany(ctx, store, []any{"key1", "key2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key1": "value1"}, values)
+}
+
+func TestSetManyWhenBulkStore(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	items := map[any]any{"key1": "value1", "key2": "value2"}
+
+	bulk := NewMockBulkStoreInterface(ctrl)
+	bulk.EXPECT().SetMany(ctx, items, OptionsMatcher{Tags: []string{"tag1"}}).Return(nil)
+
+	store := &bulkStore{NewMockStoreInterface(ctrl), bulk}
+
+	// When
+	err := SetMany(ctx, store, items, WithTags([]string{"tag1"}))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestSetManyWhenNotBulkStore(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := errors.New("unable to set value")
+
+	store := NewMockStoreInterface(ctrl)
+	store.EXPECT().Set(ctx, "key1", "value1", OptionsMatcher{Tags: []string{"tag1"}}).Return(nil)
+	store.EXPECT().Set(ctx, "key2", "value2", OptionsMatcher{Tags: []string{"tag1"}}).Return(expectedErr)
+
+	// When
+	err := SetMany(ctx, store, map[any]any{"key1": "value1", "key2": "value2"}, WithTags([]string{"tag1"}))
+
+	// Then
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestDeleteManyWhenBulkStore(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	bulk := NewMockBulkStoreInterface(ctrl)
+	bulk.EXPECT().DeleteMany(ctx, []any{"key1", "key2"}).Return(nil)
+
+	store := &bulkStore{NewMockStoreInterface(ctrl), bulk}
+
+	// When
+	err := DeleteMany(ctx, store, []any{"key1", "key2"})
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestDeleteManyWhenNotBulkStore(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := NewMockStoreInterface(ctrl)
+	store.EXPECT().Delete(ctx, "key1").Return(nil)
+	store.EXPECT().Delete(ctx, "key2").Return(nil)
+
+	// When
+	err := DeleteMany(ctx, store, []any{"key1", "key2"})
+
+	// Then
+	assert.Nil(t, err)
+}
@@ -1,5 +1,11 @@
 package store
 
+import "errors"
+
+// ErrClosed is returned by caches and their components when they are used after
+// having been closed
+var ErrClosed = errors.New("cache is closed")
+
 const NOT_FOUND_ERR string = "value not found in store"
 
 type NotFound struct {
@@ -25,3 +31,36 @@ func (e NotFound) Error() string {
 	return NOT_FOUND_ERR
 }
 func (e NotFound) Unwrap() error { return e.cause }
+
+const NOT_SUPPORTED_ERR string = "operation not supported by store"
+
+// NotSupported is returned when an optional operation is called on a store
+// that does not implement the corresponding capability
+type NotSupported struct {
+	storeType string
+	operation string
+}
+
+func NotSupportedOperation(storeType string, operation string) error {
+	err := NotSupported{
+		storeType: storeType,
+		operation: operation,
+	}
+	return &err
+}
+
+func (e NotSupported) StoreType() string {
+	return e.storeType
+}
+
+func (e NotSupported) Operation() string {
+	return e.operation
+}
+
+func (e NotSupported) Is(err error) bool {
+	return err.Error() == NOT_SUPPORTED_ERR
+}
+
+func (e NotSupported) Error() string {
+	return NOT_SUPPORTED_ERR
+}
@@ -15,3 +15,34 @@ type StoreInterface interface {
 	Clear(ctx context.Context) error
 	GetType() string
 }
+
+// BulkStoreInterface is the interface for stores able to get, set or delete
+// several keys in a single round trip
+type BulkStoreInterface interface {
+	GetMany(ctx context.Context, keys []any) (map[any]any, error)
+	SetMany(ctx context.Context, items map[any]any, options ...Option) error
+	DeleteMany(ctx context.Context, keys []any) error
+}
+
+// AtomicStoreInterface is the interface for stores able to update counters and
+// conditionally write values atomically
+type AtomicStoreInterface interface {
+	Increment(ctx context.Context, key any, delta int64) (int64, error)
+	Decrement(ctx context.Context, key any, delta int64) (int64, error)
+	SetIfNotExists(ctx context.Context, key any, value any, options ...Option) (bool, error)
+	CompareAndSwap(ctx context.Context, key any, oldValue any, newValue any, options ...Option) (bool, error)
+	CompareAndDelete(ctx context.Context, key any, oldValue any) (bool, error)
+}
+
+// TagIndexStoreInterface is the interface for stores keeping an index of their tags,
+// able to tell which keys are associated to a tag and which tags to a key
+type TagIndexStoreInterface interface {
+	KeysForTag(ctx context.Context, tag string) ([]string, error)
+	TagsForKey(ctx context.Context, key any) ([]string, error)
+}
+
+// DefaultExpirationStoreInterface is the interface for stores able to tell the
+// expiration they apply to the values written without one
+type DefaultExpirationStoreInterface interface {
+	GetDefaultExpiration() time.Duration
+}
@@ -1,14 +1,46 @@
 package store
 
+import "strings"
+
 // InvalidateOption represents a cache invalidation function.
 type InvalidateOption func(o *InvalidateOptions)
 
 type InvalidateOptions struct {
-	Tags []string
+	Tags    []string
+	Prefix  string
+	Pattern string
 }
 
 func (o *InvalidateOptions) isEmpty() bool {
-	return len(o.Tags) == 0
+	return len(o.Tags) == 0 && !o.TargetsKeys()
+}
+
+// TargetsKeys returns true when keys are invalidated by prefix or pattern
+func (o *InvalidateOptions) TargetsKeys() bool {
+	return o.Prefix != "" || o.Pattern != ""
+}
+
+// MatchKey returns true when the given key is invalidated by the prefix or the pattern
+func (o *InvalidateOptions) MatchKey(key string) bool {
+	if o.Prefix != "" && strings.HasPrefix(key, o.Prefix) {
+		return true
+	}
+
+	return o.Pattern != "" && MatchPattern(o.Pattern, key)
+}
+
+// KeyPatterns returns the glob-style patterns of the keys invalidated by prefix or
+// pattern, the prefix being escaped so it only matches itself
+func (o *InvalidateOptions) KeyPatterns() []string {
+	var patterns []string
+	if o.Prefix != "" {
+		patterns = append(patterns, EscapePattern(o.Prefix)+"*")
+	}
+	if o.Pattern != "" {
+		patterns = append(patterns, o.Pattern)
+	}
+
+	return patterns
 }
 
 func ApplyInvalidateOptionsWithDefault(defaultOptions *InvalidateOptions, opts ...InvalidateOption) *InvalidateOptions {
@@ -37,3 +69,134 @@ func WithInvalidateTags(tags []string) InvalidateOption {
 		o.Tags = tags
 	}
 }
+
+// WithInvalidatePrefix allows invalidating all the keys starting with the given prefix.
+// Stores unable to list their keys return a NotSupported error.
+func WithInvalidatePrefix(prefix string) InvalidateOption {
+	return func(o *InvalidateOptions) {
+		o.Prefix = prefix
+	}
+}
+
+// WithInvalidatePattern allows invalidating all the keys matching the given glob-style
+// pattern, using the syntax of the Redis SCAN command: "*" matches any sequence of
+// characters, "?" a single one, "[abc]", "[^abc]" and "[a-z]" a set of them, and "\"
+// escapes the next character. Stores unable to list their keys return a NotSupported error.
+func WithInvalidatePattern(pattern string) InvalidateOption {
+	return func(o *InvalidateOptions) {
+		o.Pattern = pattern
+	}
+}
+
+// MatchPattern returns true when the given key matches the glob-style pattern, using
+// the syntax of the Redis SCAN command
+func MatchPattern(pattern string, key string) bool {
+	p, k := 0, 0
+	// Position to resume from when the part following the last star does not match
+	starP, starK := -1, 0
+
+	for k < len(key) {
+		if p < len(pattern) {
+			switch pattern[p] {
+			case '*':
+				starP, starK = p, k
+				p++
+				continue
+			case '?':
+				p++
+				k++
+				continue
+			case '[':
+				if end, matched := matchPatternClass(pattern, p, key[k]); matched {
+					p = end
+					k++
+					continue
+				}
+			case '\\':
+				// A trailing backslash matches itself
+				if p+1 < len(pattern) {
+					if pattern[p+1] == key[k] {
+						p += 2
+						k++
+						continue
+					}
+				} else if key[k] == '\\' {
+					p++
+					k++
+					continue
+				}
+			default:
+				if pattern[p] == key[k] {
+					p++
+					k++
+					continue
+				}
+			}
+		}
+
+		if starP < 0 {
+			return false
+		}
+
+		starK++
+		p, k = starP+1, starK
+	}
+
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+
+	return p == len(pattern)
+}
+
+// matchPatternClass returns whether the given character matches the set starting at
+// the given position of the pattern, and the position following the set
+func matchPatternClass(pattern string, p int, c byte) (int, bool) {
+	p++
+
+	not := p < len(pattern) && pattern[p] == '^'
+	if not {
+		p++
+	}
+
+	matched := false
+	for p < len(pattern) && pattern[p] != ']' {
+		switch {
+		case pattern[p] == '\\' && p+1 < len(pattern):
+			p++
+			matched = matched || pattern[p] == c
+		case p+2 < len(pattern) && pattern[p+1] == '-':
+			start, end := pattern[p], pattern[p+2]
+			if start > end {
+				start, end = end, start
+			}
+			matched = matched || (c >= start && c <= end)
+			p += 2
+		default:
+			matched = matched || pattern[p] == c
+		}
+		p++
+	}
+
+	if p < len(pattern) {
+		// Skip the closing bracket
+		p++
+	}
+
+	return p, matched != not
+}
+
+// EscapePattern escapes the special characters of the given string so that it only
+// matches itself when used in a glob-style pattern
+func EscapePattern(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+
+	return b.String()
+}
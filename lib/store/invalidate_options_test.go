@@ -15,3 +15,81 @@ func TestInvalidateOptionsTagsValue(t *testing.T) {
 	// When - Then
 	assert.Equal(t, []string{"tag1", "tag2", "tag3"}, options.Tags)
 }
+
+func TestInvalidateOptionsMatchKey(t *testing.T) {
+	// Given
+	options := ApplyInvalidateOptions(
+		WithInvalidatePrefix("tenant:17:"),
+		WithInvalidatePattern("session:*:token"),
+	)
+
+	// When - Then
+	assert.True(t, options.TargetsKeys())
+	assert.True(t, options.MatchKey("tenant:17:book"))
+	assert.True(t, options.MatchKey("session:42:token"))
+	assert.False(t, options.MatchKey("tenant:170:book"))
+	assert.False(t, options.MatchKey("session:42:user"))
+}
+
+func TestInvalidateOptionsKeyPatterns(t *testing.T) {
+	// Given
+	options := ApplyInvalidateOptions(
+		WithInvalidatePrefix("tenant:[17]*"),
+		WithInvalidatePattern("session:*"),
+	)
+
+	// When
+	patterns := options.KeyPatterns()
+
+	// Then
+	assert.Equal(t, []string{`tenant:\[17\]\**`, "session:*"}, patterns)
+	assert.True(t, MatchPattern(patterns[0], "tenant:[17]*:book"))
+	assert.False(t, MatchPattern(patterns[0], "tenant:1:book"))
+}
+
+func TestInvalidateOptionsWhenTagsOnly(t *testing.T) {
+	// Given
+	options := ApplyInvalidateOptions(WithInvalidateTags([]string{"tag1"}))
+
+	// When - Then
+	assert.False(t, options.TargetsKeys())
+	assert.False(t, options.MatchKey("tag1"))
+	assert.Nil(t, options.KeyPatterns())
+}
+
+func TestMatchPattern(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		key     string
+		matched bool
+	}{
+		{pattern: "*", key: "", matched: true},
+		{pattern: "*", key: "my-key", matched: true},
+		{pattern: "my-*", key: "my-key", matched: true},
+		{pattern: "my-*", key: "your-key", matched: false},
+		{pattern: "*-key", key: "my-key-key", matched: true},
+		{pattern: "*a*b", key: "xaxxbxb", matched: true},
+		{pattern: "*a*b", key: "xaxxbx", matched: false},
+		{pattern: "h?llo", key: "hello", matched: true},
+		{pattern: "h?llo", key: "hllo", matched: false},
+		{pattern: "h[ae]llo", key: "hallo", matched: true},
+		{pattern: "h[ae]llo", key: "hillo", matched: false},
+		{pattern: "h[^e]llo", key: "hallo", matched: true},
+		{pattern: "h[^e]llo", key: "hello", matched: false},
+		{pattern: "h[a-c]llo", key: "hbllo", matched: true},
+		{pattern: "h[c-a]llo", key: "hbllo", matched: true},
+		{pattern: "h[a-c]llo", key: "hdllo", matched: false},
+		{pattern: `h\*llo`, key: "h*llo", matched: true},
+		{pattern: `h\*llo`, key: "hello", matched: false},
+		{pattern: `h[\]]llo`, key: "h]llo", matched: true},
+		{pattern: `my-key\`, key: `my-key\`, matched: true},
+		{pattern: "a/*/c", key: "a/b/c", matched: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.pattern+" "+tc.key, func(t *testing.T) {
+			// When - Then
+			assert.Equal(t, tc.matched, MatchPattern(tc.pattern, tc.key))
+		})
+	}
+}
@@ -12,6 +12,7 @@ type Options struct {
 	Expiration                time.Duration
 	Tags                      []string
 	ClientSideCacheExpiration time.Duration
+	TagIndex                  []TagIndexOption
 }
 
 func (o *Options) IsEmpty() bool {
@@ -39,6 +40,16 @@ func ApplyOptions(opts ...Option) *Options {
 	return o
 }
 
+// GetDefaultExpiration returns the expiration applied by the given store to the values
+// written without one, which is zero when it has none or cannot tell it
+func GetDefaultExpiration(store StoreInterface) time.Duration {
+	if expirationStore, ok := store.(DefaultExpirationStoreInterface); ok {
+		return expirationStore.GetDefaultExpiration()
+	}
+
+	return 0
+}
+
 // WithCost allows setting the memory capacity used by the item when setting a value.
 // Actually it seems to be used by Ristretto library only.
 func WithCost(cost int64) Option {
@@ -68,3 +79,12 @@ func WithClientSideCaching(clientSideCacheExpiration time.Duration) Option {
 		o.ClientSideCacheExpiration = clientSideCacheExpiration
 	}
 }
+
+// WithTagIndex allows to configure the tag index of the stores lacking native sets,
+// it is used when creating the store. Stores keeping tags in native sets only use
+// its TTL, as the expiration of their sets.
+func WithTagIndex(options ...TagIndexOption) Option {
+	return func(o *Options) {
+		o.TagIndex = options
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -50,3 +51,43 @@ func Test_applyOptionsWithDefault(t *testing.T) {
 	assert.Equal(t, int64(7), options.Cost)
 	assert.Equal(t, 25*time.Second, options.Expiration)
 }
+
+func TestWithTagIndex(t *testing.T) {
+	// Given
+	options := &Options{}
+
+	// When
+	WithTagIndex(WithTagIndexShards(4))(options)
+
+	// Then
+	assert.Equal(t, &TagIndexOptions{TTL: DefaultTagIndexTTL, Shards: 4}, ApplyTagIndexOptions(options.TagIndex...))
+}
+
+type defaultExpirationStore struct {
+	*MockStoreInterface
+	expiration time.Duration
+}
+
+func (s *defaultExpirationStore) GetDefaultExpiration() time.Duration {
+	return s.expiration
+}
+
+func TestGetDefaultExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	store := &defaultExpirationStore{MockStoreInterface: NewMockStoreInterface(ctrl), expiration: time.Hour}
+
+	// When - Then
+	assert.Equal(t, time.Hour, GetDefaultExpiration(store))
+}
+
+func TestGetDefaultExpirationWhenNotSupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	store := NewMockStoreInterface(ctrl)
+
+	// When - Then
+	assert.Equal(t, time.Duration(0), GetDefaultExpiration(store))
+}
@@ -145,3 +145,235 @@ func (mr *MockStoreInterfaceMockRecorder) Set(ctx, key, value interface{}, optio
 	varargs := append([]interface{}{ctx, key, value}, options...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockStoreInterface)(nil).Set), varargs...)
 }
+
+// MockBulkStoreInterface is a mock of BulkStoreInterface interface.
+type MockBulkStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockBulkStoreInterfaceMockRecorder
+}
+
+// MockBulkStoreInterfaceMockRecorder is the mock recorder for MockBulkStoreInterface.
+type MockBulkStoreInterfaceMockRecorder struct {
+	mock *MockBulkStoreInterface
+}
+
+// NewMockBulkStoreInterface creates a new mock instance.
+func NewMockBulkStoreInterface(ctrl *gomock.Controller) *MockBulkStoreInterface {
+	mock := &MockBulkStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockBulkStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBulkStoreInterface) EXPECT() *MockBulkStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteMany mocks base method.
+func (m *MockBulkStoreInterface) DeleteMany(ctx context.Context, keys []any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMany", ctx, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMany indicates an expected call of DeleteMany.
+func (mr *MockBulkStoreInterfaceMockRecorder) DeleteMany(ctx, keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockBulkStoreInterface)(nil).DeleteMany), ctx, keys)
+}
+
+// GetMany mocks base method.
+func (m *MockBulkStoreInterface) GetMany(ctx context.Context, keys []any) (map[any]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMany", ctx, keys)
+	ret0, _ := ret[0].(map[any]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMany indicates an expected call of GetMany.
+func (mr *MockBulkStoreInterfaceMockRecorder) GetMany(ctx, keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockBulkStoreInterface)(nil).GetMany), ctx, keys)
+}
+
+// SetMany mocks base method.
+func (m *MockBulkStoreInterface) SetMany(ctx context.Context, items map[any]any, options ...Option) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, items}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetMany", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMany indicates an expected call of SetMany.
+func (mr *MockBulkStoreInterfaceMockRecorder) SetMany(ctx, items interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, items}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMany", reflect.TypeOf((*MockBulkStoreInterface)(nil).SetMany), varargs...)
+}
+
+// MockAtomicStoreInterface is a mock of AtomicStoreInterface interface.
+type MockAtomicStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAtomicStoreInterfaceMockRecorder
+}
+
+// MockAtomicStoreInterfaceMockRecorder is the mock recorder for MockAtomicStoreInterface.
+type MockAtomicStoreInterfaceMockRecorder struct {
+	mock *MockAtomicStoreInterface
+}
+
+// NewMockAtomicStoreInterface creates a new mock instance.
+func NewMockAtomicStoreInterface(ctrl *gomock.Controller) *MockAtomicStoreInterface {
+	mock := &MockAtomicStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockAtomicStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAtomicStoreInterface) EXPECT() *MockAtomicStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CompareAndDelete mocks base method.
+func (m *MockAtomicStoreInterface) CompareAndDelete(ctx context.Context, key, oldValue any) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareAndDelete", ctx, key, oldValue)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareAndDelete indicates an expected call of CompareAndDelete.
+func (mr *MockAtomicStoreInterfaceMockRecorder) CompareAndDelete(ctx, key, oldValue interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareAndDelete", reflect.TypeOf((*MockAtomicStoreInterface)(nil).CompareAndDelete), ctx, key, oldValue)
+}
+
+// CompareAndSwap mocks base method.
+func (m *MockAtomicStoreInterface) CompareAndSwap(ctx context.Context, key, oldValue, newValue any, options ...Option) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, key, oldValue, newValue}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CompareAndSwap", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareAndSwap indicates an expected call of CompareAndSwap.
+func (mr *MockAtomicStoreInterfaceMockRecorder) CompareAndSwap(ctx, key, oldValue, newValue interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, key, oldValue, newValue}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareAndSwap", reflect.TypeOf((*MockAtomicStoreInterface)(nil).CompareAndSwap), varargs...)
+}
+
+// Decrement mocks base method.
+func (m *MockAtomicStoreInterface) Decrement(ctx context.Context, key any, delta int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decrement", ctx, key, delta)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decrement indicates an expected call of Decrement.
+func (mr *MockAtomicStoreInterfaceMockRecorder) Decrement(ctx, key, delta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrement", reflect.TypeOf((*MockAtomicStoreInterface)(nil).Decrement), ctx, key, delta)
+}
+
+// Increment mocks base method.
+func (m *MockAtomicStoreInterface) Increment(ctx context.Context, key any, delta int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Increment", ctx, key, delta)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Increment indicates an expected call of Increment.
+func (mr *MockAtomicStoreInterfaceMockRecorder) Increment(ctx, key, delta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Increment", reflect.TypeOf((*MockAtomicStoreInterface)(nil).Increment), ctx, key, delta)
+}
+
+// SetIfNotExists mocks base method.
+func (m *MockAtomicStoreInterface) SetIfNotExists(ctx context.Context, key, value any, options ...Option) (bool, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, key, value}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetIfNotExists", varargs...)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetIfNotExists indicates an expected call of SetIfNotExists.
+func (mr *MockAtomicStoreInterfaceMockRecorder) SetIfNotExists(ctx, key, value interface{}, options ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, key, value}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIfNotExists", reflect.TypeOf((*MockAtomicStoreInterface)(nil).SetIfNotExists), varargs...)
+}
+
+// MockTagIndexStoreInterface is a mock of TagIndexStoreInterface interface.
+type MockTagIndexStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagIndexStoreInterfaceMockRecorder
+}
+
+// MockTagIndexStoreInterfaceMockRecorder is the mock recorder for MockTagIndexStoreInterface.
+type MockTagIndexStoreInterfaceMockRecorder struct {
+	mock *MockTagIndexStoreInterface
+}
+
+// NewMockTagIndexStoreInterface creates a new mock instance.
+func NewMockTagIndexStoreInterface(ctrl *gomock.Controller) *MockTagIndexStoreInterface {
+	mock := &MockTagIndexStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockTagIndexStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagIndexStoreInterface) EXPECT() *MockTagIndexStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// KeysForTag mocks base method.
+func (m *MockTagIndexStoreInterface) KeysForTag(ctx context.Context, tag string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KeysForTag", ctx, tag)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KeysForTag indicates an expected call of KeysForTag.
+func (mr *MockTagIndexStoreInterfaceMockRecorder) KeysForTag(ctx, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeysForTag", reflect.TypeOf((*MockTagIndexStoreInterface)(nil).KeysForTag), ctx, tag)
+}
+
+// TagsForKey mocks base method.
+func (m *MockTagIndexStoreInterface) TagsForKey(ctx context.Context, key any) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagsForKey", ctx, key)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagsForKey indicates an expected call of TagsForKey.
+func (mr *MockTagIndexStoreInterfaceMockRecorder) TagsForKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagsForKey", reflect.TypeOf((*MockTagIndexStoreInterface)(nil).TagsForKey), ctx, key)
+}
@@ -0,0 +1,551 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTagIndexTTL is the default expiration of the tag index entries
+const DefaultTagIndexTTL = 720 * time.Hour
+
+// KeyTagsPattern represents the key pattern used by stores to keep the tags of each
+// key, so it can be dissociated from them once deleted
+const KeyTagsPattern = "gocache_key_tags_%s"
+
+// IsTagKey returns true when the given key keeps tags, under the given tag pattern or
+// KeyTagsPattern, rather than a value. Stores iterating over their keys to invalidate
+// them by prefix or pattern skip these keys.
+func IsTagKey(pattern string, key string) bool {
+	tagPrefix, _, _ := strings.Cut(pattern, "%s")
+
+	return strings.HasPrefix(key, tagPrefix) || strings.HasPrefix(key, strings.TrimSuffix(KeyTagsPattern, "%s"))
+}
+
+const (
+	// tagIndexMaxRetries is the number of times a tag index entry update is retried
+	// when it has been changed concurrently by another client of the storage
+	tagIndexMaxRetries = 10
+	// tagIndexCompactionSlack is the number of records an entry can hold in addition
+	// to twice its number of keys before being compacted
+	tagIndexCompactionSlack = 16
+	tagIndexLocks           = 64
+
+	tagIndexAddRecord    byte = '+'
+	tagIndexRemoveRecord byte = '-'
+)
+
+// tagIndexHeader starts the encoded tag index entries, it allows to tell them
+// apart from the comma-separated lists of keys written by previous versions
+var tagIndexHeader = []byte{0, 1}
+
+// ErrTagIndexConflict is returned when a tag index entry cannot be updated because
+// it keeps being changed concurrently by other clients of the storage
+var ErrTagIndexConflict = errors.New("tag index entry has been updated concurrently too many times")
+
+// TagIndexStorage is the storage in which a tag index keeps its entries. Storages
+// shared between several processes have to detect concurrent updates, the ones
+// local to the process can ignore the versions as updates are serialized by the index.
+type TagIndexStorage interface {
+	// Load returns the data of the given entry, or nil if it does not exist, along
+	// with its version to give back to Save
+	Load(ctx context.Context, key string) (data []byte, version any, err error)
+	// Save writes the data of the given entry only if it has not been changed since
+	// it has been loaded with the given version (nil if it did not exist), and returns
+	// whether it has been written
+	Save(ctx context.Context, key string, data []byte, version any, ttl time.Duration) (bool, error)
+	// Delete removes the given entry, without returning an error if it does not exist
+	Delete(ctx context.Context, key string) error
+}
+
+// TagIndexToucher is implemented by the tag index storages able to extend the
+// expiration of an entry without rewriting it
+type TagIndexToucher interface {
+	Touch(ctx context.Context, key string, ttl time.Duration) error
+}
+
+// TagIndexOption represents a tag index option function.
+type TagIndexOption func(o *TagIndexOptions)
+
+type TagIndexOptions struct {
+	TTL    time.Duration
+	Shards int
+}
+
+func ApplyTagIndexOptions(opts ...TagIndexOption) *TagIndexOptions {
+	o := &TagIndexOptions{
+		TTL:    DefaultTagIndexTTL,
+		Shards: 1,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithTagIndexTTL allows to specify the expiration of the tag index entries, which
+// is extended each time a key is added to them, or set again when the storage can
+// touch entries (720 hours by default). A zero TTL lets the storage keep the entries
+// without expiration.
+func WithTagIndexTTL(ttl time.Duration) TagIndexOption {
+	return func(o *TagIndexOptions) {
+		o.TTL = ttl
+	}
+}
+
+// WithTagIndexShards allows to spread the keys of each tag over the given number of
+// entries (1 by default), so adding a key to a big tag only rewrites a part of them.
+// The keys of a tag are read from all its entries, so changing the number of shards
+// of an existing index loses the keys added to the ones beyond the new number.
+func WithTagIndexShards(shards int) TagIndexOption {
+	return func(o *TagIndexOptions) {
+		o.Shards = shards
+	}
+}
+
+// TagIndex keeps the keys associated to each tag for stores lacking native sets.
+//
+// The keys of a tag are stored in an entry named after the tag pattern, encoded as
+// a log of length-prefixed records so keys can contain any character. Adding or
+// removing keys appends records to the entry, which is still loaded and saved as a
+// whole, so each update costs as much as the size of the entry: big tags can be
+// spread over several entries using WithTagIndexShards. Entries are compacted once
+// they hold more than twice as many records as keys, and when pruned. Updates are
+// serialized within the process and retried when the storage reports a concurrent
+// update.
+//
+// The tags of each key are also kept in an entry named after KeyTagsPattern, so a
+// deleted key can be dissociated from all its tags.
+type TagIndex struct {
+	storage TagIndexStorage
+	pattern string
+	options *TagIndexOptions
+	locks   [tagIndexLocks]sync.Mutex
+	// tags contains the tags keys have been added to by this index, which are pruned
+	tags   map[string]struct{}
+	tagsMu sync.Mutex
+}
+
+// NewTagIndex creates a tag index keeping its entries in the given storage, under
+// keys built from the given pattern and the tags, such as "gocache_tag_%s"
+func NewTagIndex(storage TagIndexStorage, pattern string, options ...TagIndexOption) *TagIndex {
+	opts := ApplyTagIndexOptions(options...)
+	if opts.Shards < 1 {
+		opts.Shards = 1
+	}
+
+	return &TagIndex{
+		storage: storage,
+		pattern: pattern,
+		options: opts,
+		tags:    make(map[string]struct{}),
+	}
+}
+
+// Add associates the given key to each of the given tags
+func (i *TagIndex) Add(ctx context.Context, key string, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	for _, tag := range tags {
+		if err := i.add(ctx, i.entryKey(tag, i.shard(key)), []string{key}); err != nil {
+			return err
+		}
+
+		i.tagsMu.Lock()
+		i.tags[tag] = struct{}{}
+		i.tagsMu.Unlock()
+	}
+
+	return i.add(ctx, i.keyTagsKey(key), tags)
+}
+
+// add adds the given members to an entry, or only extends its expiration when the
+// storage can touch entries and it already contains them
+func (i *TagIndex) add(ctx context.Context, entryKey string, members []string) error {
+	saved, err := i.update(ctx, entryKey, func(entry *tagIndexEntry) {
+		for _, member := range members {
+			entry.add(member)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if toucher, ok := i.storage.(TagIndexToucher); ok && !saved {
+		return toucher.Touch(ctx, entryKey, i.options.TTL)
+	}
+
+	return nil
+}
+
+// Remove dissociates the given keys from the tag, keys added meanwhile are kept
+func (i *TagIndex) Remove(ctx context.Context, tag string, keys []string) error {
+	shards := make(map[int][]string)
+	for _, key := range keys {
+		shard := i.shard(key)
+		shards[shard] = append(shards[shard], key)
+	}
+
+	for shard, shardKeys := range shards {
+		_, err := i.update(ctx, i.entryKey(tag, shard), func(entry *tagIndexEntry) {
+			for _, key := range shardKeys {
+				entry.remove(key)
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveKeys dissociates the given keys, which have been deleted, from all their tags
+func (i *TagIndex) RemoveKeys(ctx context.Context, keys []string) error {
+	var tags []string
+	keysByTag := make(map[string][]string)
+
+	for _, key := range keys {
+		keyTags, err := i.KeyTags(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		for _, tag := range keyTags {
+			if _, ok := keysByTag[tag]; !ok {
+				tags = append(tags, tag)
+			}
+			keysByTag[tag] = append(keysByTag[tag], key)
+		}
+	}
+
+	for _, tag := range tags {
+		if err := i.Remove(ctx, tag, keysByTag[tag]); err != nil {
+			return err
+		}
+	}
+
+	for _, key := range keys {
+		if err := i.storage.Delete(ctx, i.keyTagsKey(key)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// KeyTags returns the tags associated to the given key
+func (i *TagIndex) KeyTags(ctx context.Context, key string) ([]string, error) {
+	data, _, err := i.storage.Load(ctx, i.keyTagsKey(key))
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeTagIndexEntry(data).keys(), nil
+}
+
+// Keys returns the keys associated to the given tag
+func (i *TagIndex) Keys(ctx context.Context, tag string) ([]string, error) {
+	var keys []string
+	seen := make(map[string]struct{})
+
+	for shard := 0; shard < i.options.Shards; shard++ {
+		data, _, err := i.storage.Load(ctx, i.entryKey(tag, shard))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range decodeTagIndexEntry(data).keys() {
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	return keys, nil
+}
+
+// Compact rewrites the entries of the given tag, dropping the keys for which the
+// given function returns false, such as the ones which have expired
+func (i *TagIndex) Compact(ctx context.Context, tag string, exists func(key string) bool) error {
+	for shard := 0; shard < i.options.Shards; shard++ {
+		_, err := i.update(ctx, i.entryKey(tag, shard), func(entry *tagIndexEntry) {
+			for _, key := range entry.keys() {
+				if !exists(key) {
+					entry.remove(key)
+				}
+			}
+			entry.compact = entry.records > 0
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Prune dissociates the keys which do not exist anymore, such as the expired ones,
+// from the tags keys have been added to by this index. The given function returns
+// the missing keys among the keys of a tag. The entries of the tags having missing
+// keys are compacted.
+// Tags having no key anymore are not pruned again until keys are added to them.
+//
+// The tags are not listed in the storage, so the ones keys have been added to by
+// other processes sharing the storage, or before the index has been created, are
+// not pruned: each process has to prune the tags it uses, and the entries left
+// behind by a restart are only removed once they expire (see WithTagIndexTTL).
+func (i *TagIndex) Prune(ctx context.Context, missing func(keys []string) ([]string, error)) error {
+	i.tagsMu.Lock()
+	tags := make([]string, 0, len(i.tags))
+	for tag := range i.tags {
+		tags = append(tags, tag)
+	}
+	i.tagsMu.Unlock()
+
+	for _, tag := range tags {
+		keys, err := i.Keys(ctx, tag)
+		if err != nil {
+			return err
+		}
+
+		var removed []string
+		if len(keys) > 0 {
+			if removed, err = missing(keys); err != nil {
+				return err
+			}
+		}
+
+		if len(removed) > 0 {
+			missingKeys := make(map[string]struct{}, len(removed))
+			for _, key := range removed {
+				missingKeys[key] = struct{}{}
+			}
+
+			// The entries are rewritten with the remaining keys only instead of
+			// appending removal records to them
+			err := i.Compact(ctx, tag, func(key string) bool {
+				_, ok := missingKeys[key]
+				return !ok
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, key := range removed {
+				if err := i.storage.Delete(ctx, i.keyTagsKey(key)); err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(removed) == len(keys) {
+			i.tagsMu.Lock()
+			delete(i.tags, tag)
+			i.tagsMu.Unlock()
+		}
+	}
+
+	return nil
+}
+
+// update applies the given change to an entry and saves it if it has been changed,
+// retrying with the new data of the entry when it has been updated concurrently.
+// It returns whether the entry has been saved.
+func (i *TagIndex) update(ctx context.Context, entryKey string, change func(entry *tagIndexEntry)) (bool, error) {
+	lock := &i.locks[hashTagIndexKey(entryKey)%tagIndexLocks]
+	lock.Lock()
+	defer lock.Unlock()
+
+	for attempt := 0; attempt < tagIndexMaxRetries; attempt++ {
+		data, version, err := i.storage.Load(ctx, entryKey)
+		if err != nil {
+			return false, err
+		}
+
+		entry := decodeTagIndexEntry(data)
+		change(entry)
+		if len(entry.pending) == 0 && !entry.compact {
+			return false, nil
+		}
+
+		saved, err := i.storage.Save(ctx, entryKey, entry.encode(data), version, i.options.TTL)
+		if err != nil {
+			return false, err
+		}
+		if saved {
+			return true, nil
+		}
+	}
+
+	return false, ErrTagIndexConflict
+}
+
+func (i *TagIndex) entryKey(tag string, shard int) string {
+	key := fmt.Sprintf(i.pattern, tag)
+	if shard > 0 {
+		key += "#" + strconv.Itoa(shard)
+	}
+
+	return key
+}
+
+func (i *TagIndex) keyTagsKey(key string) string {
+	return fmt.Sprintf(KeyTagsPattern, key)
+}
+
+func (i *TagIndex) shard(key string) int {
+	if i.options.Shards == 1 {
+		return 0
+	}
+
+	return int(hashTagIndexKey(key) % uint32(i.options.Shards))
+}
+
+func hashTagIndexKey(key string) uint32 {
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+
+	return hash.Sum32()
+}
+
+// tagIndexEntry represents the decoded keys of a tag index entry along with the
+// records to append to it
+type tagIndexEntry struct {
+	members map[string]struct{}
+	// order keeps the keys in the order they have been added, it can contain keys
+	// removed since then or added several times
+	order   []string
+	records int
+	pending []byte
+	// compact is true when the entry has to be rewritten with its keys only
+	compact bool
+}
+
+func decodeTagIndexEntry(data []byte) *tagIndexEntry {
+	entry := &tagIndexEntry{members: make(map[string]struct{})}
+
+	if len(data) == 0 {
+		return entry
+	}
+
+	if !bytes.HasPrefix(data, tagIndexHeader) {
+		// Comma-separated list of keys written by a previous version
+		for _, key := range strings.Split(string(data), ",") {
+			if key != "" {
+				entry.apply(tagIndexAddRecord, key)
+			}
+		}
+		entry.compact = true
+
+		return entry
+	}
+
+	for data = data[len(tagIndexHeader):]; len(data) > 0; {
+		op := data[0]
+		length, n := binary.Uvarint(data[1:])
+		if n <= 0 || uint64(len(data)-1-n) < length {
+			// Truncated entry, keep the records read so far
+			entry.compact = true
+			break
+		}
+
+		start := 1 + n
+		entry.apply(op, string(data[start:start+int(length)]))
+		data = data[start+int(length):]
+	}
+
+	return entry
+}
+
+func (e *tagIndexEntry) apply(op byte, key string) {
+	e.records++
+
+	switch op {
+	case tagIndexAddRecord:
+		if _, ok := e.members[key]; !ok {
+			e.members[key] = struct{}{}
+			e.order = append(e.order, key)
+		}
+	case tagIndexRemoveRecord:
+		delete(e.members, key)
+	}
+}
+
+func (e *tagIndexEntry) add(key string) {
+	if _, ok := e.members[key]; ok {
+		return
+	}
+
+	e.record(tagIndexAddRecord, key)
+}
+
+func (e *tagIndexEntry) remove(key string) {
+	if _, ok := e.members[key]; !ok {
+		return
+	}
+
+	e.record(tagIndexRemoveRecord, key)
+}
+
+func (e *tagIndexEntry) record(op byte, key string) {
+	e.apply(op, key)
+	e.pending = appendTagIndexRecord(e.pending, op, key)
+}
+
+// keys returns the keys of the entry in the order they have been added
+func (e *tagIndexEntry) keys() []string {
+	keys := make([]string, 0, len(e.members))
+	seen := make(map[string]struct{}, len(e.members))
+	for _, key := range e.order {
+		if _, ok := e.members[key]; !ok {
+			continue
+		}
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// encode returns the data of the entry, appending the pending records to the
+// given previous data unless the entry has to be compacted
+func (e *tagIndexEntry) encode(data []byte) []byte {
+	if !e.compact && e.records <= 2*len(e.members)+tagIndexCompactionSlack && len(data) > 0 {
+		encoded := make([]byte, 0, len(data)+len(e.pending))
+		encoded = append(encoded, data...)
+
+		return append(encoded, e.pending...)
+	}
+
+	encoded := append([]byte{}, tagIndexHeader...)
+	for _, key := range e.keys() {
+		encoded = appendTagIndexRecord(encoded, tagIndexAddRecord, key)
+	}
+
+	return encoded
+}
+
+func appendTagIndexRecord(data []byte, op byte, key string) []byte {
+	var length [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(length[:], uint64(len(key)))
+
+	data = append(data, op)
+	data = append(data, length[:n]...)
+
+	return append(data, key...)
+}
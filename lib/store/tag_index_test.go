@@ -0,0 +1,539 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tagIndexStorageEntry struct {
+	data    []byte
+	version int
+	ttl     time.Duration
+}
+
+// memoryTagIndexStorage is a versioned storage which can simulate updates made
+// concurrently by other clients
+type memoryTagIndexStorage struct {
+	mu      sync.Mutex
+	entries map[string]*tagIndexStorageEntry
+	saves   int
+	// conflicts is the number of saves to reject before accepting them
+	conflicts int
+	err       error
+}
+
+func newMemoryTagIndexStorage() *memoryTagIndexStorage {
+	return &memoryTagIndexStorage{entries: make(map[string]*tagIndexStorageEntry)}
+}
+
+func (s *memoryTagIndexStorage) Load(_ context.Context, key string) ([]byte, any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return nil, nil, s.err
+	}
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, nil, nil
+	}
+
+	return entry.data, entry.version, nil
+}
+
+func (s *memoryTagIndexStorage) Save(_ context.Context, key string, data []byte, version any, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conflicts > 0 {
+		s.conflicts--
+		return false, nil
+	}
+
+	entry, ok := s.entries[key]
+	if (version == nil && ok) || (version != nil && (!ok || entry.version != version.(int))) {
+		return false, nil
+	}
+
+	s.saves++
+	s.entries[key] = &tagIndexStorageEntry{data: data, version: s.saves, ttl: ttl}
+
+	return true, nil
+}
+
+func (s *memoryTagIndexStorage) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+
+	delete(s.entries, key)
+
+	return nil
+}
+
+func (s *memoryTagIndexStorage) get(key string) *tagIndexStorageEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.entries[key]
+}
+
+func TestTagIndexAdd(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	storage := newMemoryTagIndexStorage()
+	index := NewTagIndex(storage, "gocache_tag_%s")
+
+	// When
+	err1 := index.Add(ctx, "my-key", []string{"tag1", "tag2"})
+	err2 := index.Add(ctx, "my,other|key", []string{"tag1"})
+	err3 := index.Add(ctx, "my-key", []string{"tag1"})
+
+	// Then
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Nil(t, err3)
+
+	keys, err := index.Keys(ctx, "tag1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"my-key", "my,other|key"}, keys)
+
+	keys, err = index.Keys(ctx, "tag2")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"my-key"}, keys)
+
+	tags, err := index.KeyTags(ctx, "my-key")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"tag1", "tag2"}, tags)
+
+	// The key already associated to the tag is not written again
+	assert.Equal(t, 5, storage.saves)
+	assert.Equal(t, DefaultTagIndexTTL, storage.get("gocache_tag_tag1").ttl)
+	assert.Equal(t, DefaultTagIndexTTL, storage.get("gocache_key_tags_my-key").ttl)
+}
+
+func TestTagIndexKeysWhenTagDoesNotExist(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	index := NewTagIndex(newMemoryTagIndexStorage(), "gocache_tag_%s")
+
+	// When
+	keys, err := index.Keys(ctx, "tag1")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestTagIndexKeysWhenError(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	storage := newMemoryTagIndexStorage()
+	storage.err = errors.New("unexpected error")
+
+	index := NewTagIndex(storage, "gocache_tag_%s")
+
+	// When
+	keys, err := index.Keys(ctx, "tag1")
+
+	// Then
+	assert.Equal(t, storage.err, err)
+	assert.Nil(t, keys)
+}
+
+func TestTagIndexKeysWhenCommaSeparatedEntry(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	storage := newMemoryTagIndexStorage()
+	storage.entries["gocache_tag_tag1"] = &tagIndexStorageEntry{data: []byte("a23fdf987h2svc23,jHG2372x38hf74")}
+
+	index := NewTagIndex(storage, "gocache_tag_%s")
+
+	// When
+	err := index.Add(ctx, "my-key", []string{"tag1"})
+
+	// Then
+	assert.Nil(t, err)
+
+	keys, err := index.Keys(ctx, "tag1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a23fdf987h2svc23", "jHG2372x38hf74", "my-key"}, keys)
+
+	// The entry has been rewritten with the new encoding
+	assert.Equal(t, tagIndexHeader, storage.get("gocache_tag_tag1").data[:len(tagIndexHeader)])
+}
+
+func TestTagIndexRemove(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	storage := newMemoryTagIndexStorage()
+	index := NewTagIndex(storage, "gocache_tag_%s")
+
+	for _, key := range []string{"key1", "key2", "key3"} {
+		assert.Nil(t, index.Add(ctx, key, []string{"tag1"}))
+	}
+
+	// When
+	err := index.Remove(ctx, "tag1", []string{"key1", "key3", "unknown"})
+
+	// Then
+	assert.Nil(t, err)
+
+	keys, err := index.Keys(ctx, "tag1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"key2"}, keys)
+}
+
+func TestTagIndexCompactsEntries(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	storage := newMemoryTagIndexStorage()
+	index := NewTagIndex(storage, "gocache_tag_%s")
+
+	// When the same key is added and removed many times
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, index.Add(ctx, "my-key", []string{"tag1"}))
+		assert.Nil(t, index.Remove(ctx, "tag1", []string{"my-key"}))
+	}
+	assert.Nil(t, index.Add(ctx, "my-key", []string{"tag1"}))
+
+	// Then the entry does not grow with the records
+	entry := decodeTagIndexEntry(storage.get("gocache_tag_tag1").data)
+	assert.Equal(t, []string{"my-key"}, entry.keys())
+	assert.LessOrEqual(t, entry.records, 2+tagIndexCompactionSlack)
+}
+
+func TestTagIndexCompact(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	storage := newMemoryTagIndexStorage()
+	index := NewTagIndex(storage, "gocache_tag_%s")
+
+	for _, key := range []string{"key1", "key2", "key3"} {
+		assert.Nil(t, index.Add(ctx, key, []string{"tag1"}))
+	}
+
+	// When
+	err := index.Compact(ctx, "tag1", func(key string) bool {
+		return key != "key2"
+	})
+
+	// Then
+	assert.Nil(t, err)
+
+	entry := decodeTagIndexEntry(storage.get("gocache_tag_tag1").data)
+	assert.Equal(t, []string{"key1", "key3"}, entry.keys())
+	assert.Equal(t, 2, entry.records)
+}
+
+func TestTagIndexRetriesWhenConflict(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	storage := newMemoryTagIndexStorage()
+	storage.conflicts = 3
+
+	index := NewTagIndex(storage, "gocache_tag_%s")
+
+	// When
+	err := index.Add(ctx, "my-key", []string{"tag1"})
+
+	// Then
+	assert.Nil(t, err)
+
+	keys, _ := index.Keys(ctx, "tag1")
+	assert.Equal(t, []string{"my-key"}, keys)
+}
+
+func TestTagIndexWhenTooManyConflicts(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	storage := newMemoryTagIndexStorage()
+	storage.conflicts = tagIndexMaxRetries
+
+	index := NewTagIndex(storage, "gocache_tag_%s")
+
+	// When
+	err := index.Add(ctx, "my-key", []string{"tag1"})
+
+	// Then
+	assert.Equal(t, ErrTagIndexConflict, err)
+}
+
+func TestTagIndexConcurrentAdds(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	storage := newMemoryTagIndexStorage()
+	index := NewTagIndex(storage, "gocache_tag_%s")
+
+	// When
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.Nil(t, index.Add(ctx, fmt.Sprintf("key%d", i), []string{"tag1"}))
+		}(i)
+	}
+	wg.Wait()
+
+	// Then no key has been lost
+	keys, err := index.Keys(ctx, "tag1")
+	assert.Nil(t, err)
+	assert.Len(t, keys, 50)
+}
+
+func TestTagIndexWithShards(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	storage := newMemoryTagIndexStorage()
+	index := NewTagIndex(storage, "gocache_tag_%s", WithTagIndexShards(4), WithTagIndexTTL(time.Hour))
+
+	// When
+	for i := 0; i < 20; i++ {
+		assert.Nil(t, index.Add(ctx, fmt.Sprintf("key%d", i), []string{"tag1"}))
+	}
+
+	// Then
+	keys, err := index.Keys(ctx, "tag1")
+	assert.Nil(t, err)
+	assert.Len(t, keys, 20)
+
+	assert.Nil(t, index.Remove(ctx, "tag1", keys[:10]))
+
+	keys, err = index.Keys(ctx, "tag1")
+	assert.Nil(t, err)
+	assert.Len(t, keys, 10)
+
+	assert.NotNil(t, storage.get("gocache_tag_tag1#3"))
+	assert.Nil(t, storage.get("gocache_tag_tag1#4"))
+	assert.Equal(t, time.Hour, storage.get("gocache_tag_tag1#3").ttl)
+}
+
+func TestTagIndexAddWithShards(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	storage := newMemoryTagIndexStorage()
+	index := NewTagIndex(storage, "gocache_tag_%s", WithTagIndexShards(4))
+
+	for i := 0; i < 100; i++ {
+		assert.Nil(t, index.Add(ctx, fmt.Sprintf("key%d", i), []string{"tag1"}))
+	}
+
+	sizes := make(map[string]int)
+	total := 0
+	for _, entryKey := range []string{"gocache_tag_tag1", "gocache_tag_tag1#1", "gocache_tag_tag1#2", "gocache_tag_tag1#3"} {
+		sizes[entryKey] = len(storage.get(entryKey).data)
+		total += sizes[entryKey]
+	}
+
+	// When
+	assert.Nil(t, index.Add(ctx, "key100", []string{"tag1"}))
+
+	// Then only the entry of its shard has been rewritten
+	var rewritten []string
+	for entryKey, size := range sizes {
+		if len(storage.get(entryKey).data) != size {
+			rewritten = append(rewritten, entryKey)
+			assert.Less(t, len(storage.get(entryKey).data), total/2)
+		}
+	}
+	assert.Len(t, rewritten, 1)
+}
+
+// touchingTagIndexStorage records the entries whose expiration has been extended
+type touchingTagIndexStorage struct {
+	*memoryTagIndexStorage
+	touched []string
+}
+
+func (s *touchingTagIndexStorage) Touch(_ context.Context, key string, ttl time.Duration) error {
+	s.touched = append(s.touched, key)
+	return nil
+}
+
+func TestTagIndexAddWhenStorageCanTouchEntries(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	storage := &touchingTagIndexStorage{memoryTagIndexStorage: newMemoryTagIndexStorage()}
+	index := NewTagIndex(storage, "gocache_tag_%s")
+
+	// When
+	err1 := index.Add(ctx, "my-key", []string{"tag1"})
+	err2 := index.Add(ctx, "my-key", []string{"tag1"})
+
+	// Then the entry is only touched once the key is already associated to the tag
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Equal(t, 2, storage.saves)
+	assert.Equal(t, []string{"gocache_tag_tag1", "gocache_key_tags_my-key"}, storage.touched)
+}
+
+func TestTagIndexRemoveKeys(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	storage := newMemoryTagIndexStorage()
+	index := NewTagIndex(storage, "gocache_tag_%s")
+
+	assert.Nil(t, index.Add(ctx, "key1", []string{"tag1", "tag2"}))
+	assert.Nil(t, index.Add(ctx, "key2", []string{"tag1"}))
+	assert.Nil(t, index.Add(ctx, "key3", []string{"tag2"}))
+
+	// When
+	err := index.RemoveKeys(ctx, []string{"key1", "key3", "unknown"})
+
+	// Then
+	assert.Nil(t, err)
+
+	keys, _ := index.Keys(ctx, "tag1")
+	assert.Equal(t, []string{"key2"}, keys)
+
+	keys, _ = index.Keys(ctx, "tag2")
+	assert.Empty(t, keys)
+
+	assert.Nil(t, storage.get("gocache_key_tags_key1"))
+	assert.Nil(t, storage.get("gocache_key_tags_key3"))
+	assert.NotNil(t, storage.get("gocache_key_tags_key2"))
+}
+
+func TestTagIndexRemoveKeysWhenError(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	storage := newMemoryTagIndexStorage()
+	index := NewTagIndex(storage, "gocache_tag_%s")
+
+	assert.Nil(t, index.Add(ctx, "key1", []string{"tag1"}))
+
+	storage.err = errors.New("unexpected error")
+
+	// When
+	err := index.RemoveKeys(ctx, []string{"key1"})
+
+	// Then
+	assert.Equal(t, storage.err, err)
+}
+
+func TestTagIndexPrune(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	storage := newMemoryTagIndexStorage()
+	index := NewTagIndex(storage, "gocache_tag_%s")
+
+	assert.Nil(t, index.Add(ctx, "key1", []string{"tag1", "tag2"}))
+	assert.Nil(t, index.Add(ctx, "key2", []string{"tag1"}))
+
+	// When key1 has expired
+	var checked []string
+	err := index.Prune(ctx, func(keys []string) ([]string, error) {
+		checked = append(checked, keys...)
+		return []string{"key1"}, nil
+	})
+
+	// Then
+	assert.Nil(t, err)
+
+	keys, _ := index.Keys(ctx, "tag1")
+	assert.Equal(t, []string{"key2"}, keys)
+
+	// The pruned entry has been compacted
+	assert.Equal(t, 1, decodeTagIndexEntry(storage.get("gocache_tag_tag1").data).records)
+
+	keys, _ = index.Keys(ctx, "tag2")
+	assert.Empty(t, keys)
+
+	assert.Nil(t, storage.get("gocache_key_tags_key1"))
+	assert.ElementsMatch(t, []string{"key1", "key2", "key1"}, checked)
+
+	// The tag having no key anymore is not pruned again
+	checked = nil
+	assert.Nil(t, index.Prune(ctx, func(keys []string) ([]string, error) {
+		checked = append(checked, keys...)
+		return nil, nil
+	}))
+	assert.Equal(t, []string{"key2"}, checked)
+}
+
+func TestTagIndexPruneWhenTagsHaveBeenAddedByAnotherIndex(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	storage := newMemoryTagIndexStorage()
+	index := NewTagIndex(storage, "gocache_tag_%s")
+
+	assert.Nil(t, index.Add(ctx, "key1", []string{"tag1"}))
+
+	// When another process, or the same one after a restart, prunes the tags
+	otherIndex := NewTagIndex(storage, "gocache_tag_%s")
+
+	var checked []string
+	err := otherIndex.Prune(ctx, func(keys []string) ([]string, error) {
+		checked = append(checked, keys...)
+		return keys, nil
+	})
+
+	// Then the tags it has not used are left to expire
+	assert.Nil(t, err)
+	assert.Empty(t, checked)
+
+	keys, _ := index.Keys(ctx, "tag1")
+	assert.Equal(t, []string{"key1"}, keys)
+	assert.Equal(t, DefaultTagIndexTTL, storage.get("gocache_tag_tag1").ttl)
+}
+
+func TestTagIndexPruneWhenExistsFails(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	storage := newMemoryTagIndexStorage()
+	index := NewTagIndex(storage, "gocache_tag_%s")
+
+	assert.Nil(t, index.Add(ctx, "key1", []string{"tag1"}))
+
+	existsErr := errors.New("unexpected error")
+
+	// When
+	err := index.Prune(ctx, func(keys []string) ([]string, error) {
+		return nil, existsErr
+	})
+
+	// Then the keys are kept
+	assert.Equal(t, existsErr, err)
+
+	keys, _ := index.Keys(ctx, "tag1")
+	assert.Equal(t, []string{"key1"}, keys)
+}
+
+func TestIsTagKey(t *testing.T) {
+	// When - Then
+	assert.True(t, IsTagKey("gocache_tag_%s", "gocache_tag_tag1"))
+	assert.True(t, IsTagKey("gocache_tag_%s", "gocache_tag_tag1#2"))
+	assert.True(t, IsTagKey("gocache_tag_%s", "gocache_key_tags_my-key"))
+	assert.True(t, IsTagKey("freecache_tag_%s", "freecache_tag_tag1"))
+	assert.False(t, IsTagKey("freecache_tag_%s", "gocache_tag_tag1"))
+	assert.False(t, IsTagKey("gocache_tag_%s", "my-key"))
+}
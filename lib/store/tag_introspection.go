@@ -0,0 +1,27 @@
+package store
+
+import (
+	"context"
+)
+
+// KeysForTag returns the keys associated to the given tag.
+// A *NotSupported error is returned if the store does not keep an index of its tags.
+func KeysForTag(ctx context.Context, store StoreInterface, tag string) ([]string, error) {
+	tagIndexStore, ok := store.(TagIndexStoreInterface)
+	if !ok {
+		return nil, NotSupportedOperation(store.GetType(), "KeysForTag")
+	}
+
+	return tagIndexStore.KeysForTag(ctx, tag)
+}
+
+// TagsForKey returns the tags associated to the given key.
+// A *NotSupported error is returned if the store does not keep an index of its tags.
+func TagsForKey(ctx context.Context, store StoreInterface, key any) ([]string, error) {
+	tagIndexStore, ok := store.(TagIndexStoreInterface)
+	if !ok {
+		return nil, NotSupportedOperation(store.GetType(), "TagsForKey")
+	}
+
+	return tagIndexStore.TagsForKey(ctx, key)
+}
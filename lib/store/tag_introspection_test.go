@@ -0,0 +1,94 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type tagIndexStore struct {
+	*MockStoreInterface
+	*MockTagIndexStoreInterface
+}
+
+func TestKeysForTagWhenTagIndexStore(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	tagIndex := NewMockTagIndexStoreInterface(ctrl)
+	tagIndex.EXPECT().KeysForTag(ctx, "tag1").Return([]string{"my-key", "my-other-key"}, nil)
+
+	store := &tagIndexStore{NewMockStoreInterface(ctrl), tagIndex}
+
+	// When
+	keys, err := KeysForTag(ctx, store, "tag1")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"my-key", "my-other-key"}, keys)
+}
+
+func TestKeysForTagWhenNotTagIndexStore(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := NewMockStoreInterface(ctrl)
+	store.EXPECT().GetType().Return("mock")
+
+	// When
+	keys, err := KeysForTag(ctx, store, "tag1")
+
+	// Then
+	assert.Nil(t, keys)
+
+	var notSupported *NotSupported
+	assert.True(t, errors.As(err, &notSupported))
+	assert.Equal(t, "mock", notSupported.StoreType())
+	assert.Equal(t, "KeysForTag", notSupported.Operation())
+}
+
+func TestTagsForKeyWhenTagIndexStore(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	tagIndex := NewMockTagIndexStoreInterface(ctrl)
+	tagIndex.EXPECT().TagsForKey(ctx, "my-key").Return([]string{"tag1", "tag2"}, nil)
+
+	store := &tagIndexStore{NewMockStoreInterface(ctrl), tagIndex}
+
+	// When
+	tags, err := TagsForKey(ctx, store, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"tag1", "tag2"}, tags)
+}
+
+func TestTagsForKeyWhenNotTagIndexStore(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := NewMockStoreInterface(ctrl)
+	store.EXPECT().GetType().Return("mock")
+
+	// When
+	tags, err := TagsForKey(ctx, store, "my-key")
+
+	// Then
+	assert.Nil(t, tags)
+
+	var notSupported *NotSupported
+	assert.True(t, errors.As(err, &notSupported))
+	assert.Equal(t, "TagsForKey", notSupported.Operation())
+}
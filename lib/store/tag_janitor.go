@@ -0,0 +1,63 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// TagPrunerInterface is the interface for stores able to dissociate the keys which
+// do not exist anymore, such as the expired ones, from their tags
+type TagPrunerInterface interface {
+	PruneTags(ctx context.Context) error
+}
+
+// TagJanitor prunes the tags of a store in background, at a regular interval.
+// Errors are ignored, pruning being done again on the next run.
+type TagJanitor struct {
+	pruner   TagPrunerInterface
+	interval time.Duration
+	ctx      context.Context
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// NewTagJanitor starts pruning the tags of the given store at the given interval,
+// until the janitor is stopped
+func NewTagJanitor(pruner TagPrunerInterface, interval time.Duration) *TagJanitor {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	janitor := &TagJanitor{
+		pruner:   pruner,
+		interval: interval,
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+
+	go janitor.run()
+
+	return janitor
+}
+
+func (j *TagJanitor) run() {
+	defer close(j.done)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			j.pruner.PruneTags(j.ctx)
+		case <-j.ctx.Done():
+			return
+		}
+	}
+}
+
+// Stop stops pruning tags, cancelling the current run, and waits for the background
+// goroutine to be done
+func (j *TagJanitor) Stop() {
+	j.cancel()
+	<-j.done
+}
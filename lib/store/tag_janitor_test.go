@@ -0,0 +1,46 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingTagPruner records the number of times tags have been pruned
+type countingTagPruner struct {
+	pruned chan struct{}
+}
+
+func (p *countingTagPruner) PruneTags(ctx context.Context) error {
+	p.pruned <- struct{}{}
+	return nil
+}
+
+func TestTagJanitor(t *testing.T) {
+	// Given
+	pruner := &countingTagPruner{pruned: make(chan struct{}, 10)}
+
+	// When
+	janitor := NewTagJanitor(pruner, 5*time.Millisecond)
+
+	// Then tags are pruned regularly
+	for i := 0; i < 2; i++ {
+		select {
+		case <-pruner.pruned:
+		case <-time.After(time.Second):
+			t.Fatal("tags should have been pruned")
+		}
+	}
+
+	janitor.Stop()
+
+	// Tags are not pruned anymore once stopped
+	for len(pruner.pruned) > 0 {
+		<-pruner.pruned
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	assert.Len(t, pruner.pruned, 0)
+}
@@ -3,10 +3,9 @@ package bigcache
 import (
 	"context"
 	"errors"
-	"fmt"
-	"strings"
 	"time"
 
+	"github.com/allegro/bigcache/v3"
 	"github.com/eko/gocache/lib/v4/store"
 )
 
@@ -18,6 +17,12 @@ type BigcacheClientInterface interface {
 	Reset() error
 }
 
+// BigcacheIteratorInterface represents a client able to iterate over its entries, such
+// as an allegro/bigcache client, which is needed to invalidate keys by prefix or pattern
+type BigcacheIteratorInterface interface {
+	Iterator() *bigcache.EntryInfoIterator
+}
+
 const (
 	// BigcacheType represents the storage type as a string value
 	BigcacheType = "bigcache"
@@ -29,14 +34,45 @@ const (
 type BigcacheStore struct {
 	client  BigcacheClientInterface
 	options *store.Options
+	tags    *store.TagIndex
 }
 
 // NewBigcache creates a new store to Bigcache instance(s)
 func NewBigcache(client BigcacheClientInterface, options ...store.Option) *BigcacheStore {
+	opts := store.ApplyOptions(options...)
+
 	return &BigcacheStore{
 		client:  client,
-		options: store.ApplyOptions(options...),
+		options: opts,
+		tags:    store.NewTagIndex(&bigcacheTagIndexStorage{client: client}, BigcacheTagPattern, opts.TagIndex...),
+	}
+}
+
+// bigcacheTagIndexStorage keeps the tag index entries in Bigcache, which does not
+// support expiration per entry
+type bigcacheTagIndexStorage struct {
+	client BigcacheClientInterface
+}
+
+func (s *bigcacheTagIndexStorage) Load(_ context.Context, key string) ([]byte, any, error) {
+	data, err := s.client.Get(key)
+	if errors.Is(err, bigcache.ErrEntryNotFound) {
+		return nil, nil, nil
+	}
+
+	return data, nil, err
+}
+
+func (s *bigcacheTagIndexStorage) Save(_ context.Context, key string, data []byte, _ any, _ time.Duration) (bool, error) {
+	return true, s.client.Set(key, data)
+}
+
+func (s *bigcacheTagIndexStorage) Delete(_ context.Context, key string) error {
+	if err := s.client.Delete(key); !errors.Is(err, bigcache.ErrEntryNotFound) {
+		return err
 	}
+
+	return nil
 }
 
 // Get returns data stored from a given key
@@ -77,42 +113,51 @@ func (s *BigcacheStore) Set(ctx context.Context, key any, value any, options ...
 	}
 
 	if tags := opts.Tags; len(tags) > 0 {
-		s.setTags(ctx, key, tags)
+		return s.tags.Add(ctx, key.(string), tags)
 	}
 
 	return nil
 }
 
-func (s *BigcacheStore) setTags(ctx context.Context, key any, tags []string) {
-	for _, tag := range tags {
-		tagKey := fmt.Sprintf(BigcacheTagPattern, tag)
-		cacheKeys := []string{}
+// Delete removes data from Bigcache for given key identifier, and dissociates it from its tags
+func (s *BigcacheStore) Delete(ctx context.Context, key any) error {
+	err := s.client.Delete(key.(string))
+	if err != nil && !errors.Is(err, bigcache.ErrEntryNotFound) {
+		return err
+	}
 
-		if result, err := s.Get(ctx, tagKey); err == nil {
-			if bytes, ok := result.([]byte); ok {
-				cacheKeys = strings.Split(string(bytes), ",")
-			}
-		}
+	// The key is dissociated from its tags even if its entry has already been removed
+	if err := s.tags.RemoveKeys(ctx, []string{key.(string)}); err != nil {
+		return err
+	}
 
-		alreadyInserted := false
-		for _, cacheKey := range cacheKeys {
-			if cacheKey == key.(string) {
-				alreadyInserted = true
-				break
-			}
-		}
+	return err
+}
 
-		if !alreadyInserted {
-			cacheKeys = append(cacheKeys, key.(string))
-		}
+// KeysForTag returns the keys associated to the given tag
+func (s *BigcacheStore) KeysForTag(ctx context.Context, tag string) ([]string, error) {
+	return s.tags.Keys(ctx, tag)
+}
 
-		s.Set(ctx, tagKey, []byte(strings.Join(cacheKeys, ",")), store.WithExpiration(720*time.Hour))
-	}
+// TagsForKey returns the tags associated to the given key
+func (s *BigcacheStore) TagsForKey(ctx context.Context, key any) ([]string, error) {
+	return s.tags.KeyTags(ctx, key.(string))
 }
 
-// Delete removes data from Bigcache for given key identifier
-func (s *BigcacheStore) Delete(_ context.Context, key any) error {
-	return s.client.Delete(key.(string))
+// PruneTags dissociates the keys which do not exist anymore from their tags
+func (s *BigcacheStore) PruneTags(ctx context.Context) error {
+	return s.tags.Prune(ctx, func(keys []string) ([]string, error) {
+		var missing []string
+		for _, key := range keys {
+			if _, err := s.client.Get(key); errors.Is(err, bigcache.ErrEntryNotFound) {
+				missing = append(missing, key)
+			} else if err != nil {
+				return nil, err
+			}
+		}
+
+		return missing, nil
+	})
 }
 
 // Invalidate invalidates some cache data in Bigcache for given options
@@ -121,21 +166,46 @@ func (s *BigcacheStore) Invalidate(ctx context.Context, options ...store.Invalid
 
 	if tags := opts.Tags; len(tags) > 0 {
 		for _, tag := range tags {
-			tagKey := fmt.Sprintf(BigcacheTagPattern, tag)
-			result, err := s.Get(ctx, tagKey)
+			cacheKeys, err := s.tags.Keys(ctx, tag)
 			if err != nil {
-				return nil
+				return err
 			}
 
-			cacheKeys := []string{}
-			if bytes, ok := result.([]byte); ok {
-				cacheKeys = strings.Split(string(bytes), ",")
+			for _, cacheKey := range cacheKeys {
+				s.client.Delete(cacheKey)
 			}
 
-			for _, cacheKey := range cacheKeys {
-				s.Delete(ctx, cacheKey)
+			// The deleted keys are dissociated from all their tags, including this one
+			if err := s.tags.RemoveKeys(ctx, cacheKeys); err != nil {
+				return err
+			}
+		}
+	}
+
+	if opts.TargetsKeys() {
+		client, ok := s.client.(BigcacheIteratorInterface)
+		if !ok {
+			return store.NotSupportedOperation(BigcacheType, "invalidate by prefix or pattern")
+		}
+
+		var cacheKeys []string
+		for iterator := client.Iterator(); iterator.SetNext(); {
+			// Entries removed during the iteration cannot be retrieved
+			entry, err := iterator.Value()
+			if err != nil {
+				continue
 			}
+
+			if !store.IsTagKey(BigcacheTagPattern, entry.Key()) && opts.MatchKey(entry.Key()) {
+				cacheKeys = append(cacheKeys, entry.Key())
+			}
+		}
+
+		for _, cacheKey := range cacheKeys {
+			s.client.Delete(cacheKey)
 		}
+
+		return s.tags.RemoveKeys(ctx, cacheKeys)
 	}
 
 	return nil
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/allegro/bigcache/v3"
 	lib_store "github.com/eko/gocache/lib/v4/store"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -168,8 +170,10 @@ func TestBigcacheSetWithTags(t *testing.T) {
 
 	client := NewMockBigcacheClientInterface(ctrl)
 	client.EXPECT().Set(cacheKey, cacheValue).Return(nil)
-	client.EXPECT().Get("gocache_tag_tag1").Return(nil, nil)
-	client.EXPECT().Set("gocache_tag_tag1", []byte("my-key")).Return(nil)
+	client.EXPECT().Get("gocache_tag_tag1").Return(nil, bigcache.ErrEntryNotFound)
+	client.EXPECT().Set("gocache_tag_tag1", []byte("\x00\x01+\x06my-key")).Return(nil)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return(nil, bigcache.ErrEntryNotFound)
+	client.EXPECT().Set("gocache_key_tags_my-key", []byte("\x00\x01+\x04tag1")).Return(nil)
 
 	store := NewBigcache(client)
 
@@ -180,6 +184,56 @@ func TestBigcacheSetWithTags(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestBigcacheSetWithTagsWhenKeyContainsComma(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my,key"
+	cacheValue := []byte("my-cache-value")
+
+	client := NewMockBigcacheClientInterface(ctrl)
+	client.EXPECT().Set(cacheKey, cacheValue).Return(nil)
+	client.EXPECT().Get("gocache_tag_tag1").Return([]byte("\x00\x01+\x0ca-second-key"), nil)
+	client.EXPECT().Set("gocache_tag_tag1", []byte("\x00\x01+\x0ca-second-key+\x06my,key")).Return(nil)
+	client.EXPECT().Get("gocache_key_tags_my,key").Return(nil, bigcache.ErrEntryNotFound)
+	client.EXPECT().Set("gocache_key_tags_my,key", []byte("\x00\x01+\x04tag1")).Return(nil)
+
+	store := NewBigcache(client)
+
+	// When
+	err := store.Set(ctx, cacheKey, cacheValue, lib_store.WithTags([]string{"tag1"}))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestBigcacheSetWithTagsWhenTagIndexError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+	cacheValue := []byte("my-cache-value")
+
+	expectedErr := errors.New("entry is bigger than max shard size")
+
+	client := NewMockBigcacheClientInterface(ctrl)
+	client.EXPECT().Set(cacheKey, cacheValue).Return(nil)
+	client.EXPECT().Get("gocache_tag_tag1").Return(nil, bigcache.ErrEntryNotFound)
+	client.EXPECT().Set("gocache_tag_tag1", []byte("\x00\x01+\x06my-key")).Return(expectedErr)
+
+	store := NewBigcache(client)
+
+	// When
+	err := store.Set(ctx, cacheKey, cacheValue, lib_store.WithTags([]string{"tag1"}))
+
+	// Then
+	assert.Equal(t, expectedErr, err)
+}
+
 func TestBigcacheSetWithTagsWhenAlreadyInserted(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -191,8 +245,8 @@ func TestBigcacheSetWithTagsWhenAlreadyInserted(t *testing.T) {
 
 	client := NewMockBigcacheClientInterface(ctrl)
 	client.EXPECT().Set(cacheKey, cacheValue).Return(nil)
-	client.EXPECT().Get("gocache_tag_tag1").Return([]byte("my-key,a-second-key"), nil)
-	client.EXPECT().Set("gocache_tag_tag1", []byte("my-key,a-second-key")).Return(nil)
+	client.EXPECT().Get("gocache_tag_tag1").Return([]byte("\x00\x01+\x06my-key+\x0ca-second-key"), nil)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return([]byte("\x00\x01+\x04tag1"), nil)
 
 	store := NewBigcache(client)
 
@@ -213,6 +267,8 @@ func TestBigcacheDelete(t *testing.T) {
 
 	client := NewMockBigcacheClientInterface(ctrl)
 	client.EXPECT().Delete(cacheKey).Return(nil)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return(nil, bigcache.ErrEntryNotFound)
+	client.EXPECT().Delete("gocache_key_tags_my-key").Return(bigcache.ErrEntryNotFound)
 
 	store := NewBigcache(client)
 
@@ -223,6 +279,30 @@ func TestBigcacheDelete(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestBigcacheDeleteWithTags(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+
+	client := NewMockBigcacheClientInterface(ctrl)
+	client.EXPECT().Delete(cacheKey).Return(nil)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return([]byte("\x00\x01+\x04tag1"), nil)
+	client.EXPECT().Get("gocache_tag_tag1").Return([]byte("\x00\x01+\x06my-key+\x0ca-second-key"), nil)
+	client.EXPECT().Set("gocache_tag_tag1", []byte("\x00\x01+\x06my-key+\x0ca-second-key-\x06my-key")).Return(nil)
+	client.EXPECT().Delete("gocache_key_tags_my-key").Return(nil)
+
+	store := NewBigcache(client)
+
+	// When
+	err := store.Delete(ctx, cacheKey)
+
+	// Then the key is dissociated from its tags
+	assert.Nil(t, err)
+}
+
 func TestBigcacheDeleteWhenError(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -251,12 +331,19 @@ func TestBigcacheInvalidate(t *testing.T) {
 
 	ctx := context.Background()
 
-	cacheKeys := []byte("a23fdf987h2svc23,jHG2372x38hf74")
+	cacheKeys := []byte("\x00\x01+\x10a23fdf987h2svc23+\x0ejHG2372x38hf74")
 
 	client := NewMockBigcacheClientInterface(ctrl)
-	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys, nil)
+	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys, nil).Times(2)
 	client.EXPECT().Delete("a23fdf987h2svc23").Return(nil)
 	client.EXPECT().Delete("jHG2372x38hf74").Return(nil)
+	client.EXPECT().Set("gocache_tag_tag1", []byte("\x00\x01+\x10a23fdf987h2svc23+\x0ejHG2372x38hf74-\x10a23fdf987h2svc23-\x0ejHG2372x38hf74")).Return(nil)
+	client.EXPECT().Get("gocache_key_tags_a23fdf987h2svc23").Return([]byte("\x00\x01+\x04tag1"), nil)
+	client.EXPECT().Get("gocache_key_tags_jHG2372x38hf74").Return([]byte("\x00\x01+\x04tag1+\x04tag2"), nil)
+	client.EXPECT().Get("gocache_tag_tag2").Return([]byte("\x00\x01+\x0ejHG2372x38hf74"), nil)
+	client.EXPECT().Set("gocache_tag_tag2", []byte("\x00\x01+\x0ejHG2372x38hf74-\x0ejHG2372x38hf74")).Return(nil)
+	client.EXPECT().Delete("gocache_key_tags_a23fdf987h2svc23").Return(nil)
+	client.EXPECT().Delete("gocache_key_tags_jHG2372x38hf74").Return(nil)
 
 	store := NewBigcache(client)
 
@@ -279,6 +366,10 @@ func TestBigcacheInvalidateWhenError(t *testing.T) {
 	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys, nil)
 	client.EXPECT().Delete("a23fdf987h2svc23").Return(errors.New("unexpected error"))
 	client.EXPECT().Delete("jHG2372x38hf74").Return(nil)
+	client.EXPECT().Get("gocache_key_tags_a23fdf987h2svc23").Return(nil, bigcache.ErrEntryNotFound)
+	client.EXPECT().Get("gocache_key_tags_jHG2372x38hf74").Return(nil, bigcache.ErrEntryNotFound)
+	client.EXPECT().Delete("gocache_key_tags_a23fdf987h2svc23").Return(bigcache.ErrEntryNotFound)
+	client.EXPECT().Delete("gocache_key_tags_jHG2372x38hf74").Return(bigcache.ErrEntryNotFound)
 
 	store := NewBigcache(client)
 
@@ -289,6 +380,127 @@ func TestBigcacheInvalidateWhenError(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestBigcacheInvalidateWithPrefix(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client, err := bigcache.New(ctx, bigcache.DefaultConfig(time.Minute))
+	assert.Nil(t, err)
+
+	store := NewBigcache(client)
+	assert.Nil(t, store.Set(ctx, "tenant:17:key1", []byte("value1"), lib_store.WithTags([]string{"tag1"})))
+	assert.Nil(t, store.Set(ctx, "tenant:170:key1", []byte("value2"), lib_store.WithTags([]string{"tag1"})))
+
+	// When
+	err = store.Invalidate(ctx, lib_store.WithInvalidatePrefix("tenant:17:"))
+
+	// Then
+	assert.Nil(t, err)
+
+	_, err = store.Get(ctx, "tenant:17:key1")
+	assert.True(t, errors.Is(err, bigcache.ErrEntryNotFound))
+
+	value, err := store.Get(ctx, "tenant:170:key1")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value2"), value)
+
+	cacheKeys, err := store.tags.Keys(ctx, "tag1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"tenant:170:key1"}, cacheKeys)
+}
+
+func TestBigcacheInvalidateWithPatternMatchingTagKeys(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client, err := bigcache.New(ctx, bigcache.DefaultConfig(time.Minute))
+	assert.Nil(t, err)
+
+	store := NewBigcache(client)
+	assert.Nil(t, store.Set(ctx, "key1", []byte("value1"), lib_store.WithTags([]string{"tag1"})))
+	assert.Nil(t, store.Set(ctx, "key2", []byte("value2"), lib_store.WithTags([]string{"tag1"})))
+
+	// When the pattern also matches "gocache_tag_tag1" and "gocache_key_tags_key1"
+	err = store.Invalidate(ctx, lib_store.WithInvalidatePattern("*1"))
+
+	// Then only the values are invalidated
+	assert.Nil(t, err)
+
+	_, err = store.Get(ctx, "key1")
+	assert.True(t, errors.Is(err, bigcache.ErrEntryNotFound))
+
+	cacheKeys, err := store.tags.Keys(ctx, "tag1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"key2"}, cacheKeys)
+}
+
+func TestBigcacheInvalidateWithPatternWhenNotSupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockBigcacheClientInterface(ctrl)
+
+	store := NewBigcache(client)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidatePattern("tenant:*"))
+
+	// Then
+	assert.True(t, errors.Is(err, &lib_store.NotSupported{}))
+}
+
+func TestBigcacheKeysForTag(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client, err := bigcache.New(ctx, bigcache.DefaultConfig(time.Minute))
+	assert.Nil(t, err)
+
+	store := NewBigcache(client)
+	assert.Nil(t, store.Set(ctx, "my-key", []byte("my-value"), lib_store.WithTags([]string{"tag1", "tag2"})))
+	assert.Nil(t, store.Set(ctx, "a-second-key", []byte("my-value"), lib_store.WithTags([]string{"tag1"})))
+
+	// When
+	keys, keysErr := store.KeysForTag(ctx, "tag1")
+	tags, tagsErr := store.TagsForKey(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, keysErr)
+	assert.ElementsMatch(t, []string{"my-key", "a-second-key"}, keys)
+	assert.Nil(t, tagsErr)
+	assert.ElementsMatch(t, []string{"tag1", "tag2"}, tags)
+}
+
+func TestBigcachePruneTags(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockBigcacheClientInterface(ctrl)
+	client.EXPECT().Set("my-key", []byte("my-value")).Return(nil)
+	client.EXPECT().Get("gocache_tag_tag1").Return(nil, bigcache.ErrEntryNotFound)
+	client.EXPECT().Set("gocache_tag_tag1", []byte("\x00\x01+\x06my-key")).Return(nil)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return(nil, bigcache.ErrEntryNotFound)
+	client.EXPECT().Set("gocache_key_tags_my-key", []byte("\x00\x01+\x04tag1")).Return(nil)
+
+	store := NewBigcache(client)
+	assert.Nil(t, store.Set(ctx, "my-key", []byte("my-value"), lib_store.WithTags([]string{"tag1"})))
+
+	// When the key has been evicted
+	client.EXPECT().Get("gocache_tag_tag1").Return([]byte("\x00\x01+\x06my-key"), nil).Times(2)
+	client.EXPECT().Get("my-key").Return(nil, bigcache.ErrEntryNotFound)
+	client.EXPECT().Set("gocache_tag_tag1", []byte("\x00\x01")).Return(nil)
+	client.EXPECT().Delete("gocache_key_tags_my-key").Return(nil)
+
+	err := store.PruneTags(ctx)
+
+	// Then
+	assert.Nil(t, err)
+}
+
 func TestBigcacheClear(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
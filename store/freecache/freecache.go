@@ -4,9 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 	"time"
 
+	"github.com/coocood/freecache"
 	lib_store "github.com/eko/gocache/lib/v4/store"
 )
 
@@ -23,26 +23,69 @@ type FreecacheClientInterface interface {
 	GetInt(key int64) (value []byte, err error)
 	TTL(key []byte) (timeLeft uint32, err error)
 	Set(key, value []byte, expireSeconds int) (err error)
+	Touch(key []byte, expireSeconds int) (err error)
 	SetInt(key int64, value []byte, expireSeconds int) (err error)
 	Del(key []byte) (affected bool)
 	DelInt(key int64) (affected bool)
 	Clear()
 }
 
+// FreecacheIteratorInterface represents a client able to iterate over its entries, such
+// as a coocood/freecache client, which is needed to invalidate keys by prefix or pattern
+type FreecacheIteratorInterface interface {
+	NewIterator() *freecache.Iterator
+}
+
 // FreecacheStore is a store for freecache
 type FreecacheStore struct {
 	client  FreecacheClientInterface
 	options *lib_store.Options
+	tags    *lib_store.TagIndex
 }
 
 // NewFreecache creates a new store to freecache instance(s)
 func NewFreecache(client FreecacheClientInterface, options ...lib_store.Option) *FreecacheStore {
+	opts := lib_store.ApplyOptions(options...)
+
 	return &FreecacheStore{
 		client:  client,
-		options: lib_store.ApplyOptions(options...),
+		options: opts,
+		tags:    lib_store.NewTagIndex(&freecacheTagIndexStorage{client: client}, FreecacheTagPattern, opts.TagIndex...),
 	}
 }
 
+// freecacheTagIndexStorage keeps the tag index entries in freecache
+type freecacheTagIndexStorage struct {
+	client FreecacheClientInterface
+}
+
+func (s *freecacheTagIndexStorage) Load(_ context.Context, key string) ([]byte, any, error) {
+	data, err := s.client.Get([]byte(key))
+	if errors.Is(err, freecache.ErrNotFound) {
+		return nil, nil, nil
+	}
+
+	return data, nil, err
+}
+
+func (s *freecacheTagIndexStorage) Save(_ context.Context, key string, data []byte, _ any, ttl time.Duration) (bool, error) {
+	return true, s.client.Set([]byte(key), data, int(ttl.Seconds()))
+}
+
+func (s *freecacheTagIndexStorage) Delete(_ context.Context, key string) error {
+	s.client.Del([]byte(key))
+	return nil
+}
+
+func (s *freecacheTagIndexStorage) Touch(_ context.Context, key string, ttl time.Duration) error {
+	err := s.client.Touch([]byte(key), int(ttl.Seconds()))
+	if errors.Is(err, freecache.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
+
 // Get returns data stored from a given key. It returns the value or not found error
 func (f *FreecacheStore) Get(_ context.Context, key any) (any, error) {
 	var err error
@@ -102,53 +145,63 @@ func (f *FreecacheStore) Set(ctx context.Context, key any, value any, options ..
 			return fmt.Errorf("size of key: %v, value: %v, err: %v", k, len(val), err)
 		}
 		if tags := opts.Tags; len(tags) > 0 {
-			f.setTags(ctx, key, tags)
+			return f.tags.Add(ctx, k, tags)
 		}
 		return nil
 	}
 	return errors.New("key type not supported by Freecache store")
 }
 
-func (f *FreecacheStore) setTags(ctx context.Context, key any, tags []string) {
-	for _, tag := range tags {
-		tagKey := fmt.Sprintf(FreecacheTagPattern, tag)
-		cacheKeys := f.getCacheKeysForTag(ctx, tagKey)
-
-		alreadyInserted := false
-		for _, cacheKey := range cacheKeys {
-			if cacheKey == key.(string) {
-				alreadyInserted = true
-				break
-			}
-		}
-
-		if !alreadyInserted {
-			cacheKeys = append(cacheKeys, key.(string))
+// Delete deletes an item in the cache by key and returns err or nil if a delete occurred.
+// The item is also dissociated from its tags.
+func (f *FreecacheStore) Delete(ctx context.Context, key any) error {
+	if v, ok := key.(string); ok {
+		if err := f.delete(v); err != nil {
+			return err
 		}
+		return f.tags.RemoveKeys(ctx, []string{v})
+	}
+	return errors.New("key type not supported by Freecache store")
+}
 
-		f.Set(ctx, tagKey, []byte(strings.Join(cacheKeys, ",")), lib_store.WithExpiration(720*time.Hour))
+// delete deletes an item in the cache by key, without dissociating it from its tags
+func (f *FreecacheStore) delete(key string) error {
+	if f.client.Del([]byte(key)) {
+		return nil
 	}
+	return fmt.Errorf("failed to delete key %v", key)
 }
 
-func (f *FreecacheStore) getCacheKeysForTag(ctx context.Context, tagKey string) []string {
-	cacheKeys := []string{}
-	if result, err := f.Get(ctx, tagKey); err == nil && result != nil {
-		if str, ok := result.([]byte); ok {
-			cacheKeys = strings.Split(string(str), ",")
-		}
+// KeysForTag returns the keys associated to the given tag
+func (f *FreecacheStore) KeysForTag(ctx context.Context, tag string) ([]string, error) {
+	return f.tags.Keys(ctx, tag)
+}
+
+// TagsForKey returns the tags associated to the given key
+func (f *FreecacheStore) TagsForKey(ctx context.Context, key any) ([]string, error) {
+	if k, ok := key.(string); ok {
+		return f.tags.KeyTags(ctx, k)
 	}
-	return cacheKeys
+
+	// Only string keys are associated to tags
+	return nil, nil
 }
 
-// Delete deletes an item in the cache by key and returns err or nil if a delete occurred
-func (f *FreecacheStore) Delete(_ context.Context, key any) error {
-	if v, ok := key.(string); ok {
-		if f.client.Del([]byte(v)) {
-			return nil
+// PruneTags dissociates the keys which do not exist anymore, such as the expired ones,
+// from their tags
+func (f *FreecacheStore) PruneTags(ctx context.Context) error {
+	return f.tags.Prune(ctx, func(keys []string) ([]string, error) {
+		var missing []string
+		for _, key := range keys {
+			if _, err := f.client.Get([]byte(key)); errors.Is(err, freecache.ErrNotFound) {
+				missing = append(missing, key)
+			} else if err != nil {
+				return nil, err
+			}
 		}
-		return fmt.Errorf("failed to delete key %v", key)
-	}
-	return errors.New("key type not supported by Freecache store")
+
+		return missing, nil
+	})
 }
 
 // Invalidate invalidates some cache data in freecache for given options
@@ -157,21 +210,49 @@ func (f *FreecacheStore) Invalidate(ctx context.Context, options ...lib_store.In
 
 	if tags := opts.Tags; len(tags) > 0 {
 		for _, tag := range tags {
-			tagKey := fmt.Sprintf(FreecacheTagPattern, tag)
-			cacheKeys := f.getCacheKeysForTag(ctx, tagKey)
+			cacheKeys, err := f.tags.Keys(ctx, tag)
+			if err != nil {
+				return err
+			}
 
 			for _, cacheKey := range cacheKeys {
-				err := f.Delete(ctx, cacheKey)
+				err := f.delete(cacheKey)
 				if err != nil {
 					return err
 				}
 			}
 
-			err := f.Delete(ctx, tagKey)
-			if err != nil {
+			if err := f.tags.Remove(ctx, tag, cacheKeys); err != nil {
 				return err
 			}
+
+			// Also dissociate the deleted keys from their other tags
+			if err := f.tags.RemoveKeys(ctx, cacheKeys); err != nil {
+				return err
+			}
+		}
+	}
+
+	if opts.TargetsKeys() {
+		client, ok := f.client.(FreecacheIteratorInterface)
+		if !ok {
+			return lib_store.NotSupportedOperation(FreecacheType, "invalidate by prefix or pattern")
+		}
+
+		var cacheKeys []string
+		iterator := client.NewIterator()
+		for entry := iterator.Next(); entry != nil; entry = iterator.Next() {
+			if !lib_store.IsTagKey(FreecacheTagPattern, string(entry.Key)) && opts.MatchKey(string(entry.Key)) {
+				cacheKeys = append(cacheKeys, string(entry.Key))
+			}
+		}
+
+		// Keys which expired since the iteration are not considered as failures
+		for _, cacheKey := range cacheKeys {
+			f.client.Del([]byte(cacheKey))
 		}
+
+		return f.tags.RemoveKeys(ctx, cacheKeys)
 	}
 
 	return nil
@@ -187,3 +268,8 @@ func (f *FreecacheStore) Clear(_ context.Context) error {
 func (f *FreecacheStore) GetType() string {
 	return FreecacheType
 }
+
+// GetDefaultExpiration returns the expiration applied to the values written without one
+func (f *FreecacheStore) GetDefaultExpiration() time.Duration {
+	return f.options.Expiration
+}
@@ -145,3 +145,17 @@ func (mr *MockFreecacheClientInterfaceMockRecorder) TTL(key interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TTL", reflect.TypeOf((*MockFreecacheClientInterface)(nil).TTL), key)
 }
+
+// Touch mocks base method.
+func (m *MockFreecacheClientInterface) Touch(key []byte, expireSeconds int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", key, expireSeconds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockFreecacheClientInterfaceMockRecorder) Touch(key, expireSeconds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockFreecacheClientInterface)(nil).Touch), key, expireSeconds)
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/coocood/freecache"
 	lib_store "github.com/eko/gocache/lib/v4/store"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -277,13 +278,39 @@ func TestFreecacheDelete(t *testing.T) {
 	cacheKey := "key"
 
 	client := NewMockFreecacheClientInterface(ctrl)
-	client.EXPECT().Del(gomock.Any()).Return(true)
+	client.EXPECT().Del([]byte(cacheKey)).Return(true)
+	client.EXPECT().Get([]byte("gocache_key_tags_key")).Return(nil, freecache.ErrNotFound)
+	client.EXPECT().Del([]byte("gocache_key_tags_key")).Return(false)
 
 	s := NewFreecache(client)
 	err := s.Delete(ctx, cacheKey)
 	assert.Nil(t, err)
 }
 
+func TestFreecacheDeleteWithTags(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+
+	client := NewMockFreecacheClientInterface(ctrl)
+	client.EXPECT().Del([]byte(cacheKey)).Return(true)
+	client.EXPECT().Get([]byte("gocache_key_tags_my-key")).Return([]byte("\x00\x01+\x04tag1"), nil)
+	client.EXPECT().Get([]byte("freecache_tag_tag1")).Return([]byte("\x00\x01+\x06my-key"), nil)
+	client.EXPECT().Set([]byte("freecache_tag_tag1"), []byte("\x00\x01+\x06my-key-\x06my-key"), 2592000).Return(nil)
+	client.EXPECT().Del([]byte("gocache_key_tags_my-key")).Return(true)
+
+	s := NewFreecache(client)
+
+	// When
+	err := s.Delete(ctx, cacheKey)
+
+	// Then the key is dissociated from its tags
+	assert.Nil(t, err)
+}
+
 func TestFreecacheDeleteFailed(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -326,8 +353,10 @@ func TestFreecacheSetWithTags(t *testing.T) {
 
 	client := NewMockFreecacheClientInterface(ctrl)
 	client.EXPECT().Set([]byte(cacheKey), cacheValue, 6).Return(nil)
-	client.EXPECT().Get([]byte("freecache_tag_tag1")).MaxTimes(1).Return(nil, errors.New("value not found in store"))
-	client.EXPECT().Set([]byte("freecache_tag_tag1"), []byte("my-key"), 2592000).Return(nil)
+	client.EXPECT().Get([]byte("freecache_tag_tag1")).Return(nil, freecache.ErrNotFound)
+	client.EXPECT().Set([]byte("freecache_tag_tag1"), []byte("\x00\x01+\x06my-key"), 2592000).Return(nil)
+	client.EXPECT().Get([]byte("gocache_key_tags_my-key")).Return(nil, freecache.ErrNotFound)
+	client.EXPECT().Set([]byte("gocache_key_tags_my-key"), []byte("\x00\x01+\x04tag1"), 2592000).Return(nil)
 
 	s := NewFreecache(client, lib_store.WithExpiration(6*time.Second))
 	err := s.Set(ctx, cacheKey, cacheValue, lib_store.WithExpiration(6*time.Second), lib_store.WithTags([]string{"tag1"}))
@@ -340,12 +369,14 @@ func TestFreecacheInvalidate(t *testing.T) {
 
 	ctx := context.Background()
 
-	cacheKeys := []byte("my-key")
+	cacheKeys := []byte("\x00\x01+\x06my-key")
 
 	client := NewMockFreecacheClientInterface(ctrl)
-	client.EXPECT().Get([]byte("freecache_tag_tag1")).Return(cacheKeys, nil)
+	client.EXPECT().Get([]byte("freecache_tag_tag1")).Return(cacheKeys, nil).Times(2)
 	client.EXPECT().Del([]byte("my-key")).Return(true)
-	client.EXPECT().Del([]byte("freecache_tag_tag1")).Return(true)
+	client.EXPECT().Set([]byte("freecache_tag_tag1"), []byte("\x00\x01+\x06my-key-\x06my-key"), 2592000).Return(nil)
+	client.EXPECT().Get([]byte("gocache_key_tags_my-key")).Return(nil, freecache.ErrNotFound)
+	client.EXPECT().Del([]byte("gocache_key_tags_my-key")).Return(false)
 
 	s := NewFreecache(client, lib_store.WithExpiration(6*time.Second))
 
@@ -365,12 +396,14 @@ func TestFreecacheTagsAlreadyPresent(t *testing.T) {
 	cacheKey := "my-key"
 	cacheValue := []byte("my-cache-value")
 
-	oldCacheKeys := []byte("key1,key2")
+	oldCacheKeys := []byte("\x00\x01+\x04key1+\x04key2")
 
 	client := NewMockFreecacheClientInterface(ctrl)
 	client.EXPECT().Set([]byte(cacheKey), cacheValue, 6).Return(nil)
-	client.EXPECT().Get([]byte("freecache_tag_tag1")).MaxTimes(1).Return(oldCacheKeys, nil)
-	client.EXPECT().Set([]byte("freecache_tag_tag1"), []byte("key1,key2,my-key"), 2592000).Return(nil)
+	client.EXPECT().Get([]byte("freecache_tag_tag1")).Return(oldCacheKeys, nil)
+	client.EXPECT().Set([]byte("freecache_tag_tag1"), []byte("\x00\x01+\x04key1+\x04key2+\x06my-key"), 2592000).Return(nil)
+	client.EXPECT().Get([]byte("gocache_key_tags_my-key")).Return(nil, freecache.ErrNotFound)
+	client.EXPECT().Set([]byte("gocache_key_tags_my-key"), []byte("\x00\x01+\x04tag1"), 2592000).Return(nil)
 
 	s := NewFreecache(client, lib_store.WithExpiration(6*time.Second))
 	err := s.Set(ctx, cacheKey, cacheValue, lib_store.WithExpiration(6*time.Second), lib_store.WithTags([]string{"tag1"}))
@@ -386,12 +419,14 @@ func TestFreecacheTagsRefreshTime(t *testing.T) {
 	cacheKey := "my-key"
 	cacheValue := []byte("my-cache-value")
 
-	oldCacheKeys := []byte("my-key")
+	oldCacheKeys := []byte("\x00\x01+\x06my-key")
 
 	client := NewMockFreecacheClientInterface(ctrl)
 	client.EXPECT().Set([]byte(cacheKey), cacheValue, 6).Return(nil)
-	client.EXPECT().Get([]byte("freecache_tag_tag1")).MaxTimes(1).Return(oldCacheKeys, nil)
-	client.EXPECT().Set([]byte("freecache_tag_tag1"), []byte("my-key"), 2592000).Return(nil)
+	client.EXPECT().Get([]byte("freecache_tag_tag1")).Return(oldCacheKeys, nil)
+	client.EXPECT().Touch([]byte("freecache_tag_tag1"), 2592000).Return(nil)
+	client.EXPECT().Get([]byte("gocache_key_tags_my-key")).Return([]byte("\x00\x01+\x04tag1"), nil)
+	client.EXPECT().Touch([]byte("gocache_key_tags_my-key"), 2592000).Return(nil)
 
 	s := NewFreecache(client, lib_store.WithExpiration(6*time.Second))
 	err := s.Set(ctx, cacheKey, cacheValue, lib_store.WithExpiration(6*time.Second), lib_store.WithTags([]string{"tag1"}))
@@ -407,11 +442,15 @@ func TestFreecacheInvalidateMultipleKeys(t *testing.T) {
 	cacheKeys := []byte("my-key,key1,key2")
 
 	client := NewMockFreecacheClientInterface(ctrl)
-	client.EXPECT().Get([]byte("freecache_tag_tag1")).Return(cacheKeys, nil)
+	client.EXPECT().Get([]byte("freecache_tag_tag1")).Return(cacheKeys, nil).Times(2)
 	client.EXPECT().Del([]byte("my-key")).Return(true)
 	client.EXPECT().Del([]byte("key1")).Return(true)
 	client.EXPECT().Del([]byte("key2")).Return(true)
-	client.EXPECT().Del([]byte("freecache_tag_tag1")).Return(true)
+	client.EXPECT().Set([]byte("freecache_tag_tag1"), []byte("\x00\x01"), 2592000).Return(nil)
+	for _, key := range []string{"my-key", "key1", "key2"} {
+		client.EXPECT().Get([]byte("gocache_key_tags_"+key)).Return(nil, freecache.ErrNotFound)
+		client.EXPECT().Del([]byte("gocache_key_tags_" + key)).Return(false)
+	}
 
 	s := NewFreecache(client, lib_store.WithExpiration(6*time.Second))
 
@@ -443,7 +482,7 @@ func TestFreecacheFailedInvalidateMultipleKeys(t *testing.T) {
 	assert.EqualError(t, err, "failed to delete key my-key")
 }
 
-func TestFreecacheFailedInvalidatePattern(t *testing.T) {
+func TestFreecacheFailedInvalidateTagIndex(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
 
@@ -452,11 +491,11 @@ func TestFreecacheFailedInvalidatePattern(t *testing.T) {
 	cacheKeys := []byte("my-key,key1,key2")
 
 	client := NewMockFreecacheClientInterface(ctrl)
-	client.EXPECT().Get([]byte("freecache_tag_tag1")).Return(cacheKeys, nil)
+	client.EXPECT().Get([]byte("freecache_tag_tag1")).Return(cacheKeys, nil).Times(2)
 	client.EXPECT().Del([]byte("my-key")).Return(true)
 	client.EXPECT().Del([]byte("key1")).Return(true)
 	client.EXPECT().Del([]byte("key2")).Return(true)
-	client.EXPECT().Del([]byte("freecache_tag_tag1")).Return(false)
+	client.EXPECT().Set([]byte("freecache_tag_tag1"), []byte("\x00\x01"), 2592000).Return(errors.New("entry is too large"))
 
 	s := NewFreecache(client, lib_store.WithExpiration(6*time.Second))
 
@@ -464,7 +503,76 @@ func TestFreecacheFailedInvalidatePattern(t *testing.T) {
 	err := s.Invalidate(ctx, lib_store.WithInvalidateTags([]string{"tag1"}))
 
 	// Then
-	assert.EqualError(t, err, "failed to delete key freecache_tag_tag1")
+	assert.EqualError(t, err, "entry is too large")
+}
+
+func TestFreecacheInvalidateWithPrefix(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := freecache.NewCache(1024 * 1024)
+
+	s := NewFreecache(client)
+	assert.Nil(t, s.Set(ctx, "tenant:17:key1", []byte("value1"), lib_store.WithTags([]string{"tag1"})))
+	assert.Nil(t, s.Set(ctx, "tenant:170:key1", []byte("value2"), lib_store.WithTags([]string{"tag1"})))
+
+	// When
+	err := s.Invalidate(ctx, lib_store.WithInvalidatePrefix("tenant:17:"))
+
+	// Then
+	assert.Nil(t, err)
+
+	_, err = client.Get([]byte("tenant:17:key1"))
+	assert.True(t, errors.Is(err, freecache.ErrNotFound))
+
+	value, err := client.Get([]byte("tenant:170:key1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value2"), value)
+
+	cacheKeys, err := s.tags.Keys(ctx, "tag1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"tenant:170:key1"}, cacheKeys)
+}
+
+func TestFreecacheInvalidateWithPatternMatchingTagKeys(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := freecache.NewCache(1024 * 1024)
+
+	s := NewFreecache(client)
+	assert.Nil(t, s.Set(ctx, "key1", []byte("value1"), lib_store.WithTags([]string{"tag1"})))
+	assert.Nil(t, s.Set(ctx, "key2", []byte("value2"), lib_store.WithTags([]string{"tag1"})))
+
+	// When the pattern also matches "freecache_tag_tag1" and "gocache_key_tags_key1"
+	err := s.Invalidate(ctx, lib_store.WithInvalidatePattern("*1"))
+
+	// Then only the values are invalidated
+	assert.Nil(t, err)
+
+	_, err = client.Get([]byte("key1"))
+	assert.True(t, errors.Is(err, freecache.ErrNotFound))
+
+	cacheKeys, err := s.tags.Keys(ctx, "tag1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"key2"}, cacheKeys)
+}
+
+func TestFreecacheInvalidateWithPatternWhenNotSupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockFreecacheClientInterface(ctrl)
+
+	s := NewFreecache(client)
+
+	// When
+	err := s.Invalidate(ctx, lib_store.WithInvalidatePattern("tenant:*"))
+
+	// Then
+	assert.True(t, errors.Is(err, &lib_store.NotSupported{}))
 }
 
 func TestFreecacheClearAll(t *testing.T) {
@@ -499,3 +607,62 @@ func TestFreecacheGetType(t *testing.T) {
 	// Then
 	assert.Equal(t, FreecacheType, ty)
 }
+
+func TestFreecacheKeysForTag(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	s := NewFreecache(freecache.NewCache(1024 * 1024))
+	assert.Nil(t, s.Set(ctx, "my-key", []byte("my-value"), lib_store.WithTags([]string{"tag1", "tag2"})))
+	assert.Nil(t, s.Set(ctx, "a-second-key", []byte("my-value"), lib_store.WithTags([]string{"tag1"})))
+
+	// When
+	keys, keysErr := s.KeysForTag(ctx, "tag1")
+	tags, tagsErr := s.TagsForKey(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, keysErr)
+	assert.ElementsMatch(t, []string{"my-key", "a-second-key"}, keys)
+	assert.Nil(t, tagsErr)
+	assert.ElementsMatch(t, []string{"tag1", "tag2"}, tags)
+}
+
+func TestFreecachePruneTags(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockFreecacheClientInterface(ctrl)
+	client.EXPECT().Set([]byte("my-key"), []byte("my-value"), 0).Return(nil)
+	client.EXPECT().Get([]byte("freecache_tag_tag1")).Return(nil, freecache.ErrNotFound)
+	client.EXPECT().Set([]byte("freecache_tag_tag1"), []byte("\x00\x01+\x06my-key"), 2592000).Return(nil)
+	client.EXPECT().Get([]byte("gocache_key_tags_my-key")).Return(nil, freecache.ErrNotFound)
+	client.EXPECT().Set([]byte("gocache_key_tags_my-key"), []byte("\x00\x01+\x04tag1"), 2592000).Return(nil)
+
+	s := NewFreecache(client)
+	assert.Nil(t, s.Set(ctx, "my-key", []byte("my-value"), lib_store.WithTags([]string{"tag1"})))
+
+	// When the key has expired
+	client.EXPECT().Get([]byte("freecache_tag_tag1")).Return([]byte("\x00\x01+\x06my-key"), nil).Times(2)
+	client.EXPECT().Get([]byte("my-key")).Return(nil, freecache.ErrNotFound)
+	client.EXPECT().Set([]byte("freecache_tag_tag1"), []byte("\x00\x01"), 2592000).Return(nil)
+	client.EXPECT().Del([]byte("gocache_key_tags_my-key")).Return(true)
+
+	err := s.PruneTags(ctx)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestFreecacheGetDefaultExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	client := NewMockFreecacheClientInterface(ctrl)
+
+	store := NewFreecache(client, lib_store.WithExpiration(6*time.Second))
+
+	// When - Then
+	assert.Equal(t, 6*time.Second, store.GetDefaultExpiration())
+}
@@ -4,13 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"sort"
 	"sync"
 	"time"
 
 	lib_store "github.com/eko/gocache/lib/v4/store"
+	"github.com/patrickmn/go-cache"
 )
 
 const (
+	// noExpiration mirrors the go-cache NoExpiration duration
+	noExpiration time.Duration = -1
+
 	// GoCacheType represents the storage type as a string value
 	GoCacheType = "go-cache"
 	// GoCacheTagPattern represents the tag pattern to be used as a key in specified storage
@@ -26,18 +32,41 @@ type GoCacheClientInterface interface {
 	Flush()
 }
 
+// GoCacheItemsInterface represents a client able to list its items, such as a
+// github.com/patrickmn/go-cache client, which is needed to invalidate keys by
+// prefix or pattern
+type GoCacheItemsInterface interface {
+	Items() map[string]cache.Item
+}
+
 // GoCacheStore is a store for GoCache (memory) library
 type GoCacheStore struct {
-	mu      sync.RWMutex
+	// mu guards the sets of keys associated to each tag and of tags associated to each key
+	mu sync.RWMutex
+	// writeMu serializes the writes of values so the atomic operations, which are
+	// implemented as read-modify-write sequences, cannot interleave with them
+	writeMu sync.Mutex
 	client  GoCacheClientInterface
 	options *lib_store.Options
+	// tags are the tags keys have been associated to, whose sets are checked by PruneTags
+	tags   map[string]struct{}
+	tagTTL time.Duration
 }
 
 // NewGoCache creates a new store to GoCache (memory) library instance
 func NewGoCache(client GoCacheClientInterface, options ...lib_store.Option) *GoCacheStore {
+	opts := lib_store.ApplyOptions(options...)
+
+	tagTTL := lib_store.ApplyTagIndexOptions(opts.TagIndex...).TTL
+	if tagTTL <= 0 {
+		tagTTL = noExpiration
+	}
+
 	return &GoCacheStore{
 		client:  client,
-		options: lib_store.ApplyOptions(options...),
+		options: opts,
+		tags:    make(map[string]struct{}),
+		tagTTL:  tagTTL,
 	}
 }
 
@@ -64,55 +93,261 @@ func (s *GoCacheStore) GetWithTTL(_ context.Context, key any) (any, time.Duratio
 }
 
 // Set defines data in GoCache memoey cache for given key identifier
-func (s *GoCacheStore) Set(ctx context.Context, key any, value any, options ...lib_store.Option) error {
+func (s *GoCacheStore) Set(_ context.Context, key any, value any, options ...lib_store.Option) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.set(key.(string), value, options...)
+
+	return nil
+}
+
+// set writes the value and associates it to its tags. The caller must hold writeMu.
+func (s *GoCacheStore) set(key string, value any, options ...lib_store.Option) {
 	opts := lib_store.ApplyOptions(options...)
 	if opts == nil {
 		opts = s.options
 	}
 
-	s.client.Set(key.(string), value, opts.Expiration)
+	s.client.Set(key, value, opts.Expiration)
 
 	if tags := opts.Tags; len(tags) > 0 {
-		s.setTags(ctx, key, tags)
+		s.setTags(key, tags)
+	}
+}
+
+// setTags associates the given key to the given tags, and keeps its tags under
+// KeyTagsPattern
+func (s *GoCacheStore) setTags(key string, tags []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, tag := range tags {
+		s.addToSet(fmt.Sprintf(GoCacheTagPattern, tag), key)
+		s.tags[tag] = struct{}{}
+	}
+
+	s.addToSet(fmt.Sprintf(lib_store.KeyTagsPattern, key), tags...)
+}
+
+// addToSet adds the given members to the set stored at the given key, which is
+// written again only if some of them were missing
+func (s *GoCacheStore) addToSet(setKey string, members ...string) {
+	var set map[string]struct{}
+	if result, exists := s.client.Get(setKey); exists {
+		set, _ = result.(map[string]struct{})
 	}
 
+	if set == nil {
+		set = make(map[string]struct{})
+	}
+
+	added := false
+	for _, member := range members {
+		if _, exists := set[member]; !exists {
+			set[member] = struct{}{}
+			added = true
+		}
+	}
+
+	if added {
+		s.client.Set(setKey, set, s.tagTTL)
+	}
+}
+
+// Delete removes data in GoCache memoey cache for given key identifier, and dissociates
+// it from its tags
+func (s *GoCacheStore) Delete(_ context.Context, key any) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.client.Delete(key.(string))
+	s.removeTags(key.(string))
 	return nil
 }
 
-func (s *GoCacheStore) setTags(ctx context.Context, key any, tags []string) {
-	for _, tag := range tags {
-		tagKey := fmt.Sprintf(GoCacheTagPattern, tag)
-		var cacheKeys map[string]struct{}
+// removeTags dissociates the given deleted keys from their tags
+func (s *GoCacheStore) removeTags(keys ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.dissociate(keys)
+}
 
-		if result, err := s.Get(ctx, tagKey); err == nil {
-			if bytes, ok := result.(map[string]struct{}); ok {
-				cacheKeys = bytes
+// dissociate removes the given keys from the sets of their tags, deleting the emptied
+// ones, and the sets of tags of the keys. It must be called with mu held.
+func (s *GoCacheStore) dissociate(keys []string) {
+	for _, key := range keys {
+		keyTagsKey := fmt.Sprintf(lib_store.KeyTagsPattern, key)
+
+		result, exists := s.client.Get(keyTagsKey)
+		if !exists {
+			continue
+		}
+
+		tags, _ := result.(map[string]struct{})
+		for tag := range tags {
+			tagKey := fmt.Sprintf(GoCacheTagPattern, tag)
+
+			if result, exists := s.client.Get(tagKey); exists {
+				if cacheKeys, ok := result.(map[string]struct{}); ok {
+					delete(cacheKeys, key)
+					if len(cacheKeys) == 0 {
+						s.client.Delete(tagKey)
+					}
+				}
 			}
 		}
 
-		s.mu.RLock()
-		if _, exists := cacheKeys[key.(string)]; exists {
-			s.mu.RUnlock()
+		s.client.Delete(keyTagsKey)
+	}
+}
+
+// KeysForTag returns the keys associated to the given tag, sorted
+func (s *GoCacheStore) KeysForTag(_ context.Context, tag string) ([]string, error) {
+	return s.getSet(fmt.Sprintf(GoCacheTagPattern, tag)), nil
+}
+
+// TagsForKey returns the tags associated to the given key, sorted
+func (s *GoCacheStore) TagsForKey(_ context.Context, key any) ([]string, error) {
+	return s.getSet(fmt.Sprintf(lib_store.KeyTagsPattern, key.(string))), nil
+}
+
+// getSet returns the sorted members of the set stored at the given key
+func (s *GoCacheStore) getSet(setKey string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result, exists := s.client.Get(setKey)
+	if !exists {
+		return nil
+	}
+
+	set, _ := result.(map[string]struct{})
+	members := make([]string, 0, len(set))
+	for member := range set {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+
+	return members
+}
+
+// PruneTags dissociates the keys which do not exist anymore, such as the expired ones,
+// from their tags
+func (s *GoCacheStore) PruneTags(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for tag := range s.tags {
+		result, exists := s.client.Get(fmt.Sprintf(GoCacheTagPattern, tag))
+		cacheKeys, ok := result.(map[string]struct{})
+		if !exists || !ok {
+			delete(s.tags, tag)
 			continue
 		}
-		s.mu.RUnlock()
 
-		if cacheKeys == nil {
-			cacheKeys = make(map[string]struct{})
+		var missing []string
+		for cacheKey := range cacheKeys {
+			if _, exists := s.client.Get(cacheKey); !exists {
+				missing = append(missing, cacheKey)
+			}
 		}
 
-		s.mu.Lock()
-		cacheKeys[key.(string)] = struct{}{}
-		s.mu.Unlock()
+		s.dissociate(missing)
 
-		s.client.Set(tagKey, cacheKeys, 720*time.Hour)
+		// Keys written before their tags were kept have no set of tags
+		for _, cacheKey := range missing {
+			delete(cacheKeys, cacheKey)
+		}
 	}
+
+	return nil
 }
 
-// Delete removes data in GoCache memoey cache for given key identifier
-func (s *GoCacheStore) Delete(_ context.Context, key any) error {
+// Increment atomically adds delta to the counter stored at the given key.
+// The counter keeps its remaining TTL or uses the store default expiration when created.
+func (s *GoCacheStore) Increment(_ context.Context, key any, delta int64) (int64, error) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	var counter int64
+	expiration := s.options.Expiration
+
+	if value, t, exists := s.client.GetWithExpiration(key.(string)); exists {
+		var err error
+		if counter, err = lib_store.ToInt64(value); err != nil {
+			return 0, err
+		}
+		expiration = remainingExpiration(t)
+	}
+
+	counter += delta
+	s.client.Set(key.(string), counter, expiration)
+
+	return counter, nil
+}
+
+// Decrement atomically subtracts delta from the counter stored at the given key
+func (s *GoCacheStore) Decrement(ctx context.Context, key any, delta int64) (int64, error) {
+	return s.Increment(ctx, key, -delta)
+}
+
+// SetIfNotExists defines data in GoCache memory cache only if the given key does not exist yet
+func (s *GoCacheStore) SetIfNotExists(_ context.Context, key any, value any, options ...lib_store.Option) (bool, error) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if _, exists := s.client.Get(key.(string)); exists {
+		return false, nil
+	}
+
+	s.set(key.(string), value, options...)
+
+	return true, nil
+}
+
+// CompareAndSwap replaces data in GoCache memory cache only if the current value equals the old one
+func (s *GoCacheStore) CompareAndSwap(_ context.Context, key any, oldValue any, newValue any, options ...lib_store.Option) (bool, error) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if value, exists := s.client.Get(key.(string)); !exists || !reflect.DeepEqual(value, oldValue) {
+		return false, nil
+	}
+
+	s.set(key.(string), newValue, options...)
+
+	return true, nil
+}
+
+// CompareAndDelete removes data from GoCache memory cache only if the current value equals the old one
+func (s *GoCacheStore) CompareAndDelete(_ context.Context, key any, oldValue any) (bool, error) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if value, exists := s.client.Get(key.(string)); !exists || !reflect.DeepEqual(value, oldValue) {
+		return false, nil
+	}
+
 	s.client.Delete(key.(string))
-	return nil
+	s.removeTags(key.(string))
+
+	return true, nil
+}
+
+// remainingExpiration returns the duration until the given expiration time,
+// a zero time meaning that the item never expires
+func remainingExpiration(t time.Time) time.Duration {
+	if t.IsZero() {
+		return noExpiration
+	}
+
+	if remaining := time.Until(t); remaining > 0 {
+		return remaining
+	}
+
+	return time.Nanosecond
 }
 
 // Invalidate invalidates some cache data in GoCache memoey cache for given options
@@ -122,34 +357,76 @@ func (s *GoCacheStore) Invalidate(ctx context.Context, options ...lib_store.Inva
 	if tags := opts.Tags; len(tags) > 0 {
 		for _, tag := range tags {
 			tagKey := fmt.Sprintf(GoCacheTagPattern, tag)
-			result, err := s.Get(ctx, tagKey)
-			if err != nil {
-				return nil
+
+			s.mu.Lock()
+			result, exists := s.client.Get(tagKey)
+			if !exists {
+				s.mu.Unlock()
+				continue
 			}
 
-			var cacheKeys map[string]struct{}
-			if bytes, ok := result.(map[string]struct{}); ok {
-				cacheKeys = bytes
+			var cacheKeys []string
+			if keys, ok := result.(map[string]struct{}); ok {
+				for cacheKey := range keys {
+					cacheKeys = append(cacheKeys, cacheKey)
+				}
 			}
+			s.client.Delete(tagKey)
+			s.mu.Unlock()
+
+			s.deleteKeys(cacheKeys)
 
-			s.mu.RLock()
-			for cacheKey := range cacheKeys {
-				_ = s.Delete(ctx, cacheKey)
+			// Also dissociate the deleted keys from their other tags
+			s.removeTags(cacheKeys...)
+		}
+	}
+
+	if opts.TargetsKeys() {
+		client, ok := s.client.(GoCacheItemsInterface)
+		if !ok {
+			return lib_store.NotSupportedOperation(GoCacheType, "invalidate by prefix or pattern")
+		}
+
+		var cacheKeys []string
+		for cacheKey := range client.Items() {
+			if !lib_store.IsTagKey(GoCacheTagPattern, cacheKey) && opts.MatchKey(cacheKey) {
+				cacheKeys = append(cacheKeys, cacheKey)
 			}
-			s.mu.RUnlock()
 		}
+
+		s.deleteKeys(cacheKeys)
+		s.removeTags(cacheKeys...)
 	}
 
 	return nil
 }
 
+// deleteKeys removes the values stored at the given keys
+func (s *GoCacheStore) deleteKeys(keys []string) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	for _, key := range keys {
+		s.client.Delete(key)
+	}
+}
+
 // GetType returns the store type
 func (s *GoCacheStore) GetType() string {
 	return GoCacheType
 }
 
+// GetDefaultExpiration returns the expiration applied to the values written without one,
+// which is zero when the default expiration of the go-cache client applies
+func (s *GoCacheStore) GetDefaultExpiration() time.Duration {
+	return s.options.Expiration
+}
+
 // Clear resets all data in the store
 func (s *GoCacheStore) Clear(_ context.Context) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
 	s.client.Flush()
 	return nil
 }
@@ -2,7 +2,9 @@ package go_cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -170,6 +172,8 @@ func TestGoCacheSetWithTags(t *testing.T) {
 	client.EXPECT().Get("gocache_tag_tag1").Return(nil, true)
 	cacheKeys := map[string]struct{}{"my-key": {}}
 	client.EXPECT().Set("gocache_tag_tag1", cacheKeys, 720*time.Hour)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return(nil, false)
+	client.EXPECT().Set("gocache_key_tags_my-key", map[string]struct{}{"tag1": {}}, 720*time.Hour)
 
 	store := NewGoCache(client)
 
@@ -194,6 +198,7 @@ func TestGoCacheSetWithTagsWhenAlreadyInserted(t *testing.T) {
 
 	cacheKeys := map[string]struct{}{"my-key": {}, "a-second-key": {}}
 	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys, true)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return(map[string]struct{}{"tag1": {}}, true)
 
 	store := NewGoCache(client)
 
@@ -214,6 +219,7 @@ func TestGoCacheDelete(t *testing.T) {
 
 	client := NewMockGoCacheClientInterface(ctrl)
 	client.EXPECT().Delete(cacheKey)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return(nil, false)
 
 	store := NewGoCache(client)
 
@@ -224,6 +230,224 @@ func TestGoCacheDelete(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestGoCacheDeleteWithTags(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+
+	tag1Keys := map[string]struct{}{"my-key": {}, "a-second-key": {}}
+	tag2Keys := map[string]struct{}{"my-key": {}}
+
+	client := NewMockGoCacheClientInterface(ctrl)
+	client.EXPECT().Delete(cacheKey)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return(map[string]struct{}{"tag1": {}, "tag2": {}}, true)
+	client.EXPECT().Get("gocache_tag_tag1").Return(tag1Keys, true)
+	client.EXPECT().Get("gocache_tag_tag2").Return(tag2Keys, true)
+	client.EXPECT().Delete("gocache_tag_tag2")
+	client.EXPECT().Delete("gocache_key_tags_my-key")
+
+	store := NewGoCache(client)
+
+	// When
+	err := store.Delete(ctx, cacheKey)
+
+	// Then the key is dissociated from its tags
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]struct{}{"a-second-key": {}}, tag1Keys)
+}
+
+func TestGoCacheKeysForTag(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	store := NewGoCache(cache.New(cache.NoExpiration, cache.NoExpiration))
+	assert.Nil(t, store.Set(ctx, "my-key", "my-value", lib_store.WithTags([]string{"tag2", "tag1"})))
+	assert.Nil(t, store.Set(ctx, "a-second-key", "my-value", lib_store.WithTags([]string{"tag1"})))
+
+	// When
+	keys, keysErr := store.KeysForTag(ctx, "tag1")
+	tags, tagsErr := store.TagsForKey(ctx, "my-key")
+	missingTags, missingErr := store.TagsForKey(ctx, "missing-key")
+
+	// Then
+	assert.Nil(t, keysErr)
+	assert.Equal(t, []string{"a-second-key", "my-key"}, keys)
+	assert.Nil(t, tagsErr)
+	assert.Equal(t, []string{"tag1", "tag2"}, tags)
+	assert.Nil(t, missingErr)
+	assert.Empty(t, missingTags)
+}
+
+func TestGoCachePruneTags(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := cache.New(10*time.Second, 30*time.Second)
+	store := NewGoCache(client)
+
+	err := store.Set(ctx, "my-key", "my-value", lib_store.WithTags([]string{"tag1"}), lib_store.WithExpiration(10*time.Millisecond))
+	assert.Nil(t, err)
+
+	err = store.Set(ctx, "a-second-key", "my-value", lib_store.WithTags([]string{"tag1"}))
+	assert.Nil(t, err)
+
+	time.Sleep(20 * time.Millisecond)
+
+	// When
+	err = store.PruneTags(ctx)
+
+	// Then the expired key is dissociated from its tags
+	assert.Nil(t, err)
+
+	cacheKeys, _ := client.Get("gocache_tag_tag1")
+	assert.Equal(t, map[string]struct{}{"a-second-key": {}}, cacheKeys)
+
+	_, exists := client.Get("gocache_key_tags_my-key")
+	assert.False(t, exists)
+}
+
+func TestGoCacheIncrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockGoCacheClientInterface(ctrl)
+	client.EXPECT().GetWithExpiration("my-counter").Return(int64(5), time.Time{}, true)
+	client.EXPECT().Set("my-counter", int64(7), noExpiration)
+
+	store := NewGoCache(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), value)
+}
+
+func TestGoCacheDecrementWhenCounterIsMissing(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockGoCacheClientInterface(ctrl)
+	client.EXPECT().GetWithExpiration("my-counter").Return(nil, time.Time{}, false)
+	client.EXPECT().Set("my-counter", int64(-2), 5*time.Second)
+
+	store := NewGoCache(client, lib_store.WithExpiration(5*time.Second))
+
+	// When
+	value, err := store.Decrement(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-2), value)
+}
+
+func TestGoCacheIncrementWhenValueIsNotACounter(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockGoCacheClientInterface(ctrl)
+	client.EXPECT().GetWithExpiration("my-key").Return("my-value", time.Time{}, true)
+
+	store := NewGoCache(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-key", 2)
+
+	// Then
+	assert.NotNil(t, err)
+	assert.Equal(t, int64(0), value)
+}
+
+func TestGoCacheSetIfNotExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockGoCacheClientInterface(ctrl)
+	client.EXPECT().Get("my-key").Return(nil, false)
+	client.EXPECT().Set("my-key", "my-value", 5*time.Second)
+
+	store := NewGoCache(client)
+
+	// When
+	set, err := store.SetIfNotExists(ctx, "my-key", "my-value", lib_store.WithExpiration(5*time.Second))
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, set)
+}
+
+func TestGoCacheSetIfNotExistsWhenKeyExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockGoCacheClientInterface(ctrl)
+	client.EXPECT().Get("my-key").Return("other-value", true)
+
+	store := NewGoCache(client)
+
+	// When
+	set, err := store.SetIfNotExists(ctx, "my-key", "my-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, set)
+}
+
+func TestGoCacheCompareAndSwap(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockGoCacheClientInterface(ctrl)
+	client.EXPECT().Get("my-key").Return([]byte("old-value"), true)
+	client.EXPECT().Set("my-key", []byte("new-value"), 0*time.Second)
+
+	store := NewGoCache(client)
+
+	// When
+	swapped, err := store.CompareAndSwap(ctx, "my-key", []byte("old-value"), []byte("new-value"))
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, swapped)
+}
+
+func TestGoCacheCompareAndDelete(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockGoCacheClientInterface(ctrl)
+	client.EXPECT().Get("my-key").Return("old-value", true)
+	client.EXPECT().Delete("my-key")
+	client.EXPECT().Get("gocache_key_tags_my-key").Return(nil, false)
+
+	store := NewGoCache(client)
+
+	// When
+	deleted, err := store.CompareAndDelete(ctx, "my-key", "old-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, deleted)
+}
+
 func TestGoCacheInvalidate(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -234,8 +458,11 @@ func TestGoCacheInvalidate(t *testing.T) {
 
 	client := NewMockGoCacheClientInterface(ctrl)
 	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys, true)
+	client.EXPECT().Delete("gocache_tag_tag1")
 	client.EXPECT().Delete("a23fdf987h2svc23")
 	client.EXPECT().Delete("jHG2372x38hf74")
+	client.EXPECT().Get("gocache_key_tags_a23fdf987h2svc23").Return(nil, false)
+	client.EXPECT().Get("gocache_key_tags_jHG2372x38hf74").Return(nil, false)
 
 	store := NewGoCache(client)
 
@@ -266,6 +493,76 @@ func TestGoCacheInvalidateWhenError(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestGoCacheInvalidateWithPrefix(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := cache.New(cache.NoExpiration, cache.NoExpiration)
+
+	store := NewGoCache(client)
+	assert.Nil(t, store.Set(ctx, "tenant:17:key1", "value1", lib_store.WithTags([]string{"tag1"})))
+	assert.Nil(t, store.Set(ctx, "tenant:17:key2", "value2"))
+	assert.Nil(t, store.Set(ctx, "tenant:170:key1", "value3", lib_store.WithTags([]string{"tag1"})))
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidatePrefix("tenant:17:"))
+
+	// Then
+	assert.Nil(t, err)
+
+	_, err = store.Get(ctx, "tenant:17:key1")
+	assert.True(t, errors.Is(err, lib_store.NotFound{}))
+	_, err = store.Get(ctx, "tenant:17:key2")
+	assert.True(t, errors.Is(err, lib_store.NotFound{}))
+
+	value, err := store.Get(ctx, "tenant:170:key1")
+	assert.Nil(t, err)
+	assert.Equal(t, "value3", value)
+
+	tagKeys, _ := client.Get("gocache_tag_tag1")
+	assert.Equal(t, map[string]struct{}{"tenant:170:key1": {}}, tagKeys)
+}
+
+func TestGoCacheInvalidateWithPatternMatchingTagKeys(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := cache.New(cache.NoExpiration, cache.NoExpiration)
+
+	store := NewGoCache(client)
+	assert.Nil(t, store.Set(ctx, "key1", "value1", lib_store.WithTags([]string{"tag1"})))
+	assert.Nil(t, store.Set(ctx, "key2", "value2", lib_store.WithTags([]string{"tag1"})))
+
+	// When the pattern also matches "gocache_tag_tag1" and "gocache_key_tags_key1"
+	err := store.Invalidate(ctx, lib_store.WithInvalidatePattern("*1"))
+
+	// Then only the values are invalidated
+	assert.Nil(t, err)
+
+	_, err = store.Get(ctx, "key1")
+	assert.True(t, errors.Is(err, lib_store.NotFound{}))
+
+	tagKeys, _ := client.Get("gocache_tag_tag1")
+	assert.Equal(t, map[string]struct{}{"key2": {}}, tagKeys)
+}
+
+func TestGoCacheInvalidateWithPatternWhenNotSupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockGoCacheClientInterface(ctrl)
+
+	store := NewGoCache(client)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidatePattern("tenant:*"))
+
+	// Then
+	assert.True(t, errors.Is(err, &lib_store.NotSupported{}))
+}
+
 func TestGoCacheClear(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -344,3 +641,81 @@ func TestGoCacheInvalidateConcurrency(t *testing.T) {
 
 	}
 }
+
+func TestGoCacheIncrementConcurrency(t *testing.T) {
+	ctx := context.Background()
+
+	client := cache.New(10*time.Second, 30*time.Second)
+	store := NewGoCache(client)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := store.Increment(ctx, "my-counter", 1)
+			assert.Nil(t, err, err)
+		}()
+	}
+	wg.Wait()
+
+	value, err := store.Get(ctx, "my-counter")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(200), value)
+}
+
+func TestGoCacheSetAndCompareAndSwapConcurrency(t *testing.T) {
+	ctx := context.Background()
+
+	client := cache.New(10*time.Second, 30*time.Second)
+	store := NewGoCache(client)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 200; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+
+			err := store.Set(ctx, "my-key", i)
+			assert.Nil(t, err, err)
+		}(i)
+
+		go func(i int) {
+			defer wg.Done()
+
+			value, err := store.Get(ctx, "my-key")
+			if err != nil {
+				return
+			}
+
+			_, err = store.CompareAndSwap(ctx, "my-key", value, -i)
+			assert.Nil(t, err, err)
+		}(i)
+	}
+	wg.Wait()
+
+	// Once the writers are done, a value which has just been set is swapped
+	err := store.Set(ctx, "my-key", 1)
+	assert.Nil(t, err)
+
+	swapped, err := store.CompareAndSwap(ctx, "my-key", 1, 2)
+	assert.Nil(t, err)
+	assert.True(t, swapped)
+
+	value, err := store.Get(ctx, "my-key")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, value)
+}
+
+func TestGoCacheGetDefaultExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	client := NewMockGoCacheClientInterface(ctrl)
+
+	store := NewGoCache(client, lib_store.WithExpiration(6*time.Second))
+
+	// When - Then
+	assert.Equal(t, 6*time.Second, store.GetDefaultExpiration())
+}
@@ -7,7 +7,6 @@ require (
 	github.com/golang/mock v1.6.0
 	github.com/hazelcast/hazelcast-go-client v1.4.0
 	github.com/stretchr/testify v1.8.1
-	golang.org/x/sync v0.1.0
 )
 
 require (
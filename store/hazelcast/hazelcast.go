@@ -4,13 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	lib_store "github.com/eko/gocache/lib/v4/store"
 	"github.com/hazelcast/hazelcast-go-client"
 	"github.com/hazelcast/hazelcast-go-client/types"
-	"golang.org/x/sync/errgroup"
 )
 
 // HazelcastMapInterface represents a hazelcast/hazelcast-go-client map
@@ -19,7 +17,12 @@ type HazelcastMapInterface interface {
 	GetEntryView(ctx context.Context, key any) (*types.SimpleEntryView, error)
 	SetWithTTL(ctx context.Context, key any, value any, ttl time.Duration) error
 	Remove(ctx context.Context, key any) (any, error)
+	ContainsKey(ctx context.Context, key any) (bool, error)
 	Clear(ctx context.Context) error
+	PutIfAbsentWithTTL(ctx context.Context, key any, value any, ttl time.Duration) (any, error)
+	ReplaceIfSame(ctx context.Context, key any, oldValue any, newValue any) (bool, error)
+	RemoveIfSame(ctx context.Context, key any, value any) (bool, error)
+	SetTTL(ctx context.Context, key any, ttl time.Duration) error
 }
 
 type HazelcastMapInterfaceProvider func(ctx context.Context) (HazelcastMapInterface, error)
@@ -30,33 +33,114 @@ const (
 	// HazelcastTagPattern represents the tag pattern to be used as a key in specified storage
 	HazelcastTagPattern = "gocache_tag_%s"
 
-	TagKeyExpiry = 720 * time.Hour
+	// TagKeyExpiry is the default expiration of the tag index entries
+	TagKeyExpiry = lib_store.DefaultTagIndexTTL
 )
 
 // HazelcastStore is a store for Hazelcast
 type HazelcastStore struct {
 	mapProvider HazelcastMapInterfaceProvider
 	options     *lib_store.Options
+	tags        *lib_store.TagIndex
 }
 
 // NewHazelcast creates a new store to Hazelcast instance(s)
 func NewHazelcast(hzClient *hazelcast.Client, mapName string, options ...lib_store.Option) *HazelcastStore {
-	return &HazelcastStore{
-		mapProvider: func(ctx context.Context) (HazelcastMapInterface, error) {
-			return hzClient.GetMap(ctx, mapName)
-		},
-		options: lib_store.ApplyOptions(options...),
-	}
+	return newHazelcastWithProvider(func(ctx context.Context) (HazelcastMapInterface, error) {
+		return hzClient.GetMap(ctx, mapName)
+	}, options...)
 }
 
 // newHazelcast creates a new store with given HazelcastMapInterface for test purpose
 func newHazelcast(hzMap HazelcastMapInterface, options ...lib_store.Option) *HazelcastStore {
+	return newHazelcastWithProvider(func(ctx context.Context) (HazelcastMapInterface, error) {
+		return hzMap, nil
+	}, options...)
+}
+
+func newHazelcastWithProvider(mapProvider HazelcastMapInterfaceProvider, options ...lib_store.Option) *HazelcastStore {
+	opts := lib_store.ApplyOptions(options...)
+	tagIndexOptions := append([]lib_store.TagIndexOption{lib_store.WithTagIndexTTL(TagKeyExpiry)}, opts.TagIndex...)
+
 	return &HazelcastStore{
-		mapProvider: func(ctx context.Context) (HazelcastMapInterface, error) {
-			return hzMap, nil
-		},
-		options: lib_store.ApplyOptions(options...),
+		mapProvider: mapProvider,
+		options:     opts,
+		tags:        lib_store.NewTagIndex(&hazelcastTagIndexStorage{mapProvider: mapProvider}, HazelcastTagPattern, tagIndexOptions...),
+	}
+}
+
+// hazelcastTagIndexStorage keeps the tag index entries in the Hazelcast map, replacing
+// them only if they still hold the loaded value to detect concurrent updates
+type hazelcastTagIndexStorage struct {
+	mapProvider HazelcastMapInterfaceProvider
+}
+
+func (s *hazelcastTagIndexStorage) Load(ctx context.Context, key string) ([]byte, any, error) {
+	hzMap, err := s.mapProvider(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	value, err := hzMap.Get(ctx, key)
+	if err != nil || value == nil {
+		return nil, nil, err
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return v, value, nil
+	case string:
+		// Comma-separated list of keys written by a previous version
+		return []byte(v), value, nil
+	}
+
+	return nil, nil, fmt.Errorf("tag index entry of type %T is not supported", value)
+}
+
+func (s *hazelcastTagIndexStorage) Save(ctx context.Context, key string, data []byte, version any, ttl time.Duration) (bool, error) {
+	hzMap, err := s.mapProvider(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if version == nil {
+		previous, err := hzMap.PutIfAbsentWithTTL(ctx, key, data, ttl)
+		return err == nil && previous == nil, err
+	}
+
+	replaced, err := hzMap.ReplaceIfSame(ctx, key, version, data)
+	if err != nil || !replaced {
+		return false, err
+	}
+
+	return true, s.touch(ctx, hzMap, key, ttl)
+}
+
+func (s *hazelcastTagIndexStorage) Delete(ctx context.Context, key string) error {
+	hzMap, err := s.mapProvider(ctx)
+	if err != nil {
+		return err
 	}
+
+	_, err = hzMap.Remove(ctx, key)
+	return err
+}
+
+func (s *hazelcastTagIndexStorage) Touch(ctx context.Context, key string, ttl time.Duration) error {
+	hzMap, err := s.mapProvider(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.touch(ctx, hzMap, key, ttl)
+}
+
+func (s *hazelcastTagIndexStorage) touch(ctx context.Context, hzMap HazelcastMapInterface, key string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+
+	return hzMap.SetTTL(ctx, key, ttl)
 }
 
 // Get returns data stored from a given key
@@ -103,67 +187,192 @@ func (s *HazelcastStore) Set(ctx context.Context, key any, value any, options ..
 		return err
 	}
 	if tags := opts.Tags; len(tags) > 0 {
-		s.setTags(ctx, hzMap, key, tags)
+		return s.tags.Add(ctx, key.(string), tags)
 	}
 	return nil
 }
 
-func (s *HazelcastStore) setTags(ctx context.Context, hzMap HazelcastMapInterface, key any, tags []string) {
-	group, ctx := errgroup.WithContext(ctx)
-	for _, tag := range tags {
-		currentTag := tag
-		group.Go(func() error {
-			tagKey := fmt.Sprintf(HazelcastTagPattern, currentTag)
-			tagValue, err := hzMap.Get(ctx, tagKey)
+// Delete removes data from Hazelcast for given key identifier, and dissociates it from its tags
+func (s *HazelcastStore) Delete(ctx context.Context, key any) error {
+	hzMap, err := s.mapProvider(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err = hzMap.Remove(ctx, key); err != nil {
+		return err
+	}
+	return s.removeTags(ctx, key)
+}
+
+// removeTags dissociates the given deleted key from its tags
+func (s *HazelcastStore) removeTags(ctx context.Context, key any) error {
+	if k, ok := key.(string); ok {
+		return s.tags.RemoveKeys(ctx, []string{k})
+	}
+	return nil
+}
+
+// KeysForTag returns the keys associated to the given tag
+func (s *HazelcastStore) KeysForTag(ctx context.Context, tag string) ([]string, error) {
+	return s.tags.Keys(ctx, tag)
+}
+
+// TagsForKey returns the tags associated to the given key
+func (s *HazelcastStore) TagsForKey(ctx context.Context, key any) ([]string, error) {
+	if k, ok := key.(string); ok {
+		return s.tags.KeyTags(ctx, k)
+	}
+
+	// Only string keys are associated to tags
+	return nil, nil
+}
+
+// PruneTags dissociates the keys which do not exist anymore, such as the expired or
+// evicted ones, from their tags
+func (s *HazelcastStore) PruneTags(ctx context.Context) error {
+	hzMap, err := s.mapProvider(ctx)
+	if err != nil {
+		return err
+	}
+	return s.tags.Prune(ctx, func(keys []string) ([]string, error) {
+		var missing []string
+		for _, key := range keys {
+			exists, err := hzMap.ContainsKey(ctx, key)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			if tagValue == nil {
-				return hzMap.SetWithTTL(ctx, tagKey, key.(string), TagKeyExpiry)
+			if !exists {
+				missing = append(missing, key)
 			}
-			cacheKeys := strings.Split(tagValue.(string), ",")
-			for _, cacheKey := range cacheKeys {
-				if key == cacheKey {
-					return nil
-				}
+		}
+		return missing, nil
+	})
+}
+
+// Increment atomically adds delta to the counter stored at the given key
+func (s *HazelcastStore) Increment(ctx context.Context, key any, delta int64) (int64, error) {
+	hzMap, err := s.mapProvider(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		value, err := hzMap.Get(ctx, key)
+		if err != nil {
+			return 0, err
+		}
+
+		if value == nil {
+			previous, err := hzMap.PutIfAbsentWithTTL(ctx, key, delta, s.options.Expiration)
+			if err != nil {
+				return 0, err
 			}
-			cacheKeys = append(cacheKeys, key.(string))
-			newTagValue := strings.Join(cacheKeys, ",")
-			return hzMap.SetWithTTL(ctx, tagKey, newTagValue, TagKeyExpiry)
-		})
+			if previous == nil {
+				return delta, nil
+			}
+			continue
+		}
+
+		counter, err := lib_store.ToInt64(value)
+		if err != nil {
+			return 0, err
+		}
+
+		swapped, err := hzMap.ReplaceIfSame(ctx, key, value, counter+delta)
+		if err != nil {
+			return 0, err
+		}
+		if swapped {
+			return counter + delta, nil
+		}
+		// the counter has been updated meanwhile, retry with its new value
 	}
-	group.Wait()
 }
 
-// Delete removes data from Hazelcast for given key identifier
-func (s *HazelcastStore) Delete(ctx context.Context, key any) error {
+// Decrement atomically subtracts delta from the counter stored at the given key
+func (s *HazelcastStore) Decrement(ctx context.Context, key any, delta int64) (int64, error) {
+	return s.Increment(ctx, key, -delta)
+}
+
+// SetIfNotExists defines data in Hazelcast only if the given key does not exist yet
+func (s *HazelcastStore) SetIfNotExists(ctx context.Context, key any, value any, options ...lib_store.Option) (bool, error) {
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
 	hzMap, err := s.mapProvider(ctx)
 	if err != nil {
-		return err
+		return false, err
 	}
-	_, err = hzMap.Remove(ctx, key)
-	return err
+	previous, err := hzMap.PutIfAbsentWithTTL(ctx, key, value, opts.Expiration)
+	if err != nil || previous != nil {
+		return false, err
+	}
+	if tags := opts.Tags; len(tags) > 0 {
+		return true, s.tags.Add(ctx, key.(string), tags)
+	}
+	return true, nil
+}
+
+// CompareAndSwap replaces data in Hazelcast only if the current value equals the old one
+func (s *HazelcastStore) CompareAndSwap(ctx context.Context, key any, oldValue any, newValue any, options ...lib_store.Option) (bool, error) {
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	hzMap, err := s.mapProvider(ctx)
+	if err != nil {
+		return false, err
+	}
+	swapped, err := hzMap.ReplaceIfSame(ctx, key, oldValue, newValue)
+	if err != nil || !swapped {
+		return false, err
+	}
+	if opts.Expiration > 0 {
+		if err = hzMap.SetTTL(ctx, key, opts.Expiration); err != nil {
+			return false, err
+		}
+	}
+	if tags := opts.Tags; len(tags) > 0 {
+		return true, s.tags.Add(ctx, key.(string), tags)
+	}
+	return true, nil
+}
+
+// CompareAndDelete removes data from Hazelcast only if the current value equals the old one
+func (s *HazelcastStore) CompareAndDelete(ctx context.Context, key any, oldValue any) (bool, error) {
+	hzMap, err := s.mapProvider(ctx)
+	if err != nil {
+		return false, err
+	}
+	deleted, err := hzMap.RemoveIfSame(ctx, key, oldValue)
+	if err != nil || !deleted {
+		return false, err
+	}
+	return true, s.removeTags(ctx, key)
 }
 
 // Invalidate invalidates some cache data in Hazelcast for given options
 func (s *HazelcastStore) Invalidate(ctx context.Context, options ...lib_store.InvalidateOption) error {
 	opts := lib_store.ApplyInvalidateOptions(options...)
+	if opts.TargetsKeys() {
+		return lib_store.NotSupportedOperation(HazelcastType, "invalidate by prefix or pattern")
+	}
+
 	if tags := opts.Tags; len(tags) > 0 {
 		hzMap, err := s.mapProvider(ctx)
 		if err != nil {
 			return err
 		}
 		for _, tag := range tags {
-			tagKey := fmt.Sprintf(HazelcastTagPattern, tag)
-			tagValue, err := hzMap.Get(ctx, tagKey)
-			if err != nil || tagValue == nil {
-				continue
+			cacheKeys, err := s.tags.Keys(ctx, tag)
+			if err != nil {
+				return err
 			}
-			cacheKeys := strings.Split(tagValue.(string), ",")
 			for _, cacheKey := range cacheKeys {
 				hzMap.Remove(ctx, cacheKey)
 			}
-			hzMap.Remove(ctx, tagKey)
+			if err := s.tags.Remove(ctx, tag, cacheKeys); err != nil {
+				return err
+			}
+			// Also dissociate the deleted keys from their other tags
+			if err := s.tags.RemoveKeys(ctx, cacheKeys); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -182,3 +391,8 @@ func (s *HazelcastStore) Clear(ctx context.Context) error {
 func (s *HazelcastStore) GetType() string {
 	return HazelcastType
 }
+
+// GetDefaultExpiration returns the expiration applied to the values written without one
+func (s *HazelcastStore) GetDefaultExpiration() time.Duration {
+	return s.options.Expiration
+}
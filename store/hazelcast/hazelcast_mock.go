@@ -50,6 +50,21 @@ func (mr *MockHazelcastMapInterfaceMockRecorder) Clear(ctx interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockHazelcastMapInterface)(nil).Clear), ctx)
 }
 
+// ContainsKey mocks base method.
+func (m *MockHazelcastMapInterface) ContainsKey(ctx context.Context, key interface{}) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContainsKey", ctx, key)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ContainsKey indicates an expected call of ContainsKey.
+func (mr *MockHazelcastMapInterfaceMockRecorder) ContainsKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContainsKey", reflect.TypeOf((*MockHazelcastMapInterface)(nil).ContainsKey), ctx, key)
+}
+
 // Get mocks base method.
 func (m *MockHazelcastMapInterface) Get(ctx context.Context, key any) (any, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +95,21 @@ func (mr *MockHazelcastMapInterfaceMockRecorder) GetEntryView(ctx, key interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntryView", reflect.TypeOf((*MockHazelcastMapInterface)(nil).GetEntryView), ctx, key)
 }
 
+// PutIfAbsentWithTTL mocks base method.
+func (m *MockHazelcastMapInterface) PutIfAbsentWithTTL(ctx context.Context, key, value any, ttl time.Duration) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutIfAbsentWithTTL", ctx, key, value, ttl)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutIfAbsentWithTTL indicates an expected call of PutIfAbsentWithTTL.
+func (mr *MockHazelcastMapInterfaceMockRecorder) PutIfAbsentWithTTL(ctx, key, value, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutIfAbsentWithTTL", reflect.TypeOf((*MockHazelcastMapInterface)(nil).PutIfAbsentWithTTL), ctx, key, value, ttl)
+}
+
 // Remove mocks base method.
 func (m *MockHazelcastMapInterface) Remove(ctx context.Context, key any) (any, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +125,50 @@ func (mr *MockHazelcastMapInterfaceMockRecorder) Remove(ctx, key interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockHazelcastMapInterface)(nil).Remove), ctx, key)
 }
 
+// RemoveIfSame mocks base method.
+func (m *MockHazelcastMapInterface) RemoveIfSame(ctx context.Context, key, value any) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveIfSame", ctx, key, value)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveIfSame indicates an expected call of RemoveIfSame.
+func (mr *MockHazelcastMapInterfaceMockRecorder) RemoveIfSame(ctx, key, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveIfSame", reflect.TypeOf((*MockHazelcastMapInterface)(nil).RemoveIfSame), ctx, key, value)
+}
+
+// ReplaceIfSame mocks base method.
+func (m *MockHazelcastMapInterface) ReplaceIfSame(ctx context.Context, key, oldValue, newValue any) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceIfSame", ctx, key, oldValue, newValue)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceIfSame indicates an expected call of ReplaceIfSame.
+func (mr *MockHazelcastMapInterfaceMockRecorder) ReplaceIfSame(ctx, key, oldValue, newValue interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceIfSame", reflect.TypeOf((*MockHazelcastMapInterface)(nil).ReplaceIfSame), ctx, key, oldValue, newValue)
+}
+
+// SetTTL mocks base method.
+func (m *MockHazelcastMapInterface) SetTTL(ctx context.Context, key any, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTTL", ctx, key, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTTL indicates an expected call of SetTTL.
+func (mr *MockHazelcastMapInterfaceMockRecorder) SetTTL(ctx, key, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTTL", reflect.TypeOf((*MockHazelcastMapInterface)(nil).SetTTL), ctx, key, ttl)
+}
+
 // SetWithTTL mocks base method.
 func (m *MockHazelcastMapInterface) SetWithTTL(ctx context.Context, key, value any, ttl time.Duration) error {
 	m.ctrl.T.Helper()
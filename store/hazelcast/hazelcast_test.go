@@ -2,6 +2,7 @@ package hazelcast
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -96,8 +97,95 @@ func TestHazelcastSetWithTags(t *testing.T) {
 
 	hzMap := NewMockHazelcastMapInterface(ctrl)
 	hzMap.EXPECT().SetWithTTL(ctx, cacheKey, cacheValue, time.Duration(0)).Return(nil)
-	hzMap.EXPECT().SetWithTTL(gomock.Any(), "gocache_tag_tag1", cacheKey, TagKeyExpiry).Return(nil)
-	hzMap.EXPECT().Get(gomock.Any(), "gocache_tag_tag1").Return(nil, nil)
+	hzMap.EXPECT().Get(ctx, "gocache_tag_tag1").Return(nil, nil)
+	hzMap.EXPECT().PutIfAbsentWithTTL(ctx, "gocache_tag_tag1", []byte("\x00\x01+\x06my-key"), TagKeyExpiry).Return(nil, nil)
+	hzMap.EXPECT().Get(ctx, "gocache_key_tags_my-key").Return(nil, nil)
+	hzMap.EXPECT().PutIfAbsentWithTTL(ctx, "gocache_key_tags_my-key", []byte("\x00\x01+\x04tag1"), TagKeyExpiry).Return(nil, nil)
+
+	store := newHazelcast(hzMap)
+
+	// When
+	err := store.Set(ctx, cacheKey, cacheValue, lib_store.WithTags([]string{"tag1"}))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestHazelcastSetWithTagsWhenTagExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my,key"
+	cacheValue := "my-cache-value"
+
+	tagValue := []byte("\x00\x01+\x0ca-second-key")
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().SetWithTTL(ctx, cacheKey, cacheValue, time.Duration(0)).Return(nil)
+	hzMap.EXPECT().Get(ctx, "gocache_tag_tag1").Return(tagValue, nil)
+	hzMap.EXPECT().ReplaceIfSame(ctx, "gocache_tag_tag1", tagValue, []byte("\x00\x01+\x0ca-second-key+\x06my,key")).Return(true, nil)
+	hzMap.EXPECT().SetTTL(ctx, "gocache_tag_tag1", TagKeyExpiry).Return(nil)
+	hzMap.EXPECT().Get(ctx, "gocache_key_tags_my,key").Return(nil, nil)
+	hzMap.EXPECT().PutIfAbsentWithTTL(ctx, "gocache_key_tags_my,key", []byte("\x00\x01+\x04tag1"), TagKeyExpiry).Return(nil, nil)
+
+	store := newHazelcast(hzMap)
+
+	// When
+	err := store.Set(ctx, cacheKey, cacheValue, lib_store.WithTags([]string{"tag1"}))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestHazelcastSetWithTagsWhenUpdatedConcurrently(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+	cacheValue := "my-cache-value"
+
+	tagValue := []byte("\x00\x01+\x0ca-second-key")
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().SetWithTTL(ctx, cacheKey, cacheValue, time.Duration(0)).Return(nil)
+	gomock.InOrder(
+		hzMap.EXPECT().Get(ctx, "gocache_tag_tag1").Return(nil, nil),
+		hzMap.EXPECT().PutIfAbsentWithTTL(ctx, "gocache_tag_tag1", []byte("\x00\x01+\x06my-key"), TagKeyExpiry).Return(tagValue, nil),
+		hzMap.EXPECT().Get(ctx, "gocache_tag_tag1").Return(tagValue, nil),
+		hzMap.EXPECT().ReplaceIfSame(ctx, "gocache_tag_tag1", tagValue, []byte("\x00\x01+\x0ca-second-key+\x06my-key")).Return(true, nil),
+		hzMap.EXPECT().SetTTL(ctx, "gocache_tag_tag1", TagKeyExpiry).Return(nil),
+		hzMap.EXPECT().Get(ctx, "gocache_key_tags_my-key").Return(nil, nil),
+		hzMap.EXPECT().PutIfAbsentWithTTL(ctx, "gocache_key_tags_my-key", []byte("\x00\x01+\x04tag1"), TagKeyExpiry).Return(nil, nil),
+	)
+
+	store := newHazelcast(hzMap)
+
+	// When
+	err := store.Set(ctx, cacheKey, cacheValue, lib_store.WithTags([]string{"tag1"}))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestHazelcastSetWithTagsWhenAlreadyInserted(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+	cacheValue := "my-cache-value"
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().SetWithTTL(ctx, cacheKey, cacheValue, time.Duration(0)).Return(nil)
+	hzMap.EXPECT().Get(ctx, "gocache_tag_tag1").Return([]byte("\x00\x01+\x06my-key"), nil)
+	hzMap.EXPECT().SetTTL(ctx, "gocache_tag_tag1", TagKeyExpiry).Return(nil)
+	hzMap.EXPECT().Get(ctx, "gocache_key_tags_my-key").Return([]byte("\x00\x01+\x04tag1"), nil)
+	hzMap.EXPECT().SetTTL(ctx, "gocache_key_tags_my-key", TagKeyExpiry).Return(nil)
 
 	store := newHazelcast(hzMap)
 
@@ -118,16 +206,221 @@ func TestHazelcastDelete(t *testing.T) {
 
 	hzMap := NewMockHazelcastMapInterface(ctrl)
 	hzMap.EXPECT().Remove(ctx, "my-key").Return(0, nil)
+	hzMap.EXPECT().Get(ctx, "gocache_key_tags_my-key").Return(nil, nil)
+	hzMap.EXPECT().Remove(ctx, "gocache_key_tags_my-key").Return(nil, nil)
+
+	store := newHazelcast(hzMap)
+
+	// When
+	err := store.Delete(ctx, cacheKey)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestHazelcastDeleteWithTags(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+
+	tagValue := []byte("\x00\x01+\x06my-key+\x0ca-second-key")
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().Remove(ctx, "my-key").Return("my-value", nil)
+	hzMap.EXPECT().Get(ctx, "gocache_key_tags_my-key").Return([]byte("\x00\x01+\x04tag1"), nil)
+	hzMap.EXPECT().Get(ctx, "gocache_tag_tag1").Return(tagValue, nil)
+	hzMap.EXPECT().ReplaceIfSame(ctx, "gocache_tag_tag1", tagValue, []byte("\x00\x01+\x06my-key+\x0ca-second-key-\x06my-key")).Return(true, nil)
+	hzMap.EXPECT().SetTTL(ctx, "gocache_tag_tag1", TagKeyExpiry).Return(nil)
+	hzMap.EXPECT().Remove(ctx, "gocache_key_tags_my-key").Return([]byte("\x00\x01+\x04tag1"), nil)
 
 	store := newHazelcast(hzMap)
 
 	// When
 	err := store.Delete(ctx, cacheKey)
 
+	// Then the key is dissociated from its tags
+	assert.Nil(t, err)
+}
+
+func TestHazelcastKeysForTag(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().Get(ctx, "gocache_tag_tag1").Return("\x00\x01+\x0ca-second-key+\x06my-key", nil)
+	hzMap.EXPECT().Get(ctx, "gocache_key_tags_my-key").Return([]byte("\x00\x01+\x04tag1"), nil)
+
+	store := newHazelcast(hzMap)
+
+	// When
+	keys, keysErr := store.KeysForTag(ctx, "tag1")
+	tags, tagsErr := store.TagsForKey(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, keysErr)
+	assert.Equal(t, []string{"a-second-key", "my-key"}, keys)
+	assert.Nil(t, tagsErr)
+	assert.Equal(t, []string{"tag1"}, tags)
+}
+
+func TestHazelcastPruneTags(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().SetWithTTL(ctx, "my-key", "my-value", time.Duration(0)).Return(nil)
+	hzMap.EXPECT().Get(ctx, "gocache_tag_tag1").Return(nil, nil)
+	hzMap.EXPECT().PutIfAbsentWithTTL(ctx, "gocache_tag_tag1", []byte("\x00\x01+\x06my-key"), TagKeyExpiry).Return(nil, nil)
+	hzMap.EXPECT().Get(ctx, "gocache_key_tags_my-key").Return(nil, nil)
+	hzMap.EXPECT().PutIfAbsentWithTTL(ctx, "gocache_key_tags_my-key", []byte("\x00\x01+\x04tag1"), TagKeyExpiry).Return(nil, nil)
+
+	store := newHazelcast(hzMap)
+	assert.Nil(t, store.Set(ctx, "my-key", "my-value", lib_store.WithTags([]string{"tag1"})))
+
+	// When the key has expired
+	tagValue := []byte("\x00\x01+\x06my-key")
+	hzMap.EXPECT().Get(ctx, "gocache_tag_tag1").Return(tagValue, nil).Times(2)
+	hzMap.EXPECT().ContainsKey(ctx, "my-key").Return(false, nil)
+	hzMap.EXPECT().ReplaceIfSame(ctx, "gocache_tag_tag1", tagValue, []byte("\x00\x01")).Return(true, nil)
+	hzMap.EXPECT().SetTTL(ctx, "gocache_tag_tag1", TagKeyExpiry).Return(nil)
+	hzMap.EXPECT().Remove(ctx, "gocache_key_tags_my-key").Return(nil, nil)
+
+	err := store.PruneTags(ctx)
+
 	// Then
 	assert.Nil(t, err)
 }
 
+func TestHazelcastIncrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	gomock.InOrder(
+		hzMap.EXPECT().Get(ctx, "my-counter").Return(int64(5), nil),
+		hzMap.EXPECT().ReplaceIfSame(ctx, "my-counter", int64(5), int64(7)).Return(false, nil),
+		hzMap.EXPECT().Get(ctx, "my-counter").Return(int64(6), nil),
+		hzMap.EXPECT().ReplaceIfSame(ctx, "my-counter", int64(6), int64(8)).Return(true, nil),
+	)
+
+	store := newHazelcast(hzMap)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(8), value)
+}
+
+func TestHazelcastDecrementWhenCounterIsMissing(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().Get(ctx, "my-counter").Return(nil, nil)
+	hzMap.EXPECT().PutIfAbsentWithTTL(ctx, "my-counter", int64(-2), 5*time.Second).Return(nil, nil)
+
+	store := newHazelcast(hzMap, lib_store.WithExpiration(5*time.Second))
+
+	// When
+	value, err := store.Decrement(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-2), value)
+}
+
+func TestHazelcastSetIfNotExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().PutIfAbsentWithTTL(ctx, "my-key", "my-value", 5*time.Second).Return(nil, nil)
+
+	store := newHazelcast(hzMap)
+
+	// When
+	set, err := store.SetIfNotExists(ctx, "my-key", "my-value", lib_store.WithExpiration(5*time.Second))
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, set)
+}
+
+func TestHazelcastSetIfNotExistsWhenKeyExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().PutIfAbsentWithTTL(ctx, "my-key", "my-value", 0*time.Second).Return("other-value", nil)
+
+	store := newHazelcast(hzMap)
+
+	// When
+	set, err := store.SetIfNotExists(ctx, "my-key", "my-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, set)
+}
+
+func TestHazelcastCompareAndSwap(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().ReplaceIfSame(ctx, "my-key", "old-value", "new-value").Return(true, nil)
+	hzMap.EXPECT().SetTTL(ctx, "my-key", 5*time.Second).Return(nil)
+
+	store := newHazelcast(hzMap, lib_store.WithExpiration(5*time.Second))
+
+	// When
+	swapped, err := store.CompareAndSwap(ctx, "my-key", "old-value", "new-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, swapped)
+}
+
+func TestHazelcastCompareAndDelete(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().RemoveIfSame(ctx, "my-key", "old-value").Return(true, nil)
+	hzMap.EXPECT().Get(ctx, "gocache_key_tags_my-key").Return(nil, nil)
+	hzMap.EXPECT().Remove(ctx, "gocache_key_tags_my-key").Return(nil, nil)
+
+	store := newHazelcast(hzMap)
+
+	// When
+	deleted, err := store.CompareAndDelete(ctx, "my-key", "old-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, deleted)
+}
+
 func TestHazelcastInvalidate(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -155,11 +448,16 @@ func TestHazelcastInvalidateWhenCacheKeysExist(t *testing.T) {
 	cacheKeys := "my-key0,my-key1,my-key2"
 
 	hzMap := NewMockHazelcastMapInterface(ctrl)
-	hzMap.EXPECT().Get(ctx, "gocache_tag_tag1").Return(cacheKeys, nil)
+	hzMap.EXPECT().Get(ctx, "gocache_tag_tag1").Return(cacheKeys, nil).Times(2)
 	hzMap.EXPECT().Remove(ctx, "my-key0").Return("my-value0", nil)
 	hzMap.EXPECT().Remove(ctx, "my-key1").Return("my-value1", nil)
 	hzMap.EXPECT().Remove(ctx, "my-key2").Return("my-value2", nil)
-	hzMap.EXPECT().Remove(ctx, "gocache_tag_tag1").Return(cacheKeys, nil)
+	hzMap.EXPECT().ReplaceIfSame(ctx, "gocache_tag_tag1", cacheKeys, []byte("\x00\x01")).Return(true, nil)
+	hzMap.EXPECT().SetTTL(ctx, "gocache_tag_tag1", TagKeyExpiry).Return(nil)
+	for _, key := range []string{"my-key0", "my-key1", "my-key2"} {
+		hzMap.EXPECT().Get(ctx, "gocache_key_tags_"+key).Return(nil, nil)
+		hzMap.EXPECT().Remove(ctx, "gocache_key_tags_"+key).Return(nil, nil)
+	}
 
 	store := newHazelcast(hzMap)
 
@@ -170,6 +468,23 @@ func TestHazelcastInvalidateWhenCacheKeysExist(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestHazelcastInvalidateWithPrefixWhenNotSupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+
+	store := newHazelcast(hzMap)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidatePrefix("tenant:17:"))
+
+	// Then
+	assert.True(t, errors.Is(err, &lib_store.NotSupported{}))
+}
+
 func TestHazelcastClear(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -199,3 +514,15 @@ func TestHazelcastType(t *testing.T) {
 	// When - Then
 	assert.Equal(t, HazelcastType, store.GetType())
 }
+
+func TestHazelcastGetDefaultExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	client := NewMockHazelcastMapInterface(ctrl)
+
+	store := newHazelcast(client, lib_store.WithExpiration(6*time.Second))
+
+	// When - Then
+	assert.Equal(t, 6*time.Second, store.GetDefaultExpiration())
+}
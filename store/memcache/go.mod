@@ -7,7 +7,6 @@ require (
 	github.com/eko/gocache/lib/v4 v4.1.3
 	github.com/golang/mock v1.6.0
 	github.com/stretchr/testify v1.8.1
-	golang.org/x/sync v0.1.0
 )
 
 require (
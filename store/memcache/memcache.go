@@ -1,14 +1,13 @@
 package memcache
 
 import (
+	"bytes"
 	"context"
 	"errors"
-	"fmt"
-	"strings"
+	"strconv"
 	"time"
 
 	lib_store "github.com/eko/gocache/lib/v4/store"
-	"golang.org/x/sync/errgroup"
 
 	"github.com/bradfitz/gomemcache/memcache"
 )
@@ -16,11 +15,15 @@ import (
 // MemcacheClientInterface represents a bradfitz/gomemcache client
 type MemcacheClientInterface interface {
 	Get(key string) (item *memcache.Item, err error)
+	GetMulti(keys []string) (map[string]*memcache.Item, error)
 	Set(item *memcache.Item) error
 	Delete(item string) error
 	FlushAll() error
 	CompareAndSwap(item *memcache.Item) error
 	Add(item *memcache.Item) error
+	Increment(key string, delta uint64) (newValue uint64, err error)
+	Decrement(key string, delta uint64) (newValue uint64, err error)
+	Touch(key string, seconds int32) error
 }
 
 const (
@@ -29,21 +32,85 @@ const (
 	// MemcacheTagPattern represents the tag pattern to be used as a key in specified storage
 	MemcacheTagPattern = "gocache_tag_%s"
 
-	TagKeyExpiry = 720 * time.Hour
+	// TagKeyExpiry is the default expiration of the tag index entries
+	TagKeyExpiry = lib_store.DefaultTagIndexTTL
 )
 
 // MemcacheStore is a store for Memcache
 type MemcacheStore struct {
 	client  MemcacheClientInterface
 	options *lib_store.Options
+	tags    *lib_store.TagIndex
 }
 
 // NewMemcache creates a new store to Memcache instance(s)
 func NewMemcache(client MemcacheClientInterface, options ...lib_store.Option) *MemcacheStore {
+	opts := lib_store.ApplyOptions(options...)
+	tagIndexOptions := append([]lib_store.TagIndexOption{lib_store.WithTagIndexTTL(TagKeyExpiry)}, opts.TagIndex...)
+
 	return &MemcacheStore{
 		client:  client,
-		options: lib_store.ApplyOptions(options...),
+		options: opts,
+		tags:    lib_store.NewTagIndex(&memcacheTagIndexStorage{client: client}, MemcacheTagPattern, tagIndexOptions...),
+	}
+}
+
+// memcacheTagIndexStorage keeps the tag index entries in Memcache, using the items
+// CAS identifiers to detect the entries updated concurrently by other clients
+type memcacheTagIndexStorage struct {
+	client MemcacheClientInterface
+}
+
+func (s *memcacheTagIndexStorage) Load(_ context.Context, key string) ([]byte, any, error) {
+	item, err := s.client.Get(key)
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return nil, nil, nil
+	}
+	if err != nil || item == nil {
+		return nil, nil, err
+	}
+
+	return item.Value, item, nil
+}
+
+func (s *memcacheTagIndexStorage) Save(_ context.Context, key string, data []byte, version any, ttl time.Duration) (bool, error) {
+	var err error
+
+	if item, ok := version.(*memcache.Item); ok {
+		item.Value = data
+		item.Expiration = int32(ttl.Seconds())
+		err = s.client.CompareAndSwap(item)
+	} else {
+		// Only create the entry if it has not been created meanwhile
+		err = s.client.Add(&memcache.Item{
+			Key:        key,
+			Value:      data,
+			Expiration: int32(ttl.Seconds()),
+		})
+	}
+
+	if errors.Is(err, memcache.ErrCASConflict) || errors.Is(err, memcache.ErrNotStored) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func (s *memcacheTagIndexStorage) Delete(_ context.Context, key string) error {
+	if err := s.client.Delete(key); !errors.Is(err, memcache.ErrCacheMiss) {
+		return err
 	}
+
+	return nil
+}
+
+func (s *memcacheTagIndexStorage) Touch(_ context.Context, key string, ttl time.Duration) error {
+	err := s.client.Touch(key, int32(ttl.Seconds()))
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return nil
+	}
+
+	return err
 }
 
 // Get returns data stored from a given key
@@ -88,99 +155,261 @@ func (s *MemcacheStore) Set(ctx context.Context, key any, value any, options ...
 	}
 
 	if tags := opts.Tags; len(tags) > 0 {
-		s.setTags(ctx, key, tags)
+		return s.tags.Add(ctx, key.(string), tags)
 	}
 
 	return nil
 }
 
-func (s *MemcacheStore) setTags(ctx context.Context, key any, tags []string) {
-	group, ctx := errgroup.WithContext(ctx)
-	for _, tag := range tags {
-		currentTag := tag
-		group.Go(func() error {
-			tagKey := fmt.Sprintf(MemcacheTagPattern, currentTag)
+// Delete removes data from Memcache for given key identifier, and dissociates it from its tags
+func (s *MemcacheStore) Delete(ctx context.Context, key any) error {
+	err := s.client.Delete(key.(string))
+	if err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+		return err
+	}
+
+	// Expired items are also dissociated from their tags
+	if err := s.tags.RemoveKeys(ctx, []string{key.(string)}); err != nil {
+		return err
+	}
 
-			var err error
-			for i := 0; i < 3; i++ {
-				if err = s.addKeyToTagValue(tagKey, key); err == nil {
-					return nil
-				}
-				// loop to retry any failure (including race conditions)
+	return err
+}
+
+// KeysForTag returns the keys associated to the given tag
+func (s *MemcacheStore) KeysForTag(ctx context.Context, tag string) ([]string, error) {
+	return s.tags.Keys(ctx, tag)
+}
+
+// TagsForKey returns the tags associated to the given key
+func (s *MemcacheStore) TagsForKey(ctx context.Context, key any) ([]string, error) {
+	return s.tags.KeyTags(ctx, key.(string))
+}
+
+// PruneTags dissociates the keys which do not exist anymore, such as the expired or
+// evicted ones, from their tags
+func (s *MemcacheStore) PruneTags(ctx context.Context) error {
+	return s.tags.Prune(ctx, func(keys []string) ([]string, error) {
+		items, err := s.client.GetMulti(keys)
+		if err != nil {
+			return nil, err
+		}
+
+		var missing []string
+		for _, key := range keys {
+			if _, ok := items[key]; !ok {
+				missing = append(missing, key)
 			}
+		}
+
+		return missing, nil
+	})
+}
 
+// GetMany returns data stored from given keys in a single round trip,
+// keys that cannot be found are absent from the returned map
+func (s *MemcacheStore) GetMany(_ context.Context, keys []any) (map[any]any, error) {
+	cacheKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		cacheKeys = append(cacheKeys, key.(string))
+	}
+
+	items, err := s.client.GetMulti(cacheKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make(map[any]any, len(items))
+	for _, key := range keys {
+		if item, ok := items[key.(string)]; ok && item != nil {
+			objects[key] = item.Value
+		}
+	}
+
+	return objects, nil
+}
+
+// SetMany defines data in Memcache for given key identifiers
+func (s *MemcacheStore) SetMany(ctx context.Context, items map[any]any, options ...lib_store.Option) error {
+	for key, value := range items {
+		if err := s.Set(ctx, key, value, options...); err != nil {
 			return err
-		})
+		}
 	}
 
-	group.Wait()
+	return nil
 }
 
-func (s *MemcacheStore) addKeyToTagValue(tagKey string, key any) error {
-	var (
-		cacheKeys = []string{}
-		result    *memcache.Item
-		err       error
-	)
+// DeleteMany removes data from Memcache for given key identifiers
+func (s *MemcacheStore) DeleteMany(ctx context.Context, keys []any) error {
+	for _, key := range keys {
+		if err := s.Delete(ctx, key); err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+			return err
+		}
+	}
 
-	result, err = s.client.Get(tagKey)
-	if err == nil {
-		cacheKeys = strings.Split(string(result.Value), ",")
-	} else if !errors.Is(err, memcache.ErrCacheMiss) {
-		return err
+	return nil
+}
+
+// Increment atomically adds delta to the counter stored at the given key.
+// A negative delta decrements the counter, which cannot go below zero in Memcache.
+func (s *MemcacheStore) Increment(ctx context.Context, key any, delta int64) (int64, error) {
+	if delta < 0 {
+		return s.Decrement(ctx, key, -delta)
 	}
 
-	for _, cacheKey := range cacheKeys {
-		// if key already exists, nothing to do
-		if cacheKey == key.(string) {
-			return nil
-		}
+	return s.updateCounter(key.(string), uint64(delta), delta, s.client.Increment)
+}
+
+// Decrement atomically subtracts delta from the counter stored at the given key.
+// Memcache counters cannot go below zero so the value is floored at zero.
+func (s *MemcacheStore) Decrement(ctx context.Context, key any, delta int64) (int64, error) {
+	if delta < 0 {
+		return s.Increment(ctx, key, -delta)
 	}
 
-	cacheKeys = append(cacheKeys, key.(string))
+	return s.updateCounter(key.(string), uint64(delta), 0, s.client.Decrement)
+}
 
-	newVal := []byte(strings.Join(cacheKeys, ","))
+// updateCounter applies the given update to the counter, creating it with the
+// initial value when it does not exist yet
+func (s *MemcacheStore) updateCounter(key string, delta uint64, initial int64, update func(key string, delta uint64) (uint64, error)) (int64, error) {
+	for {
+		value, err := update(key, delta)
+		if err == nil {
+			return int64(value), nil
+		}
+		if !errors.Is(err, memcache.ErrCacheMiss) {
+			return 0, err
+		}
 
-	if result == nil {
-		// if key didnt exist, use Add to create only if still not there
-		return s.client.Add(&memcache.Item{
-			Key:        tagKey,
-			Value:      newVal,
-			Expiration: int32(TagKeyExpiry.Seconds()),
+		err = s.client.Add(&memcache.Item{
+			Key:        key,
+			Value:      []byte(strconv.FormatInt(initial, 10)),
+			Expiration: int32(s.options.Expiration.Seconds()),
 		})
+		if err == nil {
+			return initial, nil
+		}
+		if !errors.Is(err, memcache.ErrNotStored) {
+			return 0, err
+		}
+		// the counter has been created meanwhile, retry to update it
+	}
+}
+
+// SetIfNotExists defines data in Memcache only if the given key does not exist yet.
+// Values can be given as bytes or strings.
+func (s *MemcacheStore) SetIfNotExists(ctx context.Context, key any, value any, options ...lib_store.Option) (bool, error) {
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+
+	err := s.client.Add(&memcache.Item{
+		Key:        key.(string),
+		Value:      toBytes(value),
+		Expiration: int32(opts.Expiration.Seconds()),
+	})
+	if errors.Is(err, memcache.ErrNotStored) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if tags := opts.Tags; len(tags) > 0 {
+		return true, s.tags.Add(ctx, key.(string), tags)
+	}
+
+	return true, nil
+}
+
+// CompareAndSwap replaces data in Memcache only if the current value equals the old one
+func (s *MemcacheStore) CompareAndSwap(ctx context.Context, key any, oldValue any, newValue any, options ...lib_store.Option) (bool, error) {
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+
+	swapped, err := s.compareAndSwap(key.(string), toBytes(oldValue), toBytes(newValue), int32(opts.Expiration.Seconds()))
+	if err != nil || !swapped {
+		return false, err
 	}
 
-	// update existing value
-	// using CompareAndSwap to ensure not to run over writes between Get and here
-	result.Value = newVal
-	result.Expiration = int32(TagKeyExpiry.Seconds())
-	return s.client.CompareAndSwap(result)
+	if tags := opts.Tags; len(tags) > 0 {
+		return true, s.tags.Add(ctx, key.(string), tags)
+	}
+
+	return true, nil
 }
 
-// Delete removes data from Memcache for given key identifier
-func (s *MemcacheStore) Delete(_ context.Context, key any) error {
-	return s.client.Delete(key.(string))
+// CompareAndDelete removes data from Memcache only if the current value equals the old one.
+// Memcache has no conditional delete so the item is swapped with an already expired one.
+func (s *MemcacheStore) CompareAndDelete(ctx context.Context, key any, oldValue any) (bool, error) {
+	deleted, err := s.compareAndSwap(key.(string), toBytes(oldValue), toBytes(oldValue), -1)
+	if err != nil || !deleted {
+		return false, err
+	}
+
+	return true, s.tags.RemoveKeys(ctx, []string{key.(string)})
+}
+
+func (s *MemcacheStore) compareAndSwap(key string, oldValue []byte, newValue []byte, expiration int32) (bool, error) {
+	item, err := s.client.Get(key)
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if item == nil || !bytes.Equal(item.Value, oldValue) {
+		return false, nil
+	}
+
+	item.Value = newValue
+	item.Expiration = expiration
+
+	err = s.client.CompareAndSwap(item)
+	if errors.Is(err, memcache.ErrCASConflict) || errors.Is(err, memcache.ErrNotStored) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// toBytes returns the bytes of a value given as bytes or string
+func toBytes(value any) []byte {
+	if str, ok := value.(string); ok {
+		return []byte(str)
+	}
+
+	return value.([]byte)
 }
 
 // Invalidate invalidates some cache data in Memcache for given options
 func (s *MemcacheStore) Invalidate(ctx context.Context, options ...lib_store.InvalidateOption) error {
 	opts := lib_store.ApplyInvalidateOptions(options...)
 
+	if opts.TargetsKeys() {
+		return lib_store.NotSupportedOperation(MemcacheType, "invalidate by prefix or pattern")
+	}
+
 	if tags := opts.Tags; len(tags) > 0 {
 		for _, tag := range tags {
-			tagKey := fmt.Sprintf(MemcacheTagPattern, tag)
-			result, err := s.Get(ctx, tagKey)
+			cacheKeys, err := s.tags.Keys(ctx, tag)
 			if err != nil {
-				return nil
+				return err
 			}
 
-			cacheKeys := []string{}
-			if bytes, ok := result.([]byte); ok {
-				cacheKeys = strings.Split(string(bytes), ",")
+			for _, cacheKey := range cacheKeys {
+				s.client.Delete(cacheKey)
 			}
 
-			for _, cacheKey := range cacheKeys {
-				s.Delete(ctx, cacheKey)
+			if err := s.tags.Remove(ctx, tag, cacheKeys); err != nil {
+				return err
+			}
+
+			// Also dissociate the deleted keys from their other tags
+			if err := s.tags.RemoveKeys(ctx, cacheKeys); err != nil {
+				return err
 			}
 		}
 	}
@@ -197,3 +426,8 @@ func (s *MemcacheStore) Clear(_ context.Context) error {
 func (s *MemcacheStore) GetType() string {
 	return MemcacheType
 }
+
+// GetDefaultExpiration returns the expiration applied to the values written without one
+func (s *MemcacheStore) GetDefaultExpiration() time.Duration {
+	return s.options.Expiration
+}
@@ -62,6 +62,21 @@ func (mr *MockMemcacheClientInterfaceMockRecorder) CompareAndSwap(item interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareAndSwap", reflect.TypeOf((*MockMemcacheClientInterface)(nil).CompareAndSwap), item)
 }
 
+// Decrement mocks base method.
+func (m *MockMemcacheClientInterface) Decrement(key string, delta uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decrement", key, delta)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decrement indicates an expected call of Decrement.
+func (mr *MockMemcacheClientInterfaceMockRecorder) Decrement(key, delta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrement", reflect.TypeOf((*MockMemcacheClientInterface)(nil).Decrement), key, delta)
+}
+
 // Delete mocks base method.
 func (m *MockMemcacheClientInterface) Delete(item string) error {
 	m.ctrl.T.Helper()
@@ -105,6 +120,36 @@ func (mr *MockMemcacheClientInterfaceMockRecorder) Get(key interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMemcacheClientInterface)(nil).Get), key)
 }
 
+// GetMulti mocks base method.
+func (m *MockMemcacheClientInterface) GetMulti(keys []string) (map[string]*memcache.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMulti", keys)
+	ret0, _ := ret[0].(map[string]*memcache.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMulti indicates an expected call of GetMulti.
+func (mr *MockMemcacheClientInterfaceMockRecorder) GetMulti(keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMulti", reflect.TypeOf((*MockMemcacheClientInterface)(nil).GetMulti), keys)
+}
+
+// Increment mocks base method.
+func (m *MockMemcacheClientInterface) Increment(key string, delta uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Increment", key, delta)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Increment indicates an expected call of Increment.
+func (mr *MockMemcacheClientInterfaceMockRecorder) Increment(key, delta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Increment", reflect.TypeOf((*MockMemcacheClientInterface)(nil).Increment), key, delta)
+}
+
 // Set mocks base method.
 func (m *MockMemcacheClientInterface) Set(item *memcache.Item) error {
 	m.ctrl.T.Helper()
@@ -118,3 +163,17 @@ func (mr *MockMemcacheClientInterfaceMockRecorder) Set(item interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockMemcacheClientInterface)(nil).Set), item)
 }
+
+// Touch mocks base method.
+func (m *MockMemcacheClientInterface) Touch(key string, seconds int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", key, seconds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockMemcacheClientInterfaceMockRecorder) Touch(key, seconds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockMemcacheClientInterface)(nil).Touch), key, seconds)
+}
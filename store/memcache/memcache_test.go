@@ -239,9 +239,61 @@ func TestMemcacheSetWithTags(t *testing.T) {
 	client.EXPECT().Get(tagKey).Return(nil, memcache.ErrCacheMiss)
 	client.EXPECT().Add(&memcache.Item{
 		Key:        tagKey,
-		Value:      []byte(cacheKey),
+		Value:      []byte("\x00\x01+\x06my-key"),
 		Expiration: int32(TagKeyExpiry.Seconds()),
 	}).Return(nil)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return(nil, memcache.ErrCacheMiss)
+	client.EXPECT().Add(&memcache.Item{
+		Key:        "gocache_key_tags_my-key",
+		Value:      []byte("\x00\x01+\x04tag1"),
+		Expiration: int32(TagKeyExpiry.Seconds()),
+	}).Return(nil)
+
+	store := NewMemcache(client)
+
+	// When
+	err := store.Set(ctx, cacheKey, cacheValue, lib_store.WithTags([]string{"tag1"}))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestMemcacheSetWithTagsWhenUpdatedConcurrently(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my,key"
+	cacheValue := []byte("my-cache-value")
+
+	tagKey := "gocache_tag_tag1"
+
+	client := NewMockMemcacheClientInterface(ctrl)
+	client.EXPECT().Set(gomock.Any()).Return(nil)
+	gomock.InOrder(
+		client.EXPECT().Get(tagKey).Return(nil, memcache.ErrCacheMiss),
+		client.EXPECT().Add(&memcache.Item{
+			Key:        tagKey,
+			Value:      []byte("\x00\x01+\x06my,key"),
+			Expiration: int32(TagKeyExpiry.Seconds()),
+		}).Return(memcache.ErrNotStored),
+		client.EXPECT().Get(tagKey).Return(&memcache.Item{
+			Key:   tagKey,
+			Value: []byte("\x00\x01+\x0ca-second-key"),
+		}, nil),
+		client.EXPECT().CompareAndSwap(&memcache.Item{
+			Key:        tagKey,
+			Value:      []byte("\x00\x01+\x0ca-second-key+\x06my,key"),
+			Expiration: int32(TagKeyExpiry.Seconds()),
+		}).Return(nil),
+		client.EXPECT().Get("gocache_key_tags_my,key").Return(nil, memcache.ErrCacheMiss),
+		client.EXPECT().Add(&memcache.Item{
+			Key:        "gocache_key_tags_my,key",
+			Value:      []byte("\x00\x01+\x04tag1"),
+			Expiration: int32(TagKeyExpiry.Seconds()),
+		}).Return(nil),
+	)
 
 	store := NewMemcache(client)
 
@@ -252,6 +304,27 @@ func TestMemcacheSetWithTags(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestMemcacheSetWithTagsWhenTagIndexError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := errors.New("unexpected error")
+
+	client := NewMockMemcacheClientInterface(ctrl)
+	client.EXPECT().Set(gomock.Any()).Return(nil)
+	client.EXPECT().Get("gocache_tag_tag1").Return(nil, expectedErr)
+
+	store := NewMemcache(client)
+
+	// When
+	err := store.Set(ctx, "my-key", []byte("my-cache-value"), lib_store.WithTags([]string{"tag1"}))
+
+	// Then
+	assert.Equal(t, expectedErr, err)
+}
+
 func TestMemcacheSetWithTagsWhenAlreadyInserted(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -264,8 +337,13 @@ func TestMemcacheSetWithTagsWhenAlreadyInserted(t *testing.T) {
 	client := NewMockMemcacheClientInterface(ctrl)
 	client.EXPECT().Set(gomock.Any()).AnyTimes().Return(nil)
 	client.EXPECT().Get("gocache_tag_tag1").Return(&memcache.Item{
-		Value: []byte("my-key,a-second-key"),
+		Value: []byte("\x00\x01+\x06my-key+\x0ca-second-key"),
+	}, nil)
+	client.EXPECT().Touch("gocache_tag_tag1", int32(TagKeyExpiry.Seconds())).Return(nil)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return(&memcache.Item{
+		Value: []byte("\x00\x01+\x04tag1"),
 	}, nil)
+	client.EXPECT().Touch("gocache_key_tags_my-key", int32(TagKeyExpiry.Seconds())).Return(nil)
 
 	store := NewMemcache(client)
 
@@ -286,6 +364,8 @@ func TestMemcacheDelete(t *testing.T) {
 
 	client := NewMockMemcacheClientInterface(ctrl)
 	client.EXPECT().Delete(cacheKey).Return(nil)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return(nil, memcache.ErrCacheMiss)
+	client.EXPECT().Delete("gocache_key_tags_my-key").Return(memcache.ErrCacheMiss)
 
 	store := NewMemcache(client)
 
@@ -318,6 +398,407 @@ func TestMemcacheDeleteWhenError(t *testing.T) {
 	assert.Equal(t, expectedErr, err)
 }
 
+func TestMemcacheDeleteWithTags(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+
+	client := NewMockMemcacheClientInterface(ctrl)
+	client.EXPECT().Delete(cacheKey).Return(nil)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return(&memcache.Item{
+		Key:   "gocache_key_tags_my-key",
+		Value: []byte("\x00\x01+\x04tag1"),
+	}, nil)
+	client.EXPECT().Get("gocache_tag_tag1").Return(&memcache.Item{
+		Key:   "gocache_tag_tag1",
+		Value: []byte("\x00\x01+\x06my-key+\x0ca-second-key"),
+	}, nil)
+	client.EXPECT().CompareAndSwap(&memcache.Item{
+		Key:        "gocache_tag_tag1",
+		Value:      []byte("\x00\x01+\x06my-key+\x0ca-second-key-\x06my-key"),
+		Expiration: int32(TagKeyExpiry.Seconds()),
+	}).Return(nil)
+	client.EXPECT().Delete("gocache_key_tags_my-key").Return(nil)
+
+	store := NewMemcache(client)
+
+	// When
+	err := store.Delete(ctx, cacheKey)
+
+	// Then the key is dissociated from its tags
+	assert.Nil(t, err)
+}
+
+func TestMemcacheKeysForTag(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(ctrl)
+	client.EXPECT().Get("gocache_tag_tag1").Return(&memcache.Item{
+		Key:   "gocache_tag_tag1",
+		Value: []byte("\x00\x01+\x0ca-second-key+\x06my-key"),
+	}, nil)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return(&memcache.Item{
+		Key:   "gocache_key_tags_my-key",
+		Value: []byte("\x00\x01+\x04tag1+\x04tag2"),
+	}, nil)
+
+	store := NewMemcache(client)
+
+	// When
+	keys, keysErr := store.KeysForTag(ctx, "tag1")
+	tags, tagsErr := store.TagsForKey(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, keysErr)
+	assert.Equal(t, []string{"a-second-key", "my-key"}, keys)
+	assert.Nil(t, tagsErr)
+	assert.Equal(t, []string{"tag1", "tag2"}, tags)
+}
+
+func TestMemcachePruneTags(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(ctrl)
+	client.EXPECT().Set(gomock.Any()).Return(nil)
+	client.EXPECT().Get("gocache_tag_tag1").Return(nil, memcache.ErrCacheMiss)
+	client.EXPECT().Add(&memcache.Item{
+		Key:        "gocache_tag_tag1",
+		Value:      []byte("\x00\x01+\x06my-key"),
+		Expiration: int32(TagKeyExpiry.Seconds()),
+	}).Return(nil)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return(nil, memcache.ErrCacheMiss)
+	client.EXPECT().Add(&memcache.Item{
+		Key:        "gocache_key_tags_my-key",
+		Value:      []byte("\x00\x01+\x04tag1"),
+		Expiration: int32(TagKeyExpiry.Seconds()),
+	}).Return(nil)
+
+	store := NewMemcache(client)
+	assert.Nil(t, store.Set(ctx, "my-key", []byte("my-value"), lib_store.WithTags([]string{"tag1"})))
+
+	// When the key has expired
+	tagItem := func() *memcache.Item {
+		return &memcache.Item{
+			Key:   "gocache_tag_tag1",
+			Value: []byte("\x00\x01+\x06my-key+\x0ca-second-key"),
+		}
+	}
+	client.EXPECT().Get("gocache_tag_tag1").Return(tagItem(), nil)
+	client.EXPECT().GetMulti([]string{"my-key", "a-second-key"}).Return(map[string]*memcache.Item{
+		"a-second-key": {Key: "a-second-key", Value: []byte("my-value")},
+	}, nil)
+	client.EXPECT().Get("gocache_tag_tag1").Return(tagItem(), nil)
+	client.EXPECT().CompareAndSwap(&memcache.Item{
+		Key:        "gocache_tag_tag1",
+		Value:      []byte("\x00\x01+\x0ca-second-key"),
+		Expiration: int32(TagKeyExpiry.Seconds()),
+	}).Return(nil)
+	client.EXPECT().Delete("gocache_key_tags_my-key").Return(memcache.ErrCacheMiss)
+
+	err := store.PruneTags(ctx)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestMemcacheGetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(ctrl)
+	client.EXPECT().GetMulti([]string{"my-key", "my-other-key"}).Return(map[string]*memcache.Item{
+		"my-key": {Key: "my-key", Value: []byte("my-value")},
+	}, nil)
+
+	store := NewMemcache(client)
+
+	// When
+	values, err := store.GetMany(ctx, []any{"my-key", "my-other-key"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"my-key": []byte("my-value")}, values)
+}
+
+func TestMemcacheGetManyWhenError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := errors.New("an unexpected error occurred")
+
+	client := NewMockMemcacheClientInterface(ctrl)
+	client.EXPECT().GetMulti([]string{"my-key"}).Return(nil, expectedErr)
+
+	store := NewMemcache(client)
+
+	// When
+	values, err := store.GetMany(ctx, []any{"my-key"})
+
+	// Then
+	assert.Equal(t, expectedErr, err)
+	assert.Nil(t, values)
+}
+
+func TestMemcacheSetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(ctrl)
+	client.EXPECT().Set(&memcache.Item{
+		Key:        "my-key",
+		Value:      []byte("my-value"),
+		Expiration: int32(5),
+	}).Return(nil)
+
+	store := NewMemcache(client, lib_store.WithExpiration(5*time.Second))
+
+	// When
+	err := store.SetMany(ctx, map[any]any{"my-key": []byte("my-value")})
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestMemcacheDeleteMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(ctrl)
+	client.EXPECT().Delete("my-key").Return(nil)
+	client.EXPECT().Delete("my-other-key").Return(memcache.ErrCacheMiss)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return(nil, memcache.ErrCacheMiss)
+	client.EXPECT().Delete("gocache_key_tags_my-key").Return(memcache.ErrCacheMiss)
+	client.EXPECT().Get("gocache_key_tags_my-other-key").Return(nil, memcache.ErrCacheMiss)
+	client.EXPECT().Delete("gocache_key_tags_my-other-key").Return(memcache.ErrCacheMiss)
+
+	store := NewMemcache(client)
+
+	// When
+	err := store.DeleteMany(ctx, []any{"my-key", "my-other-key"})
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestMemcacheIncrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(ctrl)
+	client.EXPECT().Increment("my-counter", uint64(2)).Return(uint64(7), nil)
+
+	store := NewMemcache(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), value)
+}
+
+func TestMemcacheIncrementWhenCounterIsMissing(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(ctrl)
+	gomock.InOrder(
+		client.EXPECT().Increment("my-counter", uint64(2)).Return(uint64(0), memcache.ErrCacheMiss),
+		client.EXPECT().Add(&memcache.Item{
+			Key:        "my-counter",
+			Value:      []byte("2"),
+			Expiration: int32(5),
+		}).Return(memcache.ErrNotStored),
+		client.EXPECT().Increment("my-counter", uint64(2)).Return(uint64(4), nil),
+	)
+
+	store := NewMemcache(client, lib_store.WithExpiration(5*time.Second))
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), value)
+}
+
+func TestMemcacheDecrementWhenCounterIsMissing(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(ctrl)
+	client.EXPECT().Decrement("my-counter", uint64(2)).Return(uint64(0), memcache.ErrCacheMiss)
+	client.EXPECT().Add(&memcache.Item{
+		Key:   "my-counter",
+		Value: []byte("0"),
+	}).Return(nil)
+
+	store := NewMemcache(client)
+
+	// When
+	value, err := store.Decrement(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), value)
+}
+
+func TestMemcacheSetIfNotExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(ctrl)
+	client.EXPECT().Add(&memcache.Item{
+		Key:        "my-key",
+		Value:      []byte("my-value"),
+		Expiration: int32(5),
+	}).Return(nil)
+
+	store := NewMemcache(client, lib_store.WithExpiration(5*time.Second))
+
+	// When
+	set, err := store.SetIfNotExists(ctx, "my-key", []byte("my-value"))
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, set)
+}
+
+func TestMemcacheSetIfNotExistsWhenKeyExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(ctrl)
+	client.EXPECT().Add(gomock.Any()).Return(memcache.ErrNotStored)
+
+	store := NewMemcache(client)
+
+	// When
+	set, err := store.SetIfNotExists(ctx, "my-key", []byte("my-value"))
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, set)
+}
+
+func TestMemcacheCompareAndSwap(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(ctrl)
+	client.EXPECT().Get("my-key").Return(&memcache.Item{Key: "my-key", Value: []byte("old-value")}, nil)
+	client.EXPECT().CompareAndSwap(&memcache.Item{
+		Key:        "my-key",
+		Value:      []byte("new-value"),
+		Expiration: int32(5),
+	}).Return(nil)
+
+	store := NewMemcache(client, lib_store.WithExpiration(5*time.Second))
+
+	// When
+	swapped, err := store.CompareAndSwap(ctx, "my-key", []byte("old-value"), []byte("new-value"))
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, swapped)
+}
+
+func TestMemcacheCompareAndSwapWhenValueDiffers(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(ctrl)
+	client.EXPECT().Get("my-key").Return(&memcache.Item{Key: "my-key", Value: []byte("other-value")}, nil)
+
+	store := NewMemcache(client)
+
+	// When
+	swapped, err := store.CompareAndSwap(ctx, "my-key", []byte("old-value"), []byte("new-value"))
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, swapped)
+}
+
+func TestMemcacheCompareAndDelete(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(ctrl)
+	client.EXPECT().Get("my-key").Return(&memcache.Item{Key: "my-key", Value: []byte("old-value")}, nil)
+	client.EXPECT().CompareAndSwap(&memcache.Item{
+		Key:        "my-key",
+		Value:      []byte("old-value"),
+		Expiration: int32(-1),
+	}).Return(memcache.ErrCASConflict)
+
+	store := NewMemcache(client)
+
+	// When
+	deleted, err := store.CompareAndDelete(ctx, "my-key", []byte("old-value"))
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, deleted)
+}
+
+func TestMemcacheCompareAndDeleteWithStringValue(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(ctrl)
+	client.EXPECT().Get("my-key").Return(&memcache.Item{Key: "my-key", Value: []byte("old-value")}, nil)
+	client.EXPECT().CompareAndSwap(&memcache.Item{
+		Key:        "my-key",
+		Value:      []byte("old-value"),
+		Expiration: int32(-1),
+	}).Return(memcache.ErrCASConflict)
+
+	store := NewMemcache(client)
+
+	// When
+	deleted, err := store.CompareAndDelete(ctx, "my-key", "old-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, deleted)
+}
+
 func TestMemcacheInvalidate(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -325,13 +806,23 @@ func TestMemcacheInvalidate(t *testing.T) {
 	ctx := context.Background()
 
 	cacheKeys := &memcache.Item{
-		Value: []byte("a23fdf987h2svc23,jHG2372x38hf74"),
+		Key:   "gocache_tag_tag1",
+		Value: []byte("\x00\x01+\x10a23fdf987h2svc23+\x0ejHG2372x38hf74"),
 	}
 
 	client := NewMockMemcacheClientInterface(ctrl)
-	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys, nil)
+	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys, nil).Times(2)
 	client.EXPECT().Delete("a23fdf987h2svc23").Return(nil)
 	client.EXPECT().Delete("jHG2372x38hf74").Return(nil)
+	client.EXPECT().CompareAndSwap(&memcache.Item{
+		Key:        "gocache_tag_tag1",
+		Value:      []byte("\x00\x01+\x10a23fdf987h2svc23+\x0ejHG2372x38hf74-\x10a23fdf987h2svc23-\x0ejHG2372x38hf74"),
+		Expiration: int32(TagKeyExpiry.Seconds()),
+	}).Return(nil)
+	client.EXPECT().Get("gocache_key_tags_a23fdf987h2svc23").Return(nil, memcache.ErrCacheMiss)
+	client.EXPECT().Get("gocache_key_tags_jHG2372x38hf74").Return(nil, memcache.ErrCacheMiss)
+	client.EXPECT().Delete("gocache_key_tags_a23fdf987h2svc23").Return(memcache.ErrCacheMiss)
+	client.EXPECT().Delete("gocache_key_tags_jHG2372x38hf74").Return(memcache.ErrCacheMiss)
 
 	store := NewMemcache(client)
 
@@ -348,14 +839,27 @@ func TestMemcacheInvalidateWhenError(t *testing.T) {
 
 	ctx := context.Background()
 
-	cacheKeys := &memcache.Item{
-		Value: []byte("a23fdf987h2svc23,jHG2372x38hf74"),
+	cacheKeys := func() *memcache.Item {
+		return &memcache.Item{
+			Key:   "gocache_tag_tag1",
+			Value: []byte("a23fdf987h2svc23,jHG2372x38hf74"),
+		}
 	}
 
 	client := NewMockMemcacheClientInterface(ctrl)
-	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys, nil)
+	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys(), nil)
 	client.EXPECT().Delete("a23fdf987h2svc23").Return(errors.New("unexpected error"))
 	client.EXPECT().Delete("jHG2372x38hf74").Return(nil)
+	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys(), nil)
+	client.EXPECT().CompareAndSwap(&memcache.Item{
+		Key:        "gocache_tag_tag1",
+		Value:      []byte("\x00\x01"),
+		Expiration: int32(TagKeyExpiry.Seconds()),
+	}).Return(nil)
+	client.EXPECT().Get("gocache_key_tags_a23fdf987h2svc23").Return(nil, memcache.ErrCacheMiss)
+	client.EXPECT().Get("gocache_key_tags_jHG2372x38hf74").Return(nil, memcache.ErrCacheMiss)
+	client.EXPECT().Delete("gocache_key_tags_a23fdf987h2svc23").Return(nil)
+	client.EXPECT().Delete("gocache_key_tags_jHG2372x38hf74").Return(nil)
 
 	store := NewMemcache(client)
 
@@ -366,6 +870,23 @@ func TestMemcacheInvalidateWhenError(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestMemcacheInvalidateWithPrefixWhenNotSupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(ctrl)
+
+	store := NewMemcache(client)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidatePrefix("tenant:17:"))
+
+	// Then
+	assert.True(t, errors.Is(err, &lib_store.NotSupported{}))
+}
+
 func TestMemcacheClear(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -415,3 +936,15 @@ func TestMemcacheGetType(t *testing.T) {
 	// When - Then
 	assert.Equal(t, MemcacheType, store.GetType())
 }
+
+func TestMemcacheGetDefaultExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	client := NewMockMemcacheClientInterface(ctrl)
+
+	store := NewMemcache(client, lib_store.WithExpiration(6*time.Second))
+
+	// When - Then
+	assert.Equal(t, 6*time.Second, store.GetDefaultExpiration())
+}
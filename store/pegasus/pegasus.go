@@ -1,6 +1,7 @@
 package pegasus
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -192,72 +193,394 @@ func (p *PegasusStore) Set(ctx context.Context, key, value any, options ...lib_s
 	return nil
 }
 
+// setTags adds the key to the tags, which are kept as native sets: the sort keys of
+// a tag hash key are the keys associated to it
 func (p *PegasusStore) setTags(ctx context.Context, key any, tags []string) error {
+	table, err := p.client.OpenTable(ctx, p.options.TableName)
+	if err != nil {
+		return err
+	}
+	defer table.Close()
+
+	ttl := p.tagIndexOptions().TTL
+
+	keyTagsKey := []byte(fmt.Sprintf(lib_store.KeyTagsPattern, cast.ToString(key)))
+
 	for _, tag := range tags {
 		tagKey := fmt.Sprintf(PegasusTagPattern, tag)
-		cacheKeys := []string{}
 
-		if result, err := p.Get(ctx, tagKey); err == nil {
-			if bytes, ok := result.([]byte); ok {
-				cacheKeys = strings.Split(string(bytes), ",")
-			}
+		if err := table.SetTTL(ctx, []byte(tagKey), []byte(cast.ToString(key)), empty, ttl); err != nil {
+			return err
 		}
 
-		alreadyInserted := false
-		for _, cacheKey := range cacheKeys {
-			if cacheKey == key.(string) {
-				alreadyInserted = true
+		if err := table.SetTTL(ctx, keyTagsKey, []byte(tag), empty, ttl); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeTags dissociates the given deleted key from its tags
+func (p *PegasusStore) removeTags(ctx context.Context, table pegasus.TableConnector, key string) error {
+	keyTagsKey := []byte(fmt.Sprintf(lib_store.KeyTagsPattern, key))
+
+	tags, err := p.getSortKeys(ctx, table, keyTagsKey)
+	if err != nil || len(tags) == 0 {
+		return err
+	}
+
+	for _, tag := range tags {
+		if err := table.Del(ctx, []byte(fmt.Sprintf(PegasusTagPattern, tag)), []byte(key)); err != nil {
+			return err
+		}
+	}
+
+	return table.MultiDel(ctx, keyTagsKey, tags)
+}
+
+// getSortKeys returns the sort keys stored under the given hash key
+func (p *PegasusStore) getSortKeys(ctx context.Context, table pegasus.TableConnector, hashKey []byte) ([][]byte, error) {
+	scanner, err := table.GetScanner(ctx, hashKey, nil, nil, &pegasus.ScannerOptions{
+		BatchSize:      p.options.TableScanNum,
+		StartInclusive: true,
+		NoValue:        true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer scanner.Close()
+
+	var sortKeys [][]byte
+	for {
+		completed, _, sortKey, _, err := scanner.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if completed {
+			return sortKeys, nil
+		}
+
+		sortKeys = append(sortKeys, sortKey)
+	}
+}
+
+// KeysForTag returns the keys associated to the given tag
+func (p *PegasusStore) KeysForTag(ctx context.Context, tag string) ([]string, error) {
+	table, err := p.client.OpenTable(ctx, p.options.TableName)
+	if err != nil {
+		return nil, err
+	}
+	defer table.Close()
+
+	cacheKeys, _, err := p.getTagKeys(ctx, table, []byte(fmt.Sprintf(PegasusTagPattern, tag)))
+
+	return cacheKeys, err
+}
+
+// TagsForKey returns the tags associated to the given key
+func (p *PegasusStore) TagsForKey(ctx context.Context, key any) ([]string, error) {
+	table, err := p.client.OpenTable(ctx, p.options.TableName)
+	if err != nil {
+		return nil, err
+	}
+	defer table.Close()
+
+	sortKeys, err := p.getSortKeys(ctx, table, []byte(fmt.Sprintf(lib_store.KeyTagsPattern, cast.ToString(key))))
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]string, 0, len(sortKeys))
+	for _, sortKey := range sortKeys {
+		tags = append(tags, string(sortKey))
+	}
+
+	return tags, nil
+}
+
+// PruneTags dissociates the keys which do not exist anymore, such as the expired ones,
+// from their tags, scanning the whole table to find the tags
+func (p *PegasusStore) PruneTags(ctx context.Context) error {
+	table, err := p.client.OpenTable(ctx, p.options.TableName)
+	if err != nil {
+		return err
+	}
+	defer table.Close()
+
+	scanners, err := table.GetUnorderedScanners(ctx, p.options.TablePartitionNum, &pegasus.ScannerOptions{
+		BatchSize: p.options.TableScanNum,
+		NoValue:   true,
+	})
+	if err != nil {
+		return err
+	}
+
+	tagPrefix := []byte(fmt.Sprintf(PegasusTagPattern, ""))
+
+	for _, scanner := range scanners {
+		for {
+			completed, hashKey, sortKey, _, err := scanner.Next(ctx)
+			if err != nil {
+				return err
+			}
+			if completed {
 				break
 			}
+
+			// The sort keys of a tag are the keys associated to it
+			if !bytes.HasPrefix(hashKey, tagPrefix) || bytes.Equal(sortKey, empty) {
+				continue
+			}
+
+			exists, err := table.Exist(ctx, sortKey, empty)
+			if err != nil {
+				return err
+			}
+			if exists {
+				continue
+			}
+
+			if err := table.Del(ctx, hashKey, sortKey); err != nil {
+				return err
+			}
+			if err := p.removeTags(ctx, table, string(sortKey)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// getTagKeys returns the keys associated to the tag along with the sort keys they
+// are stored at
+func (p *PegasusStore) getTagKeys(ctx context.Context, table pegasus.TableConnector, tagKey []byte) ([]string, [][]byte, error) {
+	scanner, err := table.GetScanner(ctx, tagKey, nil, nil, &pegasus.ScannerOptions{
+		BatchSize:      p.options.TableScanNum,
+		StartInclusive: true,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer scanner.Close()
+
+	var (
+		cacheKeys []string
+		sortKeys  [][]byte
+	)
+
+	for {
+		completed, _, sortKey, value, err := scanner.Next(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if completed {
+			break
+		}
+
+		sortKeys = append(sortKeys, sortKey)
+
+		if bytes.Equal(sortKey, empty) && !bytes.Equal(value, empty) {
+			// Comma-separated list of keys written by a previous version
+			for _, cacheKey := range strings.Split(string(value), ",") {
+				if cacheKey != "" {
+					cacheKeys = append(cacheKeys, cacheKey)
+				}
+			}
+			continue
 		}
 
-		if !alreadyInserted {
-			cacheKeys = append(cacheKeys, key.(string))
+		cacheKeys = append(cacheKeys, string(sortKey))
+	}
+
+	return cacheKeys, sortKeys, nil
+}
+
+func (p *PegasusStore) tagIndexOptions() *lib_store.TagIndexOptions {
+	if p.options.Options == nil {
+		return lib_store.ApplyTagIndexOptions()
+	}
+
+	return lib_store.ApplyTagIndexOptions(p.options.TagIndex...)
+}
+
+// Delete removes data from Pegasus for given key identifier, and dissociates it from its tags
+func (p *PegasusStore) Delete(ctx context.Context, key any) error {
+	table, err := p.client.OpenTable(ctx, p.options.TableName)
+	if err != nil {
+		return err
+	}
+	defer table.Close()
+
+	if err := table.Del(ctx, []byte(cast.ToString(key)), empty); err != nil {
+		return err
+	}
+
+	return p.removeTags(ctx, table, cast.ToString(key))
+}
+
+// GetMany returns data stored from given keys in a single batch request,
+// keys that cannot be found are absent from the returned map
+func (p *PegasusStore) GetMany(ctx context.Context, keys []any) (map[any]any, error) {
+	table, err := p.client.OpenTable(ctx, p.options.TableName)
+	if err != nil {
+		return nil, err
+	}
+	defer table.Close()
+
+	compositeKeys := make([]pegasus.CompositeKey, 0, len(keys))
+	for _, key := range keys {
+		compositeKeys = append(compositeKeys, pegasus.CompositeKey{
+			HashKey: []byte(cast.ToString(key)),
+			SortKey: empty,
+		})
+	}
+
+	values, err := table.BatchGet(ctx, compositeKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make(map[any]any, len(values))
+	for i, value := range values {
+		if value != nil {
+			objects[keys[i]] = value
 		}
+	}
+
+	return objects, nil
+}
 
-		if err := p.Set(ctx, tagKey, []byte(strings.Join(cacheKeys, ",")), lib_store.WithExpiration(720*time.Hour)); err != nil {
+// SetMany defines data in Pegasus for given key identifiers
+func (p *PegasusStore) SetMany(ctx context.Context, items map[any]any, options ...lib_store.Option) error {
+	opts := lib_store.ApplyOptions(options...)
+
+	table, err := p.client.OpenTable(ctx, p.options.TableName)
+	if err != nil {
+		return err
+	}
+	defer table.Close()
+
+	for key, value := range items {
+		err = table.SetTTL(ctx, []byte(cast.ToString(key)), empty, []byte(cast.ToString(value)), opts.Expiration)
+		if err != nil {
 			return err
 		}
+
+		if tags := opts.Tags; len(tags) > 0 {
+			if err = p.setTags(ctx, key, tags); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
-// Delete removes data from Pegasus for given key identifier
-func (p *PegasusStore) Delete(ctx context.Context, key any) error {
+// DeleteMany removes data from Pegasus for given key identifiers
+func (p *PegasusStore) DeleteMany(ctx context.Context, keys []any) error {
 	table, err := p.client.OpenTable(ctx, p.options.TableName)
 	if err != nil {
 		return err
 	}
 	defer table.Close()
 
-	return table.Del(ctx, []byte(cast.ToString(key)), empty)
+	for _, key := range keys {
+		if err = table.Del(ctx, []byte(cast.ToString(key)), empty); err != nil {
+			return err
+		}
+		if err = p.removeTags(ctx, table, cast.ToString(key)); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // Invalidate invalidates some cache data in Pegasus for given options
 func (p *PegasusStore) Invalidate(ctx context.Context, options ...lib_store.InvalidateOption) error {
 	opts := lib_store.ApplyInvalidateOptions(options...)
+	if len(opts.Tags) == 0 && !opts.TargetsKeys() {
+		return nil
+	}
+
+	table, err := p.client.OpenTable(ctx, p.options.TableName)
+	if err != nil {
+		return err
+	}
+	defer table.Close()
+
 	if tags := opts.Tags; len(tags) > 0 {
 		for _, tag := range tags {
-			tagKey := fmt.Sprintf(PegasusTagPattern, tag)
-			result, err := p.Get(ctx, tagKey)
+			tagKey := []byte(fmt.Sprintf(PegasusTagPattern, tag))
+
+			cacheKeys, sortKeys, err := p.getTagKeys(ctx, table, tagKey)
 			if err != nil {
-				return nil
+				return err
 			}
 
-			cacheKeys := []string{}
-			if bytes, ok := result.([]byte); ok {
-				cacheKeys = strings.Split(string(bytes), ",")
+			for _, cacheKey := range cacheKeys {
+				if err := table.Del(ctx, []byte(cacheKey), empty); err != nil {
+					return err
+				}
+				// Also dissociate the deleted key from its other tags
+				if err := p.removeTags(ctx, table, cacheKey); err != nil {
+					return err
+				}
 			}
 
-			for _, cacheKey := range cacheKeys {
-				if err := p.Delete(ctx, cacheKey); err != nil {
+			// Only the scanned keys are removed, the ones added meanwhile are kept
+			if len(sortKeys) > 0 {
+				if err := table.MultiDel(ctx, tagKey, sortKeys); err != nil {
 					return err
 				}
 			}
 		}
 	}
 
+	if opts.TargetsKeys() {
+		return p.invalidateKeys(ctx, table, opts)
+	}
+
+	return nil
+}
+
+// invalidateKeys deletes the keys matching the prefix or the pattern of the given
+// options using a full scan, and dissociates them from their tags
+func (p *PegasusStore) invalidateKeys(ctx context.Context, table pegasus.TableConnector, opts *lib_store.InvalidateOptions) error {
+	scanners, err := table.GetUnorderedScanners(ctx, p.options.TablePartitionNum, &pegasus.ScannerOptions{
+		BatchSize: p.options.TableScanNum,
+		NoValue:   true,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, scanner := range scanners {
+		for {
+			completed, hashKey, sortKey, _, err := scanner.Next(ctx)
+			if err != nil {
+				return err
+			}
+			if completed {
+				break
+			}
+
+			// Values are stored with an empty sort key, unlike tags
+			if !bytes.Equal(sortKey, empty) || !opts.MatchKey(string(hashKey)) {
+				continue
+			}
+
+			if err := table.Del(ctx, hashKey, empty); err != nil {
+				return err
+			}
+			if err := p.removeTags(ctx, table, string(hashKey)); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -283,14 +606,15 @@ func (p *PegasusStore) Clear(ctx context.Context) error {
 	for _, scanner := range scanners {
 		// Iterates sequentially.
 		for {
-			completed, hashKey, _, _, err := scanner.Next(ctx)
+			completed, hashKey, sortKey, _, err := scanner.Next(ctx)
 			if err != nil {
 				return err
 			}
 			if completed {
 				break
 			}
-			err = p.Delete(ctx, hashKey)
+			// Tags are stored with several sort keys under their hash key
+			err = table.Del(ctx, hashKey, sortKey)
 			if err != nil {
 				return err
 			}
@@ -185,6 +185,74 @@ func TestPegasusStore_Delete(t *testing.T) {
 	})
 }
 
+func TestPegasusStore_KeysForTag(t *testing.T) {
+	Convey("Pegasus TestKeysForTag for pegasus store", t, func() {
+		skipPegasusTest(t)
+
+		ctx := context.Background()
+
+		p, _ := NewPegasus(ctx, testPegasusOptions())
+		defer p.Close()
+
+		k, v := "test-gocache-tagged-key", "test-gocache-value"
+		err := p.Set(ctx, k, v, lib_store.WithTags([]string{"test01", "test02"}))
+		So(err, ShouldBeNil)
+
+		keys, err := p.KeysForTag(ctx, "test01")
+		So(err, ShouldBeNil)
+		So(keys, ShouldContain, k)
+
+		tags, err := p.TagsForKey(ctx, k)
+		So(err, ShouldBeNil)
+		So(tags, ShouldResemble, []string{"test01", "test02"})
+
+		err = p.Delete(ctx, k)
+		So(err, ShouldBeNil)
+	})
+}
+
+func TestPegasusStore_PruneTags(t *testing.T) {
+	Convey("Pegasus TestPruneTags for pegasus store", t, func() {
+		skipPegasusTest(t)
+
+		ctx := context.Background()
+
+		p, _ := NewPegasus(ctx, testPegasusOptions())
+		defer p.Close()
+
+		k, v := "test-gocache-prune-key", "test-gocache-value"
+		err := p.Set(ctx, k, v, lib_store.WithTags([]string{"test01"}), lib_store.WithExpiration(time.Second))
+		So(err, ShouldBeNil)
+
+		time.Sleep(2 * time.Second)
+
+		err = p.PruneTags(ctx)
+		So(err, ShouldBeNil)
+	})
+}
+
+func TestPegasusStore_GetMany(t *testing.T) {
+	Convey("Pegasus TestGetMany for pegasus store", t, func() {
+		skipPegasusTest(t)
+
+		ctx := context.Background()
+
+		p, _ := NewPegasus(ctx, testPegasusOptions())
+		defer p.Close()
+
+		err := p.SetMany(ctx, map[any]any{"test-gocache-key-01": "test-gocache-value"})
+		So(err, ShouldBeNil)
+
+		values, err := p.GetMany(ctx, []any{"test-gocache-key-01", "test-gocache-key-missing"})
+		So(err, ShouldBeNil)
+		So(values, ShouldHaveLength, 1)
+		So(cast.ToString(values["test-gocache-key-01"]), ShouldEqual, "test-gocache-value")
+
+		err = p.DeleteMany(ctx, []any{"test-gocache-key-01"})
+		So(err, ShouldBeNil)
+	})
+}
+
 func TestPegasusStore_Invalidate(t *testing.T) {
 	Convey("Pegasus TestInvalidate for pegasus store", t, func() {
 		skipPegasusTest(t)
@@ -199,6 +267,26 @@ func TestPegasusStore_Invalidate(t *testing.T) {
 	})
 }
 
+func TestPegasusStore_InvalidateWithPrefix(t *testing.T) {
+	Convey("Pegasus TestInvalidateWithPrefix for pegasus store", t, func() {
+		skipPegasusTest(t)
+
+		ctx := context.Background()
+
+		p, _ := NewPegasus(ctx, testPegasusOptions())
+		defer p.Close()
+
+		err := p.Set(ctx, "test-gocache-tenant:17:key", "test-gocache-value", lib_store.WithTags([]string{"test01"}))
+		So(err, ShouldBeNil)
+
+		err = p.Invalidate(ctx, lib_store.WithInvalidatePrefix("test-gocache-tenant:17:"))
+		So(err, ShouldBeNil)
+
+		_, err = p.Get(ctx, "test-gocache-tenant:17:key")
+		So(err, ShouldNotBeNil)
+	})
+}
+
 func TestPegasusStore_Clear(t *testing.T) {
 	Convey("Pegasus TestClear for pegasus store", t, func() {
 		skipPegasusTest(t)
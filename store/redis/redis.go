@@ -19,6 +19,16 @@ type RedisClientInterface interface {
 	FlushAll(ctx context.Context) *redis.StatusCmd
 	SAdd(ctx context.Context, key string, members ...any) *redis.IntCmd
 	SMembers(ctx context.Context, key string) *redis.StringSliceCmd
+	SRem(ctx context.Context, key string, members ...any) *redis.IntCmd
+	Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd
+	ScanType(ctx context.Context, cursor uint64, match string, count int64, keyType string) *redis.ScanCmd
+	Unlink(ctx context.Context, keys ...string) *redis.IntCmd
+	IncrBy(ctx context.Context, key string, value int64) *redis.IntCmd
+	DecrBy(ctx context.Context, key string, decrement int64) *redis.IntCmd
+	SetNX(ctx context.Context, key string, value any, expiration time.Duration) *redis.BoolCmd
+	Eval(ctx context.Context, script string, keys []string, args ...any) *redis.Cmd
+	MGet(ctx context.Context, keys ...string) *redis.SliceCmd
+	Pipelined(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error)
 }
 
 const (
@@ -26,19 +36,46 @@ const (
 	RedisType = "redis"
 	// RedisTagPattern represents the tag pattern to be used as a key in specified storage
 	RedisTagPattern = "gocache_tag_%s"
+
+	// scanCount is the number of keys each SCAN call is hinted to return
+	scanCount = 100
+)
+
+const (
+	// compareAndSwapScript sets the new value (ARGV[2]) with an optional expiration
+	// in milliseconds (ARGV[3]) only if the current value equals ARGV[1]
+	compareAndSwapScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then
+	if tonumber(ARGV[3]) > 0 then
+		redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+	else
+		redis.call("SET", KEYS[1], ARGV[2])
+	end
+	return 1
+end
+return 0`
+
+	// compareAndDeleteScript removes the key only if its current value equals ARGV[1]
+	compareAndDeleteScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`
 )
 
 // RedisStore is a store for Redis
 type RedisStore struct {
 	client  RedisClientInterface
 	options *lib_store.Options
+	tagTTL  time.Duration
 }
 
 // NewRedis creates a new store to Redis instance(s)
 func NewRedis(client RedisClientInterface, options ...lib_store.Option) *RedisStore {
+	opts := lib_store.ApplyOptions(options...)
+
 	return &RedisStore{
 		client:  client,
-		options: lib_store.ApplyOptions(options...),
+		options: opts,
+		tagTTL:  lib_store.ApplyTagIndexOptions(opts.TagIndex...).TTL,
 	}
 }
 
@@ -79,26 +116,278 @@ func (s *RedisStore) Set(ctx context.Context, key any, value any, options ...lib
 	}
 
 	if tags := opts.Tags; len(tags) > 0 {
-		s.setTags(ctx, key, tags)
+		s.setTags(ctx, tags, key.(string))
 	}
 
 	return nil
 }
 
-func (s *RedisStore) setTags(ctx context.Context, key any, tags []string) {
+// setTags associates the given keys to the tags in a single round trip using a pipeline
+func (s *RedisStore) setTags(ctx context.Context, tags []string, keys ...string) {
+	members := make([]any, 0, len(keys))
+	for _, key := range keys {
+		members = append(members, key)
+	}
+
+	tagMembers := make([]any, 0, len(tags))
 	for _, tag := range tags {
-		tagKey := fmt.Sprintf(RedisTagPattern, tag)
-		s.client.SAdd(ctx, tagKey, key.(string))
-		s.client.Expire(ctx, tagKey, 720*time.Hour)
+		tagMembers = append(tagMembers, tag)
+	}
+
+	s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, tag := range tags {
+			tagKey := fmt.Sprintf(RedisTagPattern, tag)
+			pipe.SAdd(ctx, tagKey, members...)
+			s.expireTags(ctx, pipe, tagKey)
+		}
+
+		for _, key := range keys {
+			keyTagsKey := fmt.Sprintf(lib_store.KeyTagsPattern, key)
+			pipe.SAdd(ctx, keyTagsKey, tagMembers...)
+			s.expireTags(ctx, pipe, keyTagsKey)
+		}
+		return nil
+	})
+}
+
+func (s *RedisStore) expireTags(ctx context.Context, pipe redis.Pipeliner, key string) {
+	if s.tagTTL > 0 {
+		pipe.Expire(ctx, key, s.tagTTL)
 	}
 }
 
-// Delete removes data from Redis for given key identifier
-func (s *RedisStore) Delete(ctx context.Context, key any) error {
-	_, err := s.client.Del(ctx, key.(string)).Result()
+// removeTags dissociates the given deleted keys from their tags in two round trips:
+// the tags of the keys are read using a pipeline, then the keys are removed from
+// their tag sets using a second one
+func (s *RedisStore) removeTags(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	keyTagsKeys := make([]string, 0, len(keys))
+	cmds := make([]*redis.StringSliceCmd, 0, len(keys))
+
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			keyTagsKey := fmt.Sprintf(lib_store.KeyTagsPattern, key)
+			keyTagsKeys = append(keyTagsKeys, keyTagsKey)
+			cmds = append(cmds, pipe.SMembers(ctx, keyTagsKey))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, cmd := range cmds {
+			for _, tag := range cmd.Val() {
+				pipe.SRem(ctx, fmt.Sprintf(RedisTagPattern, tag), keys[i])
+			}
+		}
+		pipe.Del(ctx, keyTagsKeys...)
+		return nil
+	})
+
 	return err
 }
 
+// Delete removes data from Redis for given key identifier, and dissociates it from its tags
+func (s *RedisStore) Delete(ctx context.Context, key any) error {
+	if _, err := s.client.Del(ctx, key.(string)).Result(); err != nil {
+		return err
+	}
+
+	return s.removeTags(ctx, key.(string))
+}
+
+// KeysForTag returns the keys associated to the given tag
+func (s *RedisStore) KeysForTag(ctx context.Context, tag string) ([]string, error) {
+	return s.client.SMembers(ctx, fmt.Sprintf(RedisTagPattern, tag)).Result()
+}
+
+// TagsForKey returns the tags associated to the given key
+func (s *RedisStore) TagsForKey(ctx context.Context, key any) ([]string, error) {
+	return s.client.SMembers(ctx, fmt.Sprintf(lib_store.KeyTagsPattern, key.(string))).Result()
+}
+
+// PruneTags dissociates the keys which do not exist anymore, such as the expired or
+// evicted ones, from their tags. Tag sets are found using SCAN with the TYPE option,
+// which requires Redis 6.0 or later.
+func (s *RedisStore) PruneTags(ctx context.Context) error {
+	var cursor uint64
+	for {
+		tagKeys, next, err := s.client.ScanType(ctx, cursor, fmt.Sprintf(RedisTagPattern, "*"), scanCount, "set").Result()
+		if err != nil {
+			return err
+		}
+
+		for _, tagKey := range tagKeys {
+			if err := s.pruneTag(ctx, tagKey); err != nil {
+				return err
+			}
+		}
+
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+func (s *RedisStore) pruneTag(ctx context.Context, tagKey string) error {
+	cacheKeys, err := s.client.SMembers(ctx, tagKey).Result()
+	if err != nil || len(cacheKeys) == 0 {
+		return err
+	}
+
+	cmds := make([]*redis.IntCmd, 0, len(cacheKeys))
+
+	_, err = s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, cacheKey := range cacheKeys {
+			cmds = append(cmds, pipe.Exists(ctx, cacheKey))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var missing []any
+	var keyTagsKeys []string
+	for i, cmd := range cmds {
+		if cmd.Val() == 0 {
+			missing = append(missing, cacheKeys[i])
+			keyTagsKeys = append(keyTagsKeys, fmt.Sprintf(lib_store.KeyTagsPattern, cacheKeys[i]))
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if err := s.client.SRem(ctx, tagKey, missing...).Err(); err != nil {
+		return err
+	}
+
+	// The missing keys are removed from their other tags when these are pruned
+	return s.client.Del(ctx, keyTagsKeys...).Err()
+}
+
+// GetMany returns data stored from given keys in a single MGET command,
+// keys that cannot be found are absent from the returned map
+func (s *RedisStore) GetMany(ctx context.Context, keys []any) (map[any]any, error) {
+	cacheKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		cacheKeys = append(cacheKeys, key.(string))
+	}
+
+	values, err := s.client.MGet(ctx, cacheKeys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make(map[any]any, len(values))
+	for i, value := range values {
+		if value != nil {
+			objects[keys[i]] = value
+		}
+	}
+
+	return objects, nil
+}
+
+// SetMany defines data in Redis for given key identifiers using a pipeline
+func (s *RedisStore) SetMany(ctx context.Context, items map[any]any, options ...lib_store.Option) error {
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, value := range items {
+			pipe.Set(ctx, key.(string), value, opts.Expiration)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if tags := opts.Tags; len(tags) > 0 {
+		keys := make([]string, 0, len(items))
+		for key := range items {
+			keys = append(keys, key.(string))
+		}
+		s.setTags(ctx, tags, keys...)
+	}
+
+	return nil
+}
+
+// DeleteMany removes data from Redis for given key identifiers in a single DEL command
+func (s *RedisStore) DeleteMany(ctx context.Context, keys []any) error {
+	cacheKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		cacheKeys = append(cacheKeys, key.(string))
+	}
+
+	if _, err := s.client.Del(ctx, cacheKeys...).Result(); err != nil {
+		return err
+	}
+
+	return s.removeTags(ctx, cacheKeys...)
+}
+
+// Increment atomically adds delta to the counter stored at the given key using INCRBY
+func (s *RedisStore) Increment(ctx context.Context, key any, delta int64) (int64, error) {
+	return s.client.IncrBy(ctx, key.(string), delta).Result()
+}
+
+// Decrement atomically subtracts delta from the counter stored at the given key using DECRBY
+func (s *RedisStore) Decrement(ctx context.Context, key any, delta int64) (int64, error) {
+	return s.client.DecrBy(ctx, key.(string), delta).Result()
+}
+
+// SetIfNotExists defines data in Redis only if the given key does not exist yet using SETNX
+func (s *RedisStore) SetIfNotExists(ctx context.Context, key any, value any, options ...lib_store.Option) (bool, error) {
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+
+	set, err := s.client.SetNX(ctx, key.(string), value, opts.Expiration).Result()
+	if err != nil || !set {
+		return false, err
+	}
+
+	if tags := opts.Tags; len(tags) > 0 {
+		s.setTags(ctx, tags, key.(string))
+	}
+
+	return true, nil
+}
+
+// CompareAndSwap replaces data in Redis only if the current value equals the old one
+func (s *RedisStore) CompareAndSwap(ctx context.Context, key any, oldValue any, newValue any, options ...lib_store.Option) (bool, error) {
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+
+	swapped, err := s.client.Eval(ctx, compareAndSwapScript, []string{key.(string)}, oldValue, newValue, opts.Expiration.Milliseconds()).Int64()
+	if err != nil || swapped == 0 {
+		return false, err
+	}
+
+	if tags := opts.Tags; len(tags) > 0 {
+		s.setTags(ctx, tags, key.(string))
+	}
+
+	return true, nil
+}
+
+// CompareAndDelete removes data from Redis only if the current value equals the old one
+func (s *RedisStore) CompareAndDelete(ctx context.Context, key any, oldValue any) (bool, error) {
+	deleted, err := s.client.Eval(ctx, compareAndDeleteScript, []string{key.(string)}, oldValue).Int64()
+	if err != nil || deleted == 0 {
+		return false, err
+	}
+
+	return true, s.removeTags(ctx, key.(string))
+}
+
 // Invalidate invalidates some cache data in Redis for given options
 func (s *RedisStore) Invalidate(ctx context.Context, options ...lib_store.InvalidateOption) error {
 	opts := lib_store.ApplyInvalidateOptions(options...)
@@ -108,25 +397,66 @@ func (s *RedisStore) Invalidate(ctx context.Context, options ...lib_store.Invali
 			tagKey := fmt.Sprintf(RedisTagPattern, tag)
 			cacheKeys, err := s.client.SMembers(ctx, tagKey).Result()
 			if err != nil {
-				continue
+				return err
 			}
 
-			for _, cacheKey := range cacheKeys {
-				s.Delete(ctx, cacheKey)
+			// The tagged keys are deleted along with the tag set in a single DEL command
+			if err := s.client.Del(ctx, append(cacheKeys, tagKey)...).Err(); err != nil {
+				return err
 			}
 
-			s.Delete(ctx, tagKey)
+			if err := s.removeTags(ctx, cacheKeys...); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, pattern := range opts.KeyPatterns() {
+		if err := s.invalidatePattern(ctx, pattern); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+// invalidatePattern deletes the keys matching the given pattern, which are found using
+// SCAN and removed using UNLINK so Redis is not blocked, and dissociates them from their tags
+func (s *RedisStore) invalidatePattern(ctx context.Context, pattern string) error {
+	var cursor uint64
+	for {
+		cacheKeys, next, err := s.client.Scan(ctx, cursor, pattern, scanCount).Result()
+		if err != nil {
+			return err
+		}
+
+		if len(cacheKeys) > 0 {
+			if err := s.client.Unlink(ctx, cacheKeys...).Err(); err != nil {
+				return err
+			}
+
+			if err := s.removeTags(ctx, cacheKeys...); err != nil {
+				return err
+			}
+		}
+
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
 // GetType returns the store type
 func (s *RedisStore) GetType() string {
 	return RedisType
 }
 
+// GetDefaultExpiration returns the expiration applied to the values written without one
+func (s *RedisStore) GetDefaultExpiration() time.Duration {
+	return s.options.Expiration
+}
+
 // Clear resets all data in the store
 func (s *RedisStore) Clear(ctx context.Context) error {
 	if err := s.client.FlushAll(ctx).Err(); err != nil {
@@ -2,6 +2,7 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -97,10 +98,13 @@ func TestRedisSetWithTags(t *testing.T) {
 	cacheKey := "my-key"
 	cacheValue := "my-cache-value"
 
+	pipe := &tagsPipeliner{}
+
 	client := NewMockRedisClientInterface(ctrl)
 	client.EXPECT().Set(ctx, cacheKey, cacheValue, time.Duration(0)).Return(&redis.StatusCmd{})
-	client.EXPECT().SAdd(ctx, "gocache_tag_tag1", "my-key").Return(&redis.IntCmd{})
-	client.EXPECT().Expire(ctx, "gocache_tag_tag1", 720*time.Hour).Return(&redis.BoolCmd{})
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	})
 
 	store := NewRedis(client)
 
@@ -109,6 +113,12 @@ func TestRedisSetWithTags(t *testing.T) {
 
 	// Then
 	assert.Nil(t, err)
+	assert.Equal(t, [][]any{
+		{"sadd", "gocache_tag_tag1", "my-key"},
+		{"expire", "gocache_tag_tag1", 720 * time.Hour},
+		{"sadd", "gocache_key_tags_my-key", "tag1"},
+		{"expire", "gocache_key_tags_my-key", 720 * time.Hour},
+	}, pipe.writes)
 }
 
 func TestRedisDelete(t *testing.T) {
@@ -119,14 +129,150 @@ func TestRedisDelete(t *testing.T) {
 
 	cacheKey := "my-key"
 
+	pipe := &tagsPipeliner{}
+
 	client := NewMockRedisClientInterface(ctrl)
 	client.EXPECT().Del(ctx, "my-key").Return(&redis.IntCmd{})
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	}).Times(2)
 
 	store := NewRedis(client)
 
 	// When
 	err := store.Delete(ctx, cacheKey)
 
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, [][]any{{"del", "gocache_key_tags_my-key"}}, pipe.writes)
+}
+
+func TestRedisDeleteWithTags(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+
+	pipe := &tagsPipeliner{tags: map[string][]string{"gocache_key_tags_my-key": {"tag1", "tag2"}}}
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Del(ctx, "my-key").Return(&redis.IntCmd{})
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	}).Times(2)
+
+	store := NewRedis(client)
+
+	// When
+	err := store.Delete(ctx, cacheKey)
+
+	// Then the key is dissociated from its tags
+	assert.Nil(t, err)
+	assert.Equal(t, [][]any{
+		{"srem", "gocache_tag_tag1", "my-key"},
+		{"srem", "gocache_tag_tag2", "my-key"},
+		{"del", "gocache_key_tags_my-key"},
+	}, pipe.writes)
+}
+
+// tagsPipeliner answers the commands reading the tag index and the existence of keys,
+// and records the commands writing to it
+type tagsPipeliner struct {
+	redis.Pipeliner
+	tags   map[string][]string
+	keys   map[string]struct{}
+	writes [][]any
+}
+
+func (p *tagsPipeliner) SMembers(_ context.Context, key string) *redis.StringSliceCmd {
+	return redis.NewStringSliceResult(p.tags[key], nil)
+}
+
+func (p *tagsPipeliner) Exists(_ context.Context, keys ...string) *redis.IntCmd {
+	if _, ok := p.keys[keys[0]]; ok {
+		return redis.NewIntResult(1, nil)
+	}
+	return redis.NewIntResult(0, nil)
+}
+
+func (p *tagsPipeliner) SAdd(_ context.Context, key string, members ...any) *redis.IntCmd {
+	p.writes = append(p.writes, append([]any{"sadd", key}, members...))
+	return &redis.IntCmd{}
+}
+
+func (p *tagsPipeliner) Expire(_ context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+	p.writes = append(p.writes, []any{"expire", key, expiration})
+	return &redis.BoolCmd{}
+}
+
+func (p *tagsPipeliner) SRem(_ context.Context, key string, members ...any) *redis.IntCmd {
+	p.writes = append(p.writes, append([]any{"srem", key}, members...))
+	return &redis.IntCmd{}
+}
+
+func (p *tagsPipeliner) Del(_ context.Context, keys ...string) *redis.IntCmd {
+	write := []any{"del"}
+	for _, key := range keys {
+		write = append(write, key)
+	}
+	p.writes = append(p.writes, write)
+	return &redis.IntCmd{}
+}
+
+func TestRedisKeysForTag(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().SMembers(ctx, "gocache_tag_tag1").Return(redis.NewStringSliceResult([]string{"my-key", "a-second-key"}, nil))
+	client.EXPECT().SMembers(ctx, "gocache_key_tags_my-key").Return(redis.NewStringSliceResult([]string{"tag1", "tag2"}, nil))
+
+	store := NewRedis(client)
+
+	// When
+	keys, keysErr := store.KeysForTag(ctx, "tag1")
+	tags, tagsErr := store.TagsForKey(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, keysErr)
+	assert.Equal(t, []string{"my-key", "a-second-key"}, keys)
+	assert.Nil(t, tagsErr)
+	assert.Equal(t, []string{"tag1", "tag2"}, tags)
+}
+
+func TestRedisPruneTags(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := &tagsPipeliner{keys: map[string]struct{}{"a-second-key": {}}}
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().ScanType(ctx, uint64(0), "gocache_tag_*", int64(100), "set").
+		Return(redis.NewScanCmdResult([]string{"gocache_tag_tag1"}, 12, nil))
+	client.EXPECT().ScanType(ctx, uint64(12), "gocache_tag_*", int64(100), "set").
+		Return(redis.NewScanCmdResult([]string{"gocache_tag_tag2"}, 0, nil))
+	client.EXPECT().SMembers(ctx, "gocache_tag_tag1").Return(redis.NewStringSliceResult([]string{"my-key", "a-second-key"}, nil))
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	})
+	client.EXPECT().SRem(ctx, "gocache_tag_tag1", "my-key").Return(&redis.IntCmd{})
+	client.EXPECT().Del(ctx, "gocache_key_tags_my-key").Return(&redis.IntCmd{})
+	client.EXPECT().SMembers(ctx, "gocache_tag_tag2").Return(redis.NewStringSliceResult([]string{"a-second-key"}, nil))
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	})
+
+	store := NewRedis(client)
+
+	// When
+	err := store.PruneTags(ctx)
+
 	// Then
 	assert.Nil(t, err)
 }
@@ -152,6 +298,324 @@ func TestRedisInvalidate(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestRedisInvalidateWhenTagHasKeys(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := &tagsPipeliner{tags: map[string][]string{
+		"gocache_key_tags_my-key":       {"tag1", "tag2"},
+		"gocache_key_tags_a-second-key": {"tag1"},
+	}}
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().SMembers(ctx, "gocache_tag_tag1").Return(redis.NewStringSliceResult([]string{"my-key", "a-second-key"}, nil))
+	client.EXPECT().Del(ctx, "my-key", "a-second-key", "gocache_tag_tag1").Return(&redis.IntCmd{})
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	}).Times(2)
+
+	store := NewRedis(client)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidateTags([]string{"tag1"}))
+
+	// Then the keys are deleted in bulk and dissociated from their other tags
+	assert.Nil(t, err)
+	assert.Equal(t, [][]any{
+		{"srem", "gocache_tag_tag1", "my-key"},
+		{"srem", "gocache_tag_tag2", "my-key"},
+		{"srem", "gocache_tag_tag1", "a-second-key"},
+		{"del", "gocache_key_tags_my-key", "gocache_key_tags_a-second-key"},
+	}, pipe.writes)
+}
+
+func TestRedisInvalidateWithPrefix(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := &tagsPipeliner{tags: map[string][]string{"gocache_key_tags_tenant:[17]:key1": {"tag1"}}}
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Scan(ctx, uint64(0), `tenant:\[17\]:*`, int64(100)).
+		Return(redis.NewScanCmdResult([]string{"tenant:[17]:key1", "tenant:[17]:key2"}, 12, nil))
+	client.EXPECT().Unlink(ctx, "tenant:[17]:key1", "tenant:[17]:key2").Return(&redis.IntCmd{})
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	}).Times(2)
+	client.EXPECT().Scan(ctx, uint64(12), `tenant:\[17\]:*`, int64(100)).
+		Return(redis.NewScanCmdResult(nil, 0, nil))
+
+	store := NewRedis(client)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidatePrefix("tenant:[17]:"))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, [][]any{
+		{"srem", "gocache_tag_tag1", "tenant:[17]:key1"},
+		{"del", "gocache_key_tags_tenant:[17]:key1", "gocache_key_tags_tenant:[17]:key2"},
+	}, pipe.writes)
+}
+
+func TestRedisInvalidateWithPatternWhenError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	scanErr := errors.New("unexpected error")
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Scan(ctx, uint64(0), "session:*", int64(100)).Return(redis.NewScanCmdResult(nil, 0, scanErr))
+
+	store := NewRedis(client)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidatePattern("session:*"))
+
+	// Then
+	assert.Equal(t, scanErr, err)
+}
+
+func TestRedisGetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().MGet(ctx, "key1", "key2", "key3").Return(redis.NewSliceResult([]any{"value1", nil, "value3"}, nil))
+
+	store := NewRedis(client)
+
+	// When
+	values, err := store.GetMany(ctx, []any{"key1", "key2", "key3"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key1": "value1", "key3": "value3"}, values)
+}
+
+func TestRedisSetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := redis.NewClient(&redis.Options{}).Pipeline()
+	tagsPipe := &tagsPipeliner{}
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	})
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(tagsPipe)
+	})
+
+	store := NewRedis(client)
+
+	// When
+	err := store.SetMany(ctx, map[any]any{"key1": "value1", "key2": "value2"}, lib_store.WithTags([]string{"tag1"}))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 2, pipe.Len())
+
+	// The keys are associated to the tags in a single round trip
+	assert.Len(t, tagsPipe.writes, 6)
+	assert.Equal(t, []any{"sadd", "gocache_tag_tag1"}, tagsPipe.writes[0][:2])
+	assert.ElementsMatch(t, []any{"key1", "key2"}, tagsPipe.writes[0][2:])
+	assert.Equal(t, []any{"expire", "gocache_tag_tag1", 720 * time.Hour}, tagsPipe.writes[1])
+	assert.ElementsMatch(t, [][]any{
+		{"sadd", "gocache_key_tags_key1", "tag1"},
+		{"expire", "gocache_key_tags_key1", 720 * time.Hour},
+		{"sadd", "gocache_key_tags_key2", "tag1"},
+		{"expire", "gocache_key_tags_key2", 720 * time.Hour},
+	}, tagsPipe.writes[2:])
+}
+
+func TestRedisDeleteMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := &tagsPipeliner{}
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Del(ctx, "key1", "key2").Return(&redis.IntCmd{})
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	}).Times(2)
+
+	store := NewRedis(client)
+
+	// When
+	err := store.DeleteMany(ctx, []any{"key1", "key2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, [][]any{{"del", "gocache_key_tags_key1", "gocache_key_tags_key2"}}, pipe.writes)
+}
+
+func TestRedisIncrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().IncrBy(ctx, "my-counter", int64(2)).Return(redis.NewIntResult(7, nil))
+
+	store := NewRedis(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), value)
+}
+
+func TestRedisDecrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().DecrBy(ctx, "my-counter", int64(2)).Return(redis.NewIntResult(-2, nil))
+
+	store := NewRedis(client)
+
+	// When
+	value, err := store.Decrement(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-2), value)
+}
+
+func TestRedisSetIfNotExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := &tagsPipeliner{}
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().SetNX(ctx, "my-key", "my-value", 5*time.Second).Return(redis.NewBoolResult(true, nil))
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	})
+
+	store := NewRedis(client, lib_store.WithExpiration(5*time.Second))
+
+	// When
+	set, err := store.SetIfNotExists(ctx, "my-key", "my-value", lib_store.WithTags([]string{"tag1"}))
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, set)
+	assert.Equal(t, [][]any{
+		{"sadd", "gocache_tag_tag1", "my-key"},
+		{"expire", "gocache_tag_tag1", 720 * time.Hour},
+		{"sadd", "gocache_key_tags_my-key", "tag1"},
+		{"expire", "gocache_key_tags_my-key", 720 * time.Hour},
+	}, pipe.writes)
+}
+
+func TestRedisSetIfNotExistsWhenKeyExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().SetNX(ctx, "my-key", "my-value", 5*time.Second).Return(redis.NewBoolResult(false, nil))
+
+	store := NewRedis(client, lib_store.WithExpiration(5*time.Second))
+
+	// When
+	set, err := store.SetIfNotExists(ctx, "my-key", "my-value", lib_store.WithTags([]string{"tag1"}))
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, set)
+}
+
+func TestRedisCompareAndSwap(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Eval(ctx, compareAndSwapScript, []string{"my-key"}, "old-value", "new-value", int64(5000)).
+		Return(redis.NewCmdResult(int64(1), nil))
+
+	store := NewRedis(client, lib_store.WithExpiration(5*time.Second))
+
+	// When
+	swapped, err := store.CompareAndSwap(ctx, "my-key", "old-value", "new-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, swapped)
+}
+
+func TestRedisCompareAndSwapWhenValueDiffers(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Eval(ctx, compareAndSwapScript, []string{"my-key"}, "old-value", "new-value", int64(0)).
+		Return(redis.NewCmdResult(int64(0), nil))
+
+	store := NewRedis(client)
+
+	// When
+	swapped, err := store.CompareAndSwap(ctx, "my-key", "old-value", "new-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, swapped)
+}
+
+func TestRedisCompareAndDelete(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := &tagsPipeliner{}
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Eval(ctx, compareAndDeleteScript, []string{"my-key"}, "old-value").
+		Return(redis.NewCmdResult(int64(1), nil))
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	}).Times(2)
+
+	store := NewRedis(client)
+
+	// When
+	deleted, err := store.CompareAndDelete(ctx, "my-key", "old-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, deleted)
+}
+
 func TestRedisClear(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -181,3 +645,15 @@ func TestRedisGetType(t *testing.T) {
 	// When - Then
 	assert.Equal(t, RedisType, store.GetType())
 }
+
+func TestRedisGetDefaultExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	client := NewMockRedisClientInterface(ctrl)
+
+	store := NewRedis(client, lib_store.WithExpiration(6*time.Second))
+
+	// When - Then
+	assert.Equal(t, 6*time.Second, store.GetDefaultExpiration())
+}
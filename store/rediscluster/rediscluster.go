@@ -19,6 +19,19 @@ type RedisClusterClientInterface interface {
 	FlushAll(ctx context.Context) *redis.StatusCmd
 	SAdd(ctx context.Context, key string, members ...any) *redis.IntCmd
 	SMembers(ctx context.Context, key string) *redis.StringSliceCmd
+	SRem(ctx context.Context, key string, members ...any) *redis.IntCmd
+	IncrBy(ctx context.Context, key string, value int64) *redis.IntCmd
+	DecrBy(ctx context.Context, key string, decrement int64) *redis.IntCmd
+	SetNX(ctx context.Context, key string, value any, expiration time.Duration) *redis.BoolCmd
+	Eval(ctx context.Context, script string, keys []string, args ...any) *redis.Cmd
+	Pipelined(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error)
+	ForEachMaster(ctx context.Context, fn func(ctx context.Context, client *redis.Client) error) error
+}
+
+// keyScanner represents a client able to scan the keys of a single cluster node
+type keyScanner interface {
+	Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd
+	ScanType(ctx context.Context, cursor uint64, match string, count int64, keyType string) *redis.ScanCmd
 }
 
 const (
@@ -26,19 +39,46 @@ const (
 	RedisClusterType = "rediscluster"
 	// RedisTagPattern represents the tag pattern to be used as a key in specified storage
 	RedisClusterTagPattern = "gocache_tag_%s"
+
+	// scanCount is the number of keys each SCAN call is hinted to return
+	scanCount = 100
+)
+
+const (
+	// compareAndSwapScript sets the new value (ARGV[2]) with an optional expiration
+	// in milliseconds (ARGV[3]) only if the current value equals ARGV[1]
+	compareAndSwapScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then
+	if tonumber(ARGV[3]) > 0 then
+		redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+	else
+		redis.call("SET", KEYS[1], ARGV[2])
+	end
+	return 1
+end
+return 0`
+
+	// compareAndDeleteScript removes the key only if its current value equals ARGV[1]
+	compareAndDeleteScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`
 )
 
 // RedisStore is a store for Redis
 type RedisClusterStore struct {
 	clusclient RedisClusterClientInterface
 	options    *lib_store.Options
+	tagTTL     time.Duration
 }
 
 // NewRedis creates a new store to Redis instance(s)
 func NewRedisCluster(client RedisClusterClientInterface, options ...lib_store.Option) *RedisClusterStore {
+	opts := lib_store.ApplyOptions(options...)
+
 	return &RedisClusterStore{
 		clusclient: client,
-		options:    lib_store.ApplyOptions(options...),
+		options:    opts,
+		tagTTL:     lib_store.ApplyTagIndexOptions(opts.TagIndex...).TTL,
 	}
 }
 
@@ -79,26 +119,293 @@ func (s *RedisClusterStore) Set(ctx context.Context, key any, value any, options
 	}
 
 	if tags := opts.Tags; len(tags) > 0 {
-		s.setTags(ctx, key, tags)
+		s.setTags(ctx, tags, key.(string))
 	}
 
 	return nil
 }
 
-func (s *RedisClusterStore) setTags(ctx context.Context, key any, tags []string) {
+// setTags associates the given keys to the tags in a single round trip using a pipeline
+func (s *RedisClusterStore) setTags(ctx context.Context, tags []string, keys ...string) {
+	members := make([]any, 0, len(keys))
+	for _, key := range keys {
+		members = append(members, key)
+	}
+
+	tagMembers := make([]any, 0, len(tags))
 	for _, tag := range tags {
-		tagKey := fmt.Sprintf(RedisClusterTagPattern, tag)
-		s.clusclient.SAdd(ctx, tagKey, key.(string))
-		s.clusclient.Expire(ctx, tagKey, 720*time.Hour)
+		tagMembers = append(tagMembers, tag)
+	}
+
+	s.clusclient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, tag := range tags {
+			tagKey := fmt.Sprintf(RedisClusterTagPattern, tag)
+			pipe.SAdd(ctx, tagKey, members...)
+			s.expireTags(ctx, pipe, tagKey)
+		}
+
+		for _, key := range keys {
+			keyTagsKey := fmt.Sprintf(lib_store.KeyTagsPattern, key)
+			pipe.SAdd(ctx, keyTagsKey, tagMembers...)
+			s.expireTags(ctx, pipe, keyTagsKey)
+		}
+		return nil
+	})
+}
+
+func (s *RedisClusterStore) expireTags(ctx context.Context, pipe redis.Pipeliner, key string) {
+	if s.tagTTL > 0 {
+		pipe.Expire(ctx, key, s.tagTTL)
 	}
 }
 
-// Delete removes data from Redis for given key identifier
+// removeTags dissociates the given deleted keys from their tags in two round trips:
+// the tags of the keys are read using a pipeline, then the keys are removed from
+// their tag sets using a second one, which is split by the client across cluster nodes
+func (s *RedisClusterStore) removeTags(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	cmds := make([]*redis.StringSliceCmd, 0, len(keys))
+
+	_, err := s.clusclient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			cmds = append(cmds, pipe.SMembers(ctx, fmt.Sprintf(lib_store.KeyTagsPattern, key)))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.clusclient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, cmd := range cmds {
+			for _, tag := range cmd.Val() {
+				pipe.SRem(ctx, fmt.Sprintf(RedisClusterTagPattern, tag), keys[i])
+			}
+			pipe.Del(ctx, fmt.Sprintf(lib_store.KeyTagsPattern, keys[i]))
+		}
+		return nil
+	})
+
+	return err
+}
+
+// Delete removes data from Redis for given key identifier, and dissociates it from its tags
 func (s *RedisClusterStore) Delete(ctx context.Context, key any) error {
-	_, err := s.clusclient.Del(ctx, key.(string)).Result()
+	if _, err := s.clusclient.Del(ctx, key.(string)).Result(); err != nil {
+		return err
+	}
+
+	return s.removeTags(ctx, key.(string))
+}
+
+// KeysForTag returns the keys associated to the given tag
+func (s *RedisClusterStore) KeysForTag(ctx context.Context, tag string) ([]string, error) {
+	return s.clusclient.SMembers(ctx, fmt.Sprintf(RedisClusterTagPattern, tag)).Result()
+}
+
+// TagsForKey returns the tags associated to the given key
+func (s *RedisClusterStore) TagsForKey(ctx context.Context, key any) ([]string, error) {
+	return s.clusclient.SMembers(ctx, fmt.Sprintf(lib_store.KeyTagsPattern, key.(string))).Result()
+}
+
+// PruneTags dissociates the keys which do not exist anymore, such as the expired or
+// evicted ones, from their tags. Tag sets are found on each master node using SCAN
+// with the TYPE option, which requires Redis 6.0 or later.
+func (s *RedisClusterStore) PruneTags(ctx context.Context) error {
+	return s.clusclient.ForEachMaster(ctx, func(ctx context.Context, client *redis.Client) error {
+		return s.pruneTags(ctx, client)
+	})
+}
+
+func (s *RedisClusterStore) pruneTags(ctx context.Context, scanner keyScanner) error {
+	var cursor uint64
+	for {
+		tagKeys, next, err := scanner.ScanType(ctx, cursor, fmt.Sprintf(RedisClusterTagPattern, "*"), scanCount, "set").Result()
+		if err != nil {
+			return err
+		}
+
+		for _, tagKey := range tagKeys {
+			if err := s.pruneTag(ctx, tagKey); err != nil {
+				return err
+			}
+		}
+
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+func (s *RedisClusterStore) pruneTag(ctx context.Context, tagKey string) error {
+	cacheKeys, err := s.clusclient.SMembers(ctx, tagKey).Result()
+	if err != nil || len(cacheKeys) == 0 {
+		return err
+	}
+
+	cmds := make([]*redis.IntCmd, 0, len(cacheKeys))
+
+	_, err = s.clusclient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, cacheKey := range cacheKeys {
+			cmds = append(cmds, pipe.Exists(ctx, cacheKey))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var missing []any
+	for i, cmd := range cmds {
+		if cmd.Val() == 0 {
+			missing = append(missing, cacheKeys[i])
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if err := s.clusclient.SRem(ctx, tagKey, missing...).Err(); err != nil {
+		return err
+	}
+
+	// The missing keys are removed from their other tags when these are pruned
+	_, err = s.clusclient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, cacheKey := range missing {
+			pipe.Del(ctx, fmt.Sprintf(lib_store.KeyTagsPattern, cacheKey))
+		}
+		return nil
+	})
+
 	return err
 }
 
+// GetMany returns data stored from given keys using a pipeline, which is split
+// by the client across cluster nodes. Keys that cannot be found are absent from the returned map.
+func (s *RedisClusterStore) GetMany(ctx context.Context, keys []any) (map[any]any, error) {
+	cmds := make([]*redis.StringCmd, 0, len(keys))
+
+	_, err := s.clusclient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			cmds = append(cmds, pipe.Get(ctx, key.(string)))
+		}
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	objects := make(map[any]any, len(cmds))
+	for i, cmd := range cmds {
+		if value, err := cmd.Result(); err == nil {
+			objects[keys[i]] = value
+		}
+	}
+
+	return objects, nil
+}
+
+// SetMany defines data in Redis for given key identifiers using a pipeline
+func (s *RedisClusterStore) SetMany(ctx context.Context, items map[any]any, options ...lib_store.Option) error {
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+
+	_, err := s.clusclient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, value := range items {
+			pipe.Set(ctx, key.(string), value, opts.Expiration)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if tags := opts.Tags; len(tags) > 0 {
+		keys := make([]string, 0, len(items))
+		for key := range items {
+			keys = append(keys, key.(string))
+		}
+		s.setTags(ctx, tags, keys...)
+	}
+
+	return nil
+}
+
+// DeleteMany removes data from Redis for given key identifiers using a pipeline,
+// as keys may belong to different hash slots
+func (s *RedisClusterStore) DeleteMany(ctx context.Context, keys []any) error {
+	cacheKeys := make([]string, 0, len(keys))
+
+	_, err := s.clusclient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Del(ctx, key.(string))
+			cacheKeys = append(cacheKeys, key.(string))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.removeTags(ctx, cacheKeys...)
+}
+
+// Increment atomically adds delta to the counter stored at the given key using INCRBY
+func (s *RedisClusterStore) Increment(ctx context.Context, key any, delta int64) (int64, error) {
+	return s.clusclient.IncrBy(ctx, key.(string), delta).Result()
+}
+
+// Decrement atomically subtracts delta from the counter stored at the given key using DECRBY
+func (s *RedisClusterStore) Decrement(ctx context.Context, key any, delta int64) (int64, error) {
+	return s.clusclient.DecrBy(ctx, key.(string), delta).Result()
+}
+
+// SetIfNotExists defines data in Redis only if the given key does not exist yet using SETNX
+func (s *RedisClusterStore) SetIfNotExists(ctx context.Context, key any, value any, options ...lib_store.Option) (bool, error) {
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+
+	set, err := s.clusclient.SetNX(ctx, key.(string), value, opts.Expiration).Result()
+	if err != nil || !set {
+		return false, err
+	}
+
+	if tags := opts.Tags; len(tags) > 0 {
+		s.setTags(ctx, tags, key.(string))
+	}
+
+	return true, nil
+}
+
+// CompareAndSwap replaces data in Redis only if the current value equals the old one
+func (s *RedisClusterStore) CompareAndSwap(ctx context.Context, key any, oldValue any, newValue any, options ...lib_store.Option) (bool, error) {
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+
+	swapped, err := s.clusclient.Eval(ctx, compareAndSwapScript, []string{key.(string)}, oldValue, newValue, opts.Expiration.Milliseconds()).Int64()
+	if err != nil || swapped == 0 {
+		return false, err
+	}
+
+	if tags := opts.Tags; len(tags) > 0 {
+		s.setTags(ctx, tags, key.(string))
+	}
+
+	return true, nil
+}
+
+// CompareAndDelete removes data from Redis only if the current value equals the old one
+func (s *RedisClusterStore) CompareAndDelete(ctx context.Context, key any, oldValue any) (bool, error) {
+	deleted, err := s.clusclient.Eval(ctx, compareAndDeleteScript, []string{key.(string)}, oldValue).Int64()
+	if err != nil || deleted == 0 {
+		return false, err
+	}
+
+	return true, s.removeTags(ctx, key.(string))
+}
+
 // Invalidate invalidates some cache data in Redis for given options
 func (s *RedisClusterStore) Invalidate(ctx context.Context, options ...lib_store.InvalidateOption) error {
 	opts := lib_store.ApplyInvalidateOptions(options...)
@@ -108,14 +415,69 @@ func (s *RedisClusterStore) Invalidate(ctx context.Context, options ...lib_store
 			tagKey := fmt.Sprintf(RedisClusterTagPattern, tag)
 			cacheKeys, err := s.clusclient.SMembers(ctx, tagKey).Result()
 			if err != nil {
-				continue
+				return err
 			}
 
-			for _, cacheKey := range cacheKeys {
-				s.Delete(ctx, cacheKey)
+			// Keys are deleted one by one as they may belong to different hash slots
+			_, err = s.clusclient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+				for _, cacheKey := range cacheKeys {
+					pipe.Del(ctx, cacheKey)
+				}
+				pipe.Del(ctx, tagKey)
+				return nil
+			})
+			if err != nil {
+				return err
 			}
 
-			s.Delete(ctx, tagKey)
+			if err := s.removeTags(ctx, cacheKeys...); err != nil {
+				return err
+			}
+		}
+	}
+
+	if patterns := opts.KeyPatterns(); len(patterns) > 0 {
+		return s.clusclient.ForEachMaster(ctx, func(ctx context.Context, client *redis.Client) error {
+			return s.invalidatePatterns(ctx, client, patterns)
+		})
+	}
+
+	return nil
+}
+
+// invalidatePatterns deletes the keys of a master node matching the given patterns,
+// which are found using SCAN and removed using UNLINK so Redis is not blocked, and
+// dissociates them from their tags
+func (s *RedisClusterStore) invalidatePatterns(ctx context.Context, scanner keyScanner, patterns []string) error {
+	for _, pattern := range patterns {
+		var cursor uint64
+		for {
+			cacheKeys, next, err := scanner.Scan(ctx, cursor, pattern, scanCount).Result()
+			if err != nil {
+				return err
+			}
+
+			if len(cacheKeys) > 0 {
+				// Keys are unlinked one by one as they may belong to different hash slots
+				_, err := s.clusclient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+					for _, cacheKey := range cacheKeys {
+						pipe.Unlink(ctx, cacheKey)
+					}
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+
+				if err := s.removeTags(ctx, cacheKeys...); err != nil {
+					return err
+				}
+			}
+
+			if next == 0 {
+				break
+			}
+			cursor = next
 		}
 	}
 
@@ -135,3 +497,8 @@ func (s *RedisClusterStore) Clear(ctx context.Context) error {
 func (s *RedisClusterStore) GetType() string {
 	return RedisClusterType
 }
+
+// GetDefaultExpiration returns the expiration applied to the values written without one
+func (s *RedisClusterStore) GetDefaultExpiration() time.Duration {
+	return s.options.Expiration
+}
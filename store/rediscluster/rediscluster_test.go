@@ -96,10 +96,13 @@ func TestRedisClusterSetWithTags(t *testing.T) {
 	cacheKey := "my-key"
 	cacheValue := "my-cache-value"
 
+	pipe := &tagsPipeliner{}
+
 	client := NewMockRedisClusterClientInterface(ctrl)
 	client.EXPECT().Set(ctx, cacheKey, cacheValue, time.Duration(0)).Return(&redis.StatusCmd{})
-	client.EXPECT().SAdd(ctx, "gocache_tag_tag1", "my-key").Return(&redis.IntCmd{})
-	client.EXPECT().Expire(ctx, "gocache_tag_tag1", 720*time.Hour).Return(&redis.BoolCmd{})
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	})
 
 	store := NewRedisCluster(client)
 
@@ -108,6 +111,12 @@ func TestRedisClusterSetWithTags(t *testing.T) {
 
 	// Then
 	assert.Nil(t, err)
+	assert.Equal(t, [][]any{
+		{"sadd", "gocache_tag_tag1", "my-key"},
+		{"expire", "gocache_tag_tag1", 720 * time.Hour},
+		{"sadd", "gocache_key_tags_my-key", "tag1"},
+		{"expire", "gocache_key_tags_my-key", 720 * time.Hour},
+	}, pipe.writes)
 }
 
 func TestRedisClusterDelete(t *testing.T) {
@@ -118,8 +127,13 @@ func TestRedisClusterDelete(t *testing.T) {
 
 	cacheKey := "my-key"
 
+	pipe := &tagsPipeliner{}
+
 	client := NewMockRedisClusterClientInterface(ctrl)
 	client.EXPECT().Del(ctx, "my-key").Return(&redis.IntCmd{})
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	}).Times(2)
 
 	store := NewRedisCluster(client)
 
@@ -128,6 +142,141 @@ func TestRedisClusterDelete(t *testing.T) {
 
 	// Then
 	assert.Nil(t, err)
+	assert.Equal(t, [][]any{{"del", "gocache_key_tags_my-key"}}, pipe.writes)
+}
+
+func TestRedisClusterDeleteWithTags(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+
+	pipe := &tagsPipeliner{tags: map[string][]string{"gocache_key_tags_my-key": {"tag1", "tag2"}}}
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Del(ctx, "my-key").Return(&redis.IntCmd{})
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	}).Times(2)
+
+	store := NewRedisCluster(client)
+
+	// When
+	err := store.Delete(ctx, cacheKey)
+
+	// Then the key is dissociated from its tags
+	assert.Nil(t, err)
+	assert.Equal(t, [][]any{
+		{"srem", "gocache_tag_tag1", "my-key"},
+		{"srem", "gocache_tag_tag2", "my-key"},
+		{"del", "gocache_key_tags_my-key"},
+	}, pipe.writes)
+}
+
+type scanner struct {
+	keys []string
+}
+
+func (s *scanner) Scan(_ context.Context, _ uint64, _ string, _ int64) *redis.ScanCmd {
+	return redis.NewScanCmdResult(s.keys, 0, nil)
+}
+
+func (s *scanner) ScanType(_ context.Context, _ uint64, _ string, _ int64, _ string) *redis.ScanCmd {
+	return redis.NewScanCmdResult(s.keys, 0, nil)
+}
+
+// tagsPipeliner answers the commands reading the tag index and the existence of keys,
+// and records the commands writing to it
+type tagsPipeliner struct {
+	redis.Pipeliner
+	tags   map[string][]string
+	keys   map[string]struct{}
+	writes [][]any
+}
+
+func (p *tagsPipeliner) SMembers(_ context.Context, key string) *redis.StringSliceCmd {
+	return redis.NewStringSliceResult(p.tags[key], nil)
+}
+
+func (p *tagsPipeliner) Exists(_ context.Context, keys ...string) *redis.IntCmd {
+	if _, ok := p.keys[keys[0]]; ok {
+		return redis.NewIntResult(1, nil)
+	}
+	return redis.NewIntResult(0, nil)
+}
+
+func (p *tagsPipeliner) SAdd(_ context.Context, key string, members ...any) *redis.IntCmd {
+	p.writes = append(p.writes, append([]any{"sadd", key}, members...))
+	return &redis.IntCmd{}
+}
+
+func (p *tagsPipeliner) Expire(_ context.Context, key string, expiration time.Duration) *redis.BoolCmd {
+	p.writes = append(p.writes, []any{"expire", key, expiration})
+	return &redis.BoolCmd{}
+}
+
+func (p *tagsPipeliner) SRem(_ context.Context, key string, members ...any) *redis.IntCmd {
+	p.writes = append(p.writes, append([]any{"srem", key}, members...))
+	return &redis.IntCmd{}
+}
+
+func (p *tagsPipeliner) Del(_ context.Context, keys ...string) *redis.IntCmd {
+	write := []any{"del"}
+	for _, key := range keys {
+		write = append(write, key)
+	}
+	p.writes = append(p.writes, write)
+	return &redis.IntCmd{}
+}
+
+func TestRedisClusterKeysForTag(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().SMembers(ctx, "gocache_tag_tag1").Return(redis.NewStringSliceResult([]string{"my-key", "a-second-key"}, nil))
+	client.EXPECT().SMembers(ctx, "gocache_key_tags_my-key").Return(redis.NewStringSliceResult([]string{"tag1", "tag2"}, nil))
+
+	store := NewRedisCluster(client)
+
+	// When
+	keys, keysErr := store.KeysForTag(ctx, "tag1")
+	tags, tagsErr := store.TagsForKey(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, keysErr)
+	assert.Equal(t, []string{"my-key", "a-second-key"}, keys)
+	assert.Nil(t, tagsErr)
+	assert.Equal(t, []string{"tag1", "tag2"}, tags)
+}
+
+func TestRedisClusterPruneTags(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := &tagsPipeliner{keys: map[string]struct{}{"a-second-key": {}}}
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().SMembers(ctx, "gocache_tag_tag1").Return(redis.NewStringSliceResult([]string{"my-key", "a-second-key"}, nil))
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	}).Times(2)
+	client.EXPECT().SRem(ctx, "gocache_tag_tag1", "my-key").Return(&redis.IntCmd{})
+
+	store := NewRedisCluster(client)
+
+	// When the tags of a master node are pruned
+	err := store.pruneTags(ctx, &scanner{keys: []string{"gocache_tag_tag1"}})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, [][]any{{"del", "gocache_key_tags_my-key"}}, pipe.writes)
 }
 
 func TestRedisClusterInvalidate(t *testing.T) {
@@ -136,19 +285,310 @@ func TestRedisClusterInvalidate(t *testing.T) {
 
 	ctx := context.Background()
 
-	cacheKeys := &redis.StringSliceCmd{}
+	pipe := &tagsPipeliner{tags: map[string][]string{"gocache_key_tags_my-key": {"tag1", "tag2"}}}
 
 	client := NewMockRedisClusterClientInterface(ctrl)
-	client.EXPECT().SMembers(ctx, "gocache_tag_tag1").Return(cacheKeys)
-	client.EXPECT().Del(ctx, "gocache_tag_tag1").Return(&redis.IntCmd{})
+	client.EXPECT().SMembers(ctx, "gocache_tag_tag1").Return(redis.NewStringSliceResult([]string{"my-key", "a-second-key"}, nil))
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	}).Times(3)
 
 	store := NewRedisCluster(client)
 
 	// When
 	err := store.Invalidate(ctx, lib_store.WithInvalidateTags([]string{"tag1"}))
 
+	// Then the keys are deleted in bulk and dissociated from their other tags
+	assert.Nil(t, err)
+	assert.Equal(t, [][]any{
+		{"del", "my-key"},
+		{"del", "a-second-key"},
+		{"del", "gocache_tag_tag1"},
+		{"srem", "gocache_tag_tag1", "my-key"},
+		{"srem", "gocache_tag_tag2", "my-key"},
+		{"del", "gocache_key_tags_my-key"},
+		{"del", "gocache_key_tags_a-second-key"},
+	}, pipe.writes)
+}
+
+func TestRedisClusterInvalidateWithPrefix(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := redis.NewClusterClient(&redis.ClusterOptions{}).Pipeline()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	}).Times(3)
+
+	store := NewRedisCluster(client)
+
+	// When the keys of a master node are invalidated
+	opts := lib_store.ApplyInvalidateOptions(lib_store.WithInvalidatePrefix("tenant:17:"))
+	err := store.invalidatePatterns(ctx, &scanner{keys: []string{"tenant:17:key1", "tenant:17:key2"}}, opts.KeyPatterns())
+
+	// Then the keys are unlinked, their tags read and their tag index entries deleted
+	assert.Nil(t, err)
+	assert.Equal(t, 6, pipe.Len())
+}
+
+func TestRedisClusterInvalidateWithPattern(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().ForEachMaster(ctx, gomock.Any()).Return(nil)
+
+	store := NewRedisCluster(client)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidatePattern("session:*"))
+
+	// Then the keys are scanned on each master node
+	assert.Nil(t, err)
+}
+
+type getPipeliner struct {
+	redis.Pipeliner
+	values map[string]string
+}
+
+func (p *getPipeliner) Get(_ context.Context, key string) *redis.StringCmd {
+	if value, ok := p.values[key]; ok {
+		return redis.NewStringResult(value, nil)
+	}
+	return redis.NewStringResult("", redis.Nil)
+}
+
+func TestRedisClusterGetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := &getPipeliner{values: map[string]string{"key1": "value1", "key3": "value3"}}
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	})
+
+	store := NewRedisCluster(client)
+
+	// When
+	values, err := store.GetMany(ctx, []any{"key1", "key2", "key3"})
+
 	// Then
 	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key1": "value1", "key3": "value3"}, values)
+}
+
+func TestRedisClusterSetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := redis.NewClusterClient(&redis.ClusterOptions{}).Pipeline()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	})
+
+	store := NewRedisCluster(client)
+
+	// When
+	err := store.SetMany(ctx, map[any]any{"key1": "value1", "key2": "value2"}, lib_store.WithExpiration(5*time.Second))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 2, pipe.Len())
+}
+
+func TestRedisClusterDeleteMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := &tagsPipeliner{}
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	}).Times(3)
+
+	store := NewRedisCluster(client)
+
+	// When
+	err := store.DeleteMany(ctx, []any{"key1", "key2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, [][]any{
+		{"del", "key1"},
+		{"del", "key2"},
+		{"del", "gocache_key_tags_key1"},
+		{"del", "gocache_key_tags_key2"},
+	}, pipe.writes)
+}
+
+func TestRedisClusterIncrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().IncrBy(ctx, "my-counter", int64(2)).Return(redis.NewIntResult(7, nil))
+
+	store := NewRedisCluster(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), value)
+}
+
+func TestRedisClusterDecrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().DecrBy(ctx, "my-counter", int64(2)).Return(redis.NewIntResult(-2, nil))
+
+	store := NewRedisCluster(client)
+
+	// When
+	value, err := store.Decrement(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-2), value)
+}
+
+func TestRedisClusterSetIfNotExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := &tagsPipeliner{}
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().SetNX(ctx, "my-key", "my-value", 5*time.Second).Return(redis.NewBoolResult(true, nil))
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	})
+
+	store := NewRedisCluster(client, lib_store.WithExpiration(5*time.Second))
+
+	// When
+	set, err := store.SetIfNotExists(ctx, "my-key", "my-value", lib_store.WithTags([]string{"tag1"}))
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, set)
+	assert.Equal(t, [][]any{
+		{"sadd", "gocache_tag_tag1", "my-key"},
+		{"expire", "gocache_tag_tag1", 720 * time.Hour},
+		{"sadd", "gocache_key_tags_my-key", "tag1"},
+		{"expire", "gocache_key_tags_my-key", 720 * time.Hour},
+	}, pipe.writes)
+}
+
+func TestRedisClusterSetIfNotExistsWhenKeyExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().SetNX(ctx, "my-key", "my-value", 5*time.Second).Return(redis.NewBoolResult(false, nil))
+
+	store := NewRedisCluster(client, lib_store.WithExpiration(5*time.Second))
+
+	// When
+	set, err := store.SetIfNotExists(ctx, "my-key", "my-value", lib_store.WithTags([]string{"tag1"}))
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, set)
+}
+
+func TestRedisClusterCompareAndSwap(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Eval(ctx, compareAndSwapScript, []string{"my-key"}, "old-value", "new-value", int64(5000)).
+		Return(redis.NewCmdResult(int64(1), nil))
+
+	store := NewRedisCluster(client, lib_store.WithExpiration(5*time.Second))
+
+	// When
+	swapped, err := store.CompareAndSwap(ctx, "my-key", "old-value", "new-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, swapped)
+}
+
+func TestRedisClusterCompareAndSwapWhenValueDiffers(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Eval(ctx, compareAndSwapScript, []string{"my-key"}, "old-value", "new-value", int64(0)).
+		Return(redis.NewCmdResult(int64(0), nil))
+
+	store := NewRedisCluster(client)
+
+	// When
+	swapped, err := store.CompareAndSwap(ctx, "my-key", "old-value", "new-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, swapped)
+}
+
+func TestRedisClusterCompareAndDelete(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := &tagsPipeliner{}
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Eval(ctx, compareAndDeleteScript, []string{"my-key"}, "old-value").
+		Return(redis.NewCmdResult(int64(1), nil))
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	}).Times(2)
+
+	store := NewRedisCluster(client)
+
+	// When
+	deleted, err := store.CompareAndDelete(ctx, "my-key", "old-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, deleted)
 }
 
 func TestRedisClusterClear(t *testing.T) {
@@ -180,3 +620,15 @@ func TestRedisClusterGetType(t *testing.T) {
 	// When - Then
 	assert.Equal(t, RedisClusterType, store.GetType())
 }
+
+func TestRedisClusterGetDefaultExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+
+	store := NewRedisCluster(client, lib_store.WithExpiration(6*time.Second))
+
+	// When - Then
+	assert.Equal(t, 6*time.Second, store.GetDefaultExpiration())
+}
@@ -4,7 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
+	"reflect"
+	"sync"
 	"time"
 
 	lib_store "github.com/eko/gocache/lib/v4/store"
@@ -23,20 +24,62 @@ type RistrettoClientInterface interface {
 	SetWithTTL(key, value any, cost int64, ttl time.Duration) bool
 	Del(key any)
 	Clear()
+	GetTTL(key any) (time.Duration, bool)
+	Wait()
 }
 
 // RistrettoStore is a store for Ristretto (memory) library
 type RistrettoStore struct {
+	// mu serializes the writes of values so the atomic operations, which are implemented
+	// as read-modify-write sequences, cannot interleave with them
+	mu      sync.Mutex
 	client  RistrettoClientInterface
 	options *lib_store.Options
+	tags    *lib_store.TagIndex
 }
 
 // NewRistretto creates a new store to Ristretto (memory) library instance
 func NewRistretto(client RistrettoClientInterface, options ...lib_store.Option) *RistrettoStore {
+	opts := lib_store.ApplyOptions(options...)
+	storage := &ristrettoTagIndexStorage{client: client, cost: opts.Cost}
+
 	return &RistrettoStore{
 		client:  client,
-		options: lib_store.ApplyOptions(options...),
+		options: opts,
+		tags:    lib_store.NewTagIndex(storage, RistrettoTagPattern, opts.TagIndex...),
+	}
+}
+
+// ristrettoTagIndexStorage keeps the tag index entries in Ristretto, waiting for
+// them to be written so they can be read right away
+type ristrettoTagIndexStorage struct {
+	client RistrettoClientInterface
+	cost   int64
+}
+
+func (s *ristrettoTagIndexStorage) Load(_ context.Context, key string) ([]byte, any, error) {
+	value, exists := s.client.Get(key)
+	if !exists {
+		return nil, nil, nil
+	}
+
+	data, _ := value.([]byte)
+
+	return data, nil, nil
+}
+
+func (s *ristrettoTagIndexStorage) Save(_ context.Context, key string, data []byte, _ any, ttl time.Duration) (bool, error) {
+	if set := s.client.SetWithTTL(key, data, s.cost, ttl); !set {
+		return false, fmt.Errorf("An error has occurred while setting tag index entry '%v'", key)
 	}
+	s.client.Wait()
+
+	return true, nil
+}
+
+func (s *ristrettoTagIndexStorage) Delete(_ context.Context, key string) error {
+	s.client.Del(key)
+	return nil
 }
 
 // Get returns data stored from a given key
@@ -59,77 +102,186 @@ func (s *RistrettoStore) GetWithTTL(ctx context.Context, key any) (any, time.Dur
 
 // Set defines data in Ristretto memoey cache for given key identifier
 func (s *RistrettoStore) Set(ctx context.Context, key any, value any, options ...lib_store.Option) error {
-	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	var err error
+	return s.set(ctx, key, value, options...)
+}
+
+// set writes the value and waits for it to be applied so a following atomic operation
+// reads it. The caller must hold mu.
+func (s *RistrettoStore) set(ctx context.Context, key any, value any, options ...lib_store.Option) error {
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
 
 	if set := s.client.SetWithTTL(key, value, opts.Cost, opts.Expiration); !set {
-		err = fmt.Errorf("An error has occurred while setting value '%v' on key '%v'", value, key)
+		return fmt.Errorf("An error has occurred while setting value '%v' on key '%v'", value, key)
 	}
+	s.client.Wait()
 
-	if err != nil {
-		return err
+	if tags := opts.Tags; len(tags) > 0 {
+		return s.tags.Add(ctx, key.(string), tags)
 	}
 
-	if tags := opts.Tags; len(tags) > 0 {
-		s.setTags(ctx, key, tags)
+	return nil
+}
+
+// Delete removes data in Ristretto memoey cache for given key identifier
+func (s *RistrettoStore) Delete(ctx context.Context, key any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.client.Del(key)
+	return s.removeTags(ctx, key)
+}
+
+// removeTags dissociates the given deleted key from its tags
+func (s *RistrettoStore) removeTags(ctx context.Context, key any) error {
+	if k, ok := key.(string); ok {
+		return s.tags.RemoveKeys(ctx, []string{k})
 	}
 
 	return nil
 }
 
-func (s *RistrettoStore) setTags(ctx context.Context, key any, tags []string) {
-	for _, tag := range tags {
-		tagKey := fmt.Sprintf(RistrettoTagPattern, tag)
-		cacheKeys := []string{}
+// KeysForTag returns the keys associated to the given tag
+func (s *RistrettoStore) KeysForTag(ctx context.Context, tag string) ([]string, error) {
+	return s.tags.Keys(ctx, tag)
+}
 
-		if result, err := s.Get(ctx, tagKey); err == nil {
-			if bytes, ok := result.([]byte); ok {
-				cacheKeys = strings.Split(string(bytes), ",")
-			}
-		}
+// TagsForKey returns the tags associated to the given key
+func (s *RistrettoStore) TagsForKey(ctx context.Context, key any) ([]string, error) {
+	if k, ok := key.(string); ok {
+		return s.tags.KeyTags(ctx, k)
+	}
 
-		alreadyInserted := false
-		for _, cacheKey := range cacheKeys {
-			if cacheKey == key.(string) {
-				alreadyInserted = true
-				break
+	// Only string keys are associated to tags
+	return nil, nil
+}
+
+// PruneTags dissociates the keys which do not exist anymore, such as the expired or
+// evicted ones, from their tags
+func (s *RistrettoStore) PruneTags(ctx context.Context) error {
+	return s.tags.Prune(ctx, func(keys []string) ([]string, error) {
+		var missing []string
+		for _, key := range keys {
+			if _, exists := s.client.Get(key); !exists {
+				missing = append(missing, key)
 			}
 		}
 
-		if !alreadyInserted {
-			cacheKeys = append(cacheKeys, key.(string))
+		return missing, nil
+	})
+}
+
+// Increment atomically adds delta to the counter stored at the given key.
+// The counter keeps its remaining TTL or uses the store default expiration when created.
+func (s *RistrettoStore) Increment(_ context.Context, key any, delta int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var counter int64
+	expiration := s.options.Expiration
+
+	if value, exists := s.client.Get(key); exists {
+		var err error
+		if counter, err = lib_store.ToInt64(value); err != nil {
+			return 0, err
 		}
+		if ttl, ok := s.client.GetTTL(key); ok {
+			expiration = ttl
+		}
+	}
 
-		s.Set(ctx, tagKey, []byte(strings.Join(cacheKeys, ",")), lib_store.WithExpiration(720*time.Hour))
+	counter += delta
+
+	if set := s.client.SetWithTTL(key, counter, s.options.Cost, expiration); !set {
+		return 0, fmt.Errorf("An error has occurred while setting value '%v' on key '%v'", counter, key)
 	}
+	s.client.Wait()
+
+	return counter, nil
 }
 
-// Delete removes data in Ristretto memoey cache for given key identifier
-func (s *RistrettoStore) Delete(_ context.Context, key any) error {
+// Decrement atomically subtracts delta from the counter stored at the given key
+func (s *RistrettoStore) Decrement(ctx context.Context, key any, delta int64) (int64, error) {
+	return s.Increment(ctx, key, -delta)
+}
+
+// SetIfNotExists defines data in Ristretto memory cache only if the given key does not exist yet
+func (s *RistrettoStore) SetIfNotExists(ctx context.Context, key any, value any, options ...lib_store.Option) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.client.Get(key); exists {
+		return false, nil
+	}
+
+	if err := s.set(ctx, key, value, options...); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// CompareAndSwap replaces data in Ristretto memory cache only if the current value equals the old one
+func (s *RistrettoStore) CompareAndSwap(ctx context.Context, key any, oldValue any, newValue any, options ...lib_store.Option) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if value, exists := s.client.Get(key); !exists || !reflect.DeepEqual(value, oldValue) {
+		return false, nil
+	}
+
+	if err := s.set(ctx, key, newValue, options...); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// CompareAndDelete removes data from Ristretto memory cache only if the current value equals the old one
+func (s *RistrettoStore) CompareAndDelete(ctx context.Context, key any, oldValue any) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if value, exists := s.client.Get(key); !exists || !reflect.DeepEqual(value, oldValue) {
+		return false, nil
+	}
+
 	s.client.Del(key)
-	return nil
+
+	return true, s.removeTags(ctx, key)
 }
 
 // Invalidate invalidates some cache data in Redis for given options
 func (s *RistrettoStore) Invalidate(ctx context.Context, options ...lib_store.InvalidateOption) error {
 	opts := lib_store.ApplyInvalidateOptions(options...)
 
+	if opts.TargetsKeys() {
+		return lib_store.NotSupportedOperation(RistrettoType, "invalidate by prefix or pattern")
+	}
+
 	if tags := opts.Tags; len(tags) > 0 {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
 		for _, tag := range tags {
-			tagKey := fmt.Sprintf(RistrettoTagPattern, tag)
-			result, err := s.Get(ctx, tagKey)
+			cacheKeys, err := s.tags.Keys(ctx, tag)
 			if err != nil {
-				return nil
+				return err
 			}
 
-			cacheKeys := []string{}
-			if bytes, ok := result.([]byte); ok {
-				cacheKeys = strings.Split(string(bytes), ",")
+			for _, cacheKey := range cacheKeys {
+				s.client.Del(cacheKey)
 			}
 
-			for _, cacheKey := range cacheKeys {
-				s.Delete(ctx, cacheKey)
+			if err := s.tags.Remove(ctx, tag, cacheKeys); err != nil {
+				return err
+			}
+
+			// Also dissociate the deleted keys from their other tags
+			if err := s.tags.RemoveKeys(ctx, cacheKeys); err != nil {
+				return err
 			}
 		}
 	}
@@ -139,6 +291,9 @@ func (s *RistrettoStore) Invalidate(ctx context.Context, options ...lib_store.In
 
 // Clear resets all data in the store
 func (s *RistrettoStore) Clear(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.client.Clear()
 	return nil
 }
@@ -147,3 +302,8 @@ func (s *RistrettoStore) Clear(_ context.Context) error {
 func (s *RistrettoStore) GetType() string {
 	return RistrettoType
 }
+
+// GetDefaultExpiration returns the expiration applied to the values written without one
+func (s *RistrettoStore) GetDefaultExpiration() time.Duration {
+	return s.options.Expiration
+}
@@ -2,10 +2,13 @@ package ristretto
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/dgraph-io/ristretto"
 	lib_store "github.com/eko/gocache/lib/v4/store"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -125,6 +128,7 @@ func TestRistrettoSet(t *testing.T) {
 
 	client := NewMockRistrettoClientInterface(ctrl)
 	client.EXPECT().SetWithTTL(cacheKey, cacheValue, int64(4), 0*time.Second).Return(true)
+	client.EXPECT().Wait()
 
 	store := NewRistretto(client, lib_store.WithCost(7))
 
@@ -146,6 +150,7 @@ func TestRistrettoSetWhenNoOptionsGiven(t *testing.T) {
 
 	client := NewMockRistrettoClientInterface(ctrl)
 	client.EXPECT().SetWithTTL(cacheKey, cacheValue, int64(7), 0*time.Second).Return(true)
+	client.EXPECT().Wait()
 
 	store := NewRistretto(client, lib_store.WithCost(7))
 
@@ -188,8 +193,11 @@ func TestRistrettoSetWithTags(t *testing.T) {
 
 	client := NewMockRistrettoClientInterface(ctrl)
 	client.EXPECT().SetWithTTL(cacheKey, cacheValue, int64(0), 0*time.Second).Return(true)
-	client.EXPECT().Get("gocache_tag_tag1").Return(nil, true)
-	client.EXPECT().SetWithTTL("gocache_tag_tag1", []byte("my-key"), int64(0), 720*time.Hour).Return(true)
+	client.EXPECT().Get("gocache_tag_tag1").Return(nil, false)
+	client.EXPECT().SetWithTTL("gocache_tag_tag1", []byte("\x00\x01+\x06my-key"), int64(0), 720*time.Hour).Return(true)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return(nil, false)
+	client.EXPECT().SetWithTTL("gocache_key_tags_my-key", []byte("\x00\x01+\x04tag1"), int64(0), 720*time.Hour).Return(true)
+	client.EXPECT().Wait().Times(3)
 
 	store := NewRistretto(client)
 
@@ -211,8 +219,9 @@ func TestRistrettoSetWithTagsWhenAlreadyInserted(t *testing.T) {
 
 	client := NewMockRistrettoClientInterface(ctrl)
 	client.EXPECT().SetWithTTL(cacheKey, cacheValue, int64(0), 0*time.Second).Return(true)
-	client.EXPECT().Get("gocache_tag_tag1").Return([]byte("my-key,a-second-key"), true)
-	client.EXPECT().SetWithTTL("gocache_tag_tag1", []byte("my-key,a-second-key"), int64(0), 720*time.Hour).Return(true)
+	client.EXPECT().Wait()
+	client.EXPECT().Get("gocache_tag_tag1").Return([]byte("\x00\x01+\x06my-key+\x0ca-second-key"), true)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return([]byte("\x00\x01+\x04tag1"), true)
 
 	store := NewRistretto(client)
 
@@ -223,6 +232,30 @@ func TestRistrettoSetWithTagsWhenAlreadyInserted(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestRistrettoSetWithTagsWhenTagIndexError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+	cacheValue := []byte("my-cache-value")
+
+	client := NewMockRistrettoClientInterface(ctrl)
+	client.EXPECT().SetWithTTL(cacheKey, cacheValue, int64(0), 0*time.Second).Return(true)
+	client.EXPECT().Wait()
+	client.EXPECT().Get("gocache_tag_tag1").Return(nil, false)
+	client.EXPECT().SetWithTTL("gocache_tag_tag1", []byte("\x00\x01+\x06my-key"), int64(0), 720*time.Hour).Return(false)
+
+	store := NewRistretto(client)
+
+	// When
+	err := store.Set(ctx, cacheKey, cacheValue, lib_store.WithTags([]string{"tag1"}))
+
+	// Then
+	assert.EqualError(t, err, "An error has occurred while setting tag index entry 'gocache_tag_tag1'")
+}
+
 func TestRistrettoDelete(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -233,14 +266,161 @@ func TestRistrettoDelete(t *testing.T) {
 
 	client := NewMockRistrettoClientInterface(ctrl)
 	client.EXPECT().Del(cacheKey)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return([]byte("\x00\x01+\x04tag1"), true)
+	client.EXPECT().Get("gocache_tag_tag1").Return([]byte("\x00\x01+\x06my-key"), true)
+	client.EXPECT().SetWithTTL("gocache_tag_tag1", []byte("\x00\x01+\x06my-key-\x06my-key"), int64(0), 720*time.Hour).Return(true)
+	client.EXPECT().Wait()
+	client.EXPECT().Del("gocache_key_tags_my-key")
 
 	store := NewRistretto(client)
 
 	// When
 	err := store.Delete(ctx, cacheKey)
 
+	// Then the key is dissociated from its tags
+	assert.Nil(t, err)
+}
+
+func TestRistrettoIncrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRistrettoClientInterface(ctrl)
+	client.EXPECT().Get("my-counter").Return(int64(5), true)
+	client.EXPECT().GetTTL("my-counter").Return(3*time.Second, true)
+	client.EXPECT().SetWithTTL("my-counter", int64(7), int64(0), 3*time.Second).Return(true)
+	client.EXPECT().Wait()
+
+	store := NewRistretto(client, lib_store.WithExpiration(10*time.Second))
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), value)
+}
+
+func TestRistrettoDecrementWhenCounterIsMissing(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRistrettoClientInterface(ctrl)
+	client.EXPECT().Get("my-counter").Return(nil, false)
+	client.EXPECT().SetWithTTL("my-counter", int64(-2), int64(0), 10*time.Second).Return(true)
+	client.EXPECT().Wait()
+
+	store := NewRistretto(client, lib_store.WithExpiration(10*time.Second))
+
+	// When
+	value, err := store.Decrement(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-2), value)
+}
+
+func TestRistrettoIncrementWhenValueIsNotACounter(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRistrettoClientInterface(ctrl)
+	client.EXPECT().Get("my-key").Return(struct{}{}, true)
+
+	store := NewRistretto(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-key", 2)
+
+	// Then
+	assert.EqualError(t, err, "value of type struct {} is not a counter")
+	assert.Equal(t, int64(0), value)
+}
+
+func TestRistrettoSetIfNotExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRistrettoClientInterface(ctrl)
+	client.EXPECT().Get("my-key").Return(nil, false)
+	client.EXPECT().SetWithTTL("my-key", "my-value", int64(0), 5*time.Second).Return(true)
+	client.EXPECT().Wait()
+
+	store := NewRistretto(client)
+
+	// When
+	set, err := store.SetIfNotExists(ctx, "my-key", "my-value", lib_store.WithExpiration(5*time.Second))
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, set)
+}
+
+func TestRistrettoSetIfNotExistsWhenKeyExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRistrettoClientInterface(ctrl)
+	client.EXPECT().Get("my-key").Return("other-value", true)
+
+	store := NewRistretto(client)
+
+	// When
+	set, err := store.SetIfNotExists(ctx, "my-key", "my-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, set)
+}
+
+func TestRistrettoCompareAndSwap(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRistrettoClientInterface(ctrl)
+	client.EXPECT().Get("my-key").Return([]byte("old-value"), true)
+	client.EXPECT().SetWithTTL("my-key", []byte("new-value"), int64(0), 0*time.Second).Return(true)
+	client.EXPECT().Wait()
+
+	store := NewRistretto(client)
+
+	// When
+	swapped, err := store.CompareAndSwap(ctx, "my-key", []byte("old-value"), []byte("new-value"))
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, swapped)
+}
+
+func TestRistrettoCompareAndDelete(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRistrettoClientInterface(ctrl)
+	client.EXPECT().Get("my-key").Return("other-value", true)
+
+	store := NewRistretto(client)
+
+	// When
+	deleted, err := store.CompareAndDelete(ctx, "my-key", "old-value")
+
 	// Then
 	assert.Nil(t, err)
+	assert.False(t, deleted)
 }
 
 func TestRistrettoInvalidate(t *testing.T) {
@@ -249,12 +429,18 @@ func TestRistrettoInvalidate(t *testing.T) {
 
 	ctx := context.Background()
 
-	cacheKeys := []byte("a23fdf987h2svc23,jHG2372x38hf74")
+	cacheKeys := []byte("\x00\x01+\x10a23fdf987h2svc23+\x0ejHG2372x38hf74")
 
 	client := NewMockRistrettoClientInterface(ctrl)
-	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys, true)
+	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys, true).Times(2)
 	client.EXPECT().Del("a23fdf987h2svc23")
 	client.EXPECT().Del("jHG2372x38hf74")
+	client.EXPECT().SetWithTTL("gocache_tag_tag1", []byte("\x00\x01+\x10a23fdf987h2svc23+\x0ejHG2372x38hf74-\x10a23fdf987h2svc23-\x0ejHG2372x38hf74"), int64(0), 720*time.Hour).Return(true)
+	client.EXPECT().Wait()
+	client.EXPECT().Get("gocache_key_tags_a23fdf987h2svc23").Return(nil, false)
+	client.EXPECT().Get("gocache_key_tags_jHG2372x38hf74").Return(nil, false)
+	client.EXPECT().Del("gocache_key_tags_a23fdf987h2svc23")
+	client.EXPECT().Del("gocache_key_tags_jHG2372x38hf74")
 
 	store := NewRistretto(client)
 
@@ -285,6 +471,76 @@ func TestRistrettoInvalidateWhenError(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestRistrettoInvalidateWithPrefixWhenNotSupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRistrettoClientInterface(ctrl)
+
+	store := NewRistretto(client)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidatePrefix("tenant:17:"))
+
+	// Then
+	assert.True(t, errors.Is(err, &lib_store.NotSupported{}))
+}
+
+func TestRistrettoKeysForTag(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRistrettoClientInterface(ctrl)
+	client.EXPECT().Get("gocache_tag_tag1").Return([]byte("\x00\x01+\x0ca-second-key+\x06my-key"), true)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return(nil, false)
+
+	store := NewRistretto(client)
+
+	// When
+	keys, keysErr := store.KeysForTag(ctx, "tag1")
+	tags, tagsErr := store.TagsForKey(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, keysErr)
+	assert.Equal(t, []string{"a-second-key", "my-key"}, keys)
+	assert.Nil(t, tagsErr)
+	assert.Empty(t, tags)
+}
+
+func TestRistrettoPruneTags(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRistrettoClientInterface(ctrl)
+	client.EXPECT().SetWithTTL("my-key", "my-value", int64(0), 0*time.Second).Return(true)
+	client.EXPECT().Get("gocache_tag_tag1").Return(nil, false)
+	client.EXPECT().SetWithTTL("gocache_tag_tag1", []byte("\x00\x01+\x06my-key"), int64(0), time.Hour).Return(true)
+	client.EXPECT().Get("gocache_key_tags_my-key").Return(nil, false)
+	client.EXPECT().SetWithTTL("gocache_key_tags_my-key", []byte("\x00\x01+\x04tag1"), int64(0), time.Hour).Return(true)
+	client.EXPECT().Wait().Times(3)
+
+	store := NewRistretto(client, lib_store.WithTagIndex(lib_store.WithTagIndexTTL(time.Hour)))
+	assert.Nil(t, store.Set(ctx, "my-key", "my-value", lib_store.WithTags([]string{"tag1"})))
+
+	// When the key has been evicted
+	client.EXPECT().Get("gocache_tag_tag1").Return([]byte("\x00\x01+\x06my-key"), true).Times(2)
+	client.EXPECT().Get("my-key").Return(nil, false)
+	client.EXPECT().SetWithTTL("gocache_tag_tag1", []byte("\x00\x01"), int64(0), time.Hour).Return(true)
+	client.EXPECT().Wait()
+	client.EXPECT().Del("gocache_key_tags_my-key")
+
+	err := store.PruneTags(ctx)
+
+	// Then
+	assert.Nil(t, err)
+}
+
 func TestRistrettoClear(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -314,3 +570,63 @@ func TestRistrettoGetType(t *testing.T) {
 	// When - Then
 	assert.Equal(t, RistrettoType, store.GetType())
 }
+
+func TestRistrettoSetAndCompareAndSwapConcurrency(t *testing.T) {
+	ctx := context.Background()
+
+	client, err := ristretto.NewCache(&ristretto.Config{
+		NumCounters: 1000,
+		MaxCost:     1000,
+		BufferItems: 64,
+	})
+	assert.Nil(t, err)
+	store := NewRistretto(client, lib_store.WithCost(1))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 200; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+
+			err := store.Set(ctx, "my-key", i)
+			assert.Nil(t, err, err)
+		}(i)
+
+		go func(i int) {
+			defer wg.Done()
+
+			value, err := store.Get(ctx, "my-key")
+			if err != nil {
+				return
+			}
+
+			_, err = store.CompareAndSwap(ctx, "my-key", value, -i)
+			assert.Nil(t, err, err)
+		}(i)
+	}
+	wg.Wait()
+
+	// Once the writers are done, a value which has just been set is swapped
+	err = store.Set(ctx, "my-key", 1)
+	assert.Nil(t, err)
+
+	swapped, err := store.CompareAndSwap(ctx, "my-key", 1, 2)
+	assert.Nil(t, err)
+	assert.True(t, swapped)
+
+	value, err := store.Get(ctx, "my-key")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, value)
+}
+
+func TestRistrettoGetDefaultExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	client := NewMockRistrettoClientInterface(ctrl)
+
+	store := NewRistretto(client, lib_store.WithExpiration(6*time.Second))
+
+	// When - Then
+	assert.Equal(t, 6*time.Second, store.GetDefaultExpiration())
+}
@@ -3,6 +3,7 @@ package rueidis
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	lib_store "github.com/eko/gocache/lib/v4/store"
@@ -17,12 +18,34 @@ const (
 	RueidisTagPattern = "gocache_tag_%s"
 
 	defaultClientSideCacheExpiration = 10 * time.Second
+
+	// scanCount is the number of keys each SCAN call is hinted to return
+	scanCount = 100
+
+	// compareAndSwapScript sets the new value (ARGV[2]) with an optional expiration
+	// in milliseconds (ARGV[3]) only if the current value equals ARGV[1]
+	compareAndSwapScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then
+	if tonumber(ARGV[3]) > 0 then
+		redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+	else
+		redis.call("SET", KEYS[1], ARGV[2])
+	end
+	return 1
+end
+return 0`
+
+	// compareAndDeleteScript removes the key only if its current value equals ARGV[1]
+	compareAndDeleteScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`
 )
 
 // RueidisStore is a store for Redis
 type RueidisStore struct {
 	client  rueidis.Client
 	options *lib_store.Options
+	tagTTL  time.Duration
 }
 
 // NewRueidis creates a new store to Redis instance(s)
@@ -37,6 +60,7 @@ func NewRueidis(client rueidis.Client, options ...lib_store.Option) *RueidisStor
 	return &RueidisStore{
 		client:  client,
 		options: appliedOptions,
+		tagTTL:  lib_store.ApplyTagIndexOptions(appliedOptions.TagIndex...).TTL,
 	}
 }
 
@@ -66,33 +90,316 @@ func (s *RueidisStore) GetWithTTL(ctx context.Context, key any) (any, time.Durat
 func (s *RueidisStore) Set(ctx context.Context, key any, value any, options ...lib_store.Option) error {
 	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
 	ttl := int64(opts.Expiration.Seconds())
-	cmd := s.client.B().Set().Key(key.(string)).Value(value.(string)).ExSeconds(ttl).Build()
+	cmd := s.client.B().Set().Key(key.(string)).Value(toString(value)).ExSeconds(ttl).Build()
 	err := s.client.Do(ctx, cmd).Error()
 	if err != nil {
 		return err
 	}
 
 	if tags := opts.Tags; len(tags) > 0 {
-		s.setTags(ctx, key, tags)
+		s.setTags(ctx, tags, key.(string))
 	}
 
 	return nil
 }
 
-func (s *RueidisStore) setTags(ctx context.Context, key any, tags []string) {
-	ttl := 720 * time.Hour
+// setTags associates the given keys to the tags in a single round trip
+func (s *RueidisStore) setTags(ctx context.Context, tags []string, keys ...string) {
+	cmds := make(rueidis.Commands, 0, 2*(len(tags)+len(keys)))
 	for _, tag := range tags {
-		tagKey := fmt.Sprintf(RueidisTagPattern, tag)
-		s.client.DoMulti(ctx,
-			s.client.B().Sadd().Key(tagKey).Member(key.(string)).Build(),
-			s.client.B().Expire().Key(tagKey).Seconds(int64(ttl.Seconds())).Build(),
-		)
+		cmds = append(cmds, s.addToSet(fmt.Sprintf(RueidisTagPattern, tag), keys...)...)
+	}
+
+	for _, key := range keys {
+		cmds = append(cmds, s.addToSet(fmt.Sprintf(lib_store.KeyTagsPattern, key), tags...)...)
 	}
+
+	s.client.DoMulti(ctx, cmds...)
+}
+
+// addToSet returns the commands adding the given members to a set and extending its expiration
+func (s *RueidisStore) addToSet(setKey string, members ...string) rueidis.Commands {
+	cmds := rueidis.Commands{s.client.B().Sadd().Key(setKey).Member(members...).Build()}
+	if s.tagTTL > 0 {
+		cmds = append(cmds, s.client.B().Expire().Key(setKey).Seconds(int64(s.tagTTL.Seconds())).Build())
+	}
+
+	return cmds
 }
 
-// Delete removes data from Redis for given key identifier
+// removeTags dissociates the given deleted keys from their tags in two round trips:
+// the tags of the keys are read, then the keys are removed from their tag sets, each
+// command being sent on its own as the keys may belong to different hash slots
+func (s *RueidisStore) removeTags(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	reads := make(rueidis.Commands, 0, len(keys))
+	for _, key := range keys {
+		reads = append(reads, s.client.B().Smembers().Key(fmt.Sprintf(lib_store.KeyTagsPattern, key)).Build())
+	}
+
+	var writes rueidis.Commands
+	for i, res := range s.client.DoMulti(ctx, reads...) {
+		tags, err := res.AsStrSlice()
+		if err != nil {
+			return err
+		}
+
+		for _, tag := range tags {
+			writes = append(writes, s.client.B().Srem().Key(fmt.Sprintf(RueidisTagPattern, tag)).Member(keys[i]).Build())
+		}
+		writes = append(writes, s.client.B().Del().Key(fmt.Sprintf(lib_store.KeyTagsPattern, keys[i])).Build())
+	}
+
+	for _, res := range s.client.DoMulti(ctx, writes...) {
+		if err := res.Error(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete removes data from Redis for given key identifier, and dissociates it from its tags
 func (s *RueidisStore) Delete(ctx context.Context, key any) error {
-	return s.client.Do(ctx, s.client.B().Del().Key(key.(string)).Build()).Error()
+	if err := s.client.Do(ctx, s.client.B().Del().Key(key.(string)).Build()).Error(); err != nil {
+		return err
+	}
+
+	return s.removeTags(ctx, key.(string))
+}
+
+// KeysForTag returns the keys associated to the given tag
+func (s *RueidisStore) KeysForTag(ctx context.Context, tag string) ([]string, error) {
+	return s.client.Do(ctx, s.client.B().Smembers().Key(fmt.Sprintf(RueidisTagPattern, tag)).Build()).AsStrSlice()
+}
+
+// TagsForKey returns the tags associated to the given key
+func (s *RueidisStore) TagsForKey(ctx context.Context, key any) ([]string, error) {
+	return s.client.Do(ctx, s.client.B().Smembers().Key(fmt.Sprintf(lib_store.KeyTagsPattern, key.(string))).Build()).AsStrSlice()
+}
+
+// PruneTags dissociates the keys which do not exist anymore, such as the expired or
+// evicted ones, from their tags. Tag sets are found on each node using SCAN with the
+// TYPE option, which requires Redis 6.0 or later.
+func (s *RueidisStore) PruneTags(ctx context.Context) error {
+	for _, node := range s.client.Nodes() {
+		var cursor uint64
+		for {
+			cmd := node.B().Scan().Cursor(cursor).Match(fmt.Sprintf(RueidisTagPattern, "*")).Count(scanCount).Type("set").Build()
+			entry, err := node.Do(ctx, cmd).AsScanEntry()
+			if err != nil {
+				return err
+			}
+
+			for _, tagKey := range entry.Elements {
+				if err := s.pruneTag(ctx, tagKey); err != nil {
+					return err
+				}
+			}
+
+			if entry.Cursor == 0 {
+				break
+			}
+			cursor = entry.Cursor
+		}
+	}
+
+	return nil
+}
+
+func (s *RueidisStore) pruneTag(ctx context.Context, tagKey string) error {
+	cacheKeys, err := s.client.Do(ctx, s.client.B().Smembers().Key(tagKey).Build()).AsStrSlice()
+	if err != nil || len(cacheKeys) == 0 {
+		return err
+	}
+
+	reads := make(rueidis.Commands, 0, len(cacheKeys))
+	for _, cacheKey := range cacheKeys {
+		reads = append(reads, s.client.B().Exists().Key(cacheKey).Build())
+	}
+
+	var missing []string
+	for i, res := range s.client.DoMulti(ctx, reads...) {
+		exists, err := res.AsInt64()
+		if err != nil {
+			return err
+		}
+		if exists == 0 {
+			missing = append(missing, cacheKeys[i])
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	// The missing keys are removed from their other tags when these are pruned
+	cmds := make(rueidis.Commands, 0, len(missing)+1)
+	cmds = append(cmds, s.client.B().Srem().Key(tagKey).Member(missing...).Build())
+	for _, cacheKey := range missing {
+		cmds = append(cmds, s.client.B().Del().Key(fmt.Sprintf(lib_store.KeyTagsPattern, cacheKey)).Build())
+	}
+
+	for _, res := range s.client.DoMulti(ctx, cmds...) {
+		if err := res.Error(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetMany returns data stored from given keys, grouped into MGET commands
+// consulting the client-side cache, keys that cannot be found are absent from the returned map
+func (s *RueidisStore) GetMany(ctx context.Context, keys []any) (map[any]any, error) {
+	cacheKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		cacheKeys = append(cacheKeys, key.(string))
+	}
+
+	messages, err := rueidis.MGetCache(s.client, ctx, s.options.ClientSideCacheExpiration, cacheKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make(map[any]any, len(messages))
+	for _, key := range keys {
+		message, ok := messages[key.(string)]
+		if !ok {
+			continue
+		}
+
+		str, err := message.ToString()
+		if err != nil {
+			continue
+		}
+		objects[key] = str
+	}
+
+	return objects, nil
+}
+
+// SetMany defines data in Redis for given key identifiers in a single round trip
+func (s *RueidisStore) SetMany(ctx context.Context, items map[any]any, options ...lib_store.Option) error {
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	ttl := int64(opts.Expiration.Seconds())
+
+	cmds := make(rueidis.Commands, 0, len(items))
+	for key, value := range items {
+		cmds = append(cmds, s.client.B().Set().Key(key.(string)).Value(toString(value)).ExSeconds(ttl).Build())
+	}
+
+	for _, res := range s.client.DoMulti(ctx, cmds...) {
+		if err := res.Error(); err != nil {
+			return err
+		}
+	}
+
+	if tags := opts.Tags; len(tags) > 0 {
+		keys := make([]string, 0, len(items))
+		for key := range items {
+			keys = append(keys, key.(string))
+		}
+		s.setTags(ctx, tags, keys...)
+	}
+
+	return nil
+}
+
+// DeleteMany removes data from Redis for given key identifiers, sending one DEL command
+// per key in a single round trip as the keys may belong to different hash slots
+func (s *RueidisStore) DeleteMany(ctx context.Context, keys []any) error {
+	cacheKeys := make([]string, 0, len(keys))
+	cmds := make(rueidis.Commands, 0, len(keys))
+	for _, key := range keys {
+		cacheKeys = append(cacheKeys, key.(string))
+		cmds = append(cmds, s.client.B().Del().Key(key.(string)).Build())
+	}
+
+	for _, res := range s.client.DoMulti(ctx, cmds...) {
+		if err := res.Error(); err != nil {
+			return err
+		}
+	}
+
+	return s.removeTags(ctx, cacheKeys...)
+}
+
+// Increment atomically adds delta to the counter stored at the given key using INCRBY
+func (s *RueidisStore) Increment(ctx context.Context, key any, delta int64) (int64, error) {
+	return s.client.Do(ctx, s.client.B().Incrby().Key(key.(string)).Increment(delta).Build()).AsInt64()
+}
+
+// Decrement atomically subtracts delta from the counter stored at the given key using DECRBY
+func (s *RueidisStore) Decrement(ctx context.Context, key any, delta int64) (int64, error) {
+	return s.client.Do(ctx, s.client.B().Decrby().Key(key.(string)).Decrement(delta).Build()).AsInt64()
+}
+
+// SetIfNotExists defines data in Redis only if the given key does not exist yet using SET NX
+func (s *RueidisStore) SetIfNotExists(ctx context.Context, key any, value any, options ...lib_store.Option) (bool, error) {
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+
+	var err error
+	cmd := s.client.B().Set().Key(key.(string)).Value(toString(value)).Nx()
+	if ttl := int64(opts.Expiration.Seconds()); ttl > 0 {
+		err = s.client.Do(ctx, cmd.ExSeconds(ttl).Build()).Error()
+	} else {
+		err = s.client.Do(ctx, cmd.Build()).Error()
+	}
+	if rueidis.IsRedisNil(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if tags := opts.Tags; len(tags) > 0 {
+		s.setTags(ctx, tags, key.(string))
+	}
+
+	return true, nil
+}
+
+// CompareAndSwap replaces data in Redis only if the current value equals the old one
+func (s *RueidisStore) CompareAndSwap(ctx context.Context, key any, oldValue any, newValue any, options ...lib_store.Option) (bool, error) {
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+
+	cmd := s.client.B().Eval().Script(compareAndSwapScript).Numkeys(1).Key(key.(string)).
+		Arg(toString(oldValue), toString(newValue), strconv.FormatInt(opts.Expiration.Milliseconds(), 10)).Build()
+	swapped, err := s.client.Do(ctx, cmd).AsInt64()
+	if err != nil || swapped == 0 {
+		return false, err
+	}
+
+	if tags := opts.Tags; len(tags) > 0 {
+		s.setTags(ctx, tags, key.(string))
+	}
+
+	return true, nil
+}
+
+// CompareAndDelete removes data from Redis only if the current value equals the old one
+func (s *RueidisStore) CompareAndDelete(ctx context.Context, key any, oldValue any) (bool, error) {
+	cmd := s.client.B().Eval().Script(compareAndDeleteScript).Numkeys(1).Key(key.(string)).Arg(toString(oldValue)).Build()
+	deleted, err := s.client.Do(ctx, cmd).AsInt64()
+	if err != nil || deleted == 0 {
+		return false, err
+	}
+
+	return true, s.removeTags(ctx, key.(string))
+}
+
+// toString returns the string of a value given as string or bytes, such as the
+// markers and metadata written by the caches
+func toString(value any) string {
+	if b, ok := value.([]byte); ok {
+		return string(b)
+	}
+
+	return value.(string)
 }
 
 // Invalidate invalidates some cache data in Redis for given options
@@ -105,25 +412,87 @@ func (s *RueidisStore) Invalidate(ctx context.Context, options ...lib_store.Inva
 
 			cacheKeys, err := s.client.Do(ctx, s.client.B().Smembers().Key(tagKey).Build()).AsStrSlice()
 			if err != nil {
-				continue
+				return err
 			}
 
+			// Keys are deleted one by one as they may belong to different hash slots
+			cmds := make(rueidis.Commands, 0, len(cacheKeys)+1)
 			for _, cacheKey := range cacheKeys {
-				s.Delete(ctx, cacheKey)
+				cmds = append(cmds, s.client.B().Del().Key(cacheKey).Build())
 			}
+			cmds = append(cmds, s.client.B().Del().Key(tagKey).Build())
 
-			s.Delete(ctx, tagKey)
+			for _, res := range s.client.DoMulti(ctx, cmds...) {
+				if err := res.Error(); err != nil {
+					return err
+				}
+			}
+
+			if err := s.removeTags(ctx, cacheKeys...); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, pattern := range opts.KeyPatterns() {
+		for _, node := range s.client.Nodes() {
+			if err := s.invalidatePattern(ctx, node, pattern); err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
+// invalidatePattern deletes the keys of the given node matching the pattern, which are
+// found using SCAN and removed using UNLINK so Redis is not blocked, and dissociates
+// them from their tags
+func (s *RueidisStore) invalidatePattern(ctx context.Context, node rueidis.Client, pattern string) error {
+	var cursor uint64
+	for {
+		entry, err := node.Do(ctx, node.B().Scan().Cursor(cursor).Match(pattern).Count(scanCount).Build()).AsScanEntry()
+		if err != nil {
+			return err
+		}
+
+		if len(entry.Elements) > 0 {
+			// Keys are unlinked one by one as they may belong to different hash slots
+			cmds := make(rueidis.Commands, 0, len(entry.Elements))
+			for _, cacheKey := range entry.Elements {
+				cmds = append(cmds, s.client.B().Unlink().Key(cacheKey).Build())
+			}
+
+			for _, res := range s.client.DoMulti(ctx, cmds...) {
+				if err := res.Error(); err != nil {
+					return err
+				}
+			}
+
+			if err := s.removeTags(ctx, entry.Elements...); err != nil {
+				return err
+			}
+		}
+
+		if entry.Cursor == 0 {
+			break
+		}
+		cursor = entry.Cursor
+	}
+
+	return nil
+}
+
 // GetType returns the store type
 func (s *RueidisStore) GetType() string {
 	return RueidisType
 }
 
+// GetDefaultExpiration returns the expiration applied to the values written without one
+func (s *RueidisStore) GetDefaultExpiration() time.Duration {
+	return s.options.Expiration
+}
+
 // Clear resets all data in the store
 func (s *RueidisStore) Clear(ctx context.Context) error {
 	return rueidiscompat.NewAdapter(s.client).FlushAll(ctx).Err()
@@ -91,6 +91,61 @@ func TestRueidisSet(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestRueidisSetWithBytes(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("SET", "my-key", "my-cache-value", "EX", "10")).Return(mock.Result(mock.RedisString("")))
+
+	store := NewRueidis(client, lib_store.WithExpiration(time.Second*10))
+
+	// When
+	err := store.Set(ctx, "my-key", []byte("my-cache-value"))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRueidisSetNegativeCachingMarker(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("SET", "gocache_not_found_my-key", "gocache_not_found", "EX", "30")).Return(mock.Result(mock.RedisString("OK")))
+
+	store := NewRueidis(client)
+
+	// When the loadable cache with negative caching writes its marker, given as bytes
+	err := store.Set(ctx, "gocache_not_found_my-key", []byte("gocache_not_found"), lib_store.WithExpiration(30*time.Second))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRueidisSetIfNotExistsWithBytes(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("SET", "my-key", "my-value", "NX", "EX", "10")).Return(mock.Result(mock.RedisString("OK")))
+
+	store := NewRueidis(client, lib_store.WithExpiration(time.Second*10))
+
+	// When
+	set, err := store.SetIfNotExists(ctx, "my-key", []byte("my-value"))
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, set)
+}
+
 func TestRueidisSetWhenNoOptionsGiven(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -126,9 +181,13 @@ func TestRedisSetWithTags(t *testing.T) {
 	client.EXPECT().DoMulti(ctx,
 		mock.Match("SADD", "gocache_tag_tag1", "my-key"),
 		mock.Match("EXPIRE", "gocache_tag_tag1", "2592000"),
+		mock.Match("SADD", "gocache_key_tags_my-key", "tag1"),
+		mock.Match("EXPIRE", "gocache_key_tags_my-key", "2592000"),
 	).Return([]rueidis.RedisResult{
 		mock.Result(mock.RedisString("")),
 		mock.Result(mock.RedisString("")),
+		mock.Result(mock.RedisString("")),
+		mock.Result(mock.RedisString("")),
 	})
 
 	store := NewRueidis(client, lib_store.WithExpiration(time.Second*10))
@@ -150,6 +209,10 @@ func TestRedisDelete(t *testing.T) {
 
 	client := mock.NewClient(ctrl)
 	client.EXPECT().Do(ctx, mock.Match("DEL", cacheKey)).Return(mock.Result(mock.RedisInt64(1)))
+	client.EXPECT().DoMulti(ctx, mock.Match("SMEMBERS", "gocache_key_tags_my-key")).
+		Return([]rueidis.RedisResult{mock.Result(mock.RedisArray())})
+	client.EXPECT().DoMulti(ctx, mock.Match("DEL", "gocache_key_tags_my-key")).
+		Return([]rueidis.RedisResult{mock.Result(mock.RedisInt64(0))})
 
 	store := NewRueidis(client)
 
@@ -160,6 +223,392 @@ func TestRedisDelete(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestRueidisDeleteWithTags(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("DEL", cacheKey)).Return(mock.Result(mock.RedisInt64(1)))
+	client.EXPECT().DoMulti(ctx, mock.Match("SMEMBERS", "gocache_key_tags_my-key")).
+		Return([]rueidis.RedisResult{mock.Result(mock.RedisArray(mock.RedisString("tag1"), mock.RedisString("tag2")))})
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("SREM", "gocache_tag_tag1", "my-key"),
+		mock.Match("SREM", "gocache_tag_tag2", "my-key"),
+		mock.Match("DEL", "gocache_key_tags_my-key"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisInt64(1)),
+		mock.Result(mock.RedisInt64(1)),
+		mock.Result(mock.RedisInt64(1)),
+	})
+
+	store := NewRueidis(client)
+
+	// When
+	err := store.Delete(ctx, cacheKey)
+
+	// Then the key is dissociated from its tags
+	assert.Nil(t, err)
+}
+
+func TestRueidisKeysForTag(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("SMEMBERS", "gocache_tag_tag1")).
+		Return(mock.Result(mock.RedisArray(mock.RedisString("my-key"), mock.RedisString("a-second-key"))))
+	client.EXPECT().Do(ctx, mock.Match("SMEMBERS", "gocache_key_tags_my-key")).
+		Return(mock.Result(mock.RedisArray(mock.RedisString("tag1"), mock.RedisString("tag2"))))
+
+	store := NewRueidis(client)
+
+	// When
+	keys, keysErr := store.KeysForTag(ctx, "tag1")
+	tags, tagsErr := store.TagsForKey(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, keysErr)
+	assert.Equal(t, []string{"my-key", "a-second-key"}, keys)
+	assert.Nil(t, tagsErr)
+	assert.Equal(t, []string{"tag1", "tag2"}, tags)
+}
+
+func TestRueidisPruneTags(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Nodes().Return(map[string]rueidis.Client{"node": client})
+	client.EXPECT().Do(ctx, mock.Match("SCAN", "0", "MATCH", "gocache_tag_*", "COUNT", "100", "TYPE", "set")).
+		Return(mock.Result(mock.RedisArray(mock.RedisString("0"), mock.RedisArray(mock.RedisString("gocache_tag_tag1")))))
+	client.EXPECT().Do(ctx, mock.Match("SMEMBERS", "gocache_tag_tag1")).
+		Return(mock.Result(mock.RedisArray(mock.RedisString("my-key"), mock.RedisString("a-second-key"))))
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("EXISTS", "my-key"),
+		mock.Match("EXISTS", "a-second-key"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisInt64(0)),
+		mock.Result(mock.RedisInt64(1)),
+	})
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("SREM", "gocache_tag_tag1", "my-key"),
+		mock.Match("DEL", "gocache_key_tags_my-key"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisInt64(1)),
+		mock.Result(mock.RedisInt64(1)),
+	})
+
+	store := NewRueidis(client)
+
+	// When
+	err := store.PruneTags(ctx)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRueidisInvalidateWithPrefix(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Nodes().Return(map[string]rueidis.Client{"node": client})
+	client.EXPECT().Do(ctx, mock.Match("SCAN", "0", "MATCH", "tenant:17:*", "COUNT", "100")).
+		Return(mock.Result(mock.RedisArray(mock.RedisString("0"), mock.RedisArray(mock.RedisString("tenant:17:key1"), mock.RedisString("tenant:17:key2")))))
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("UNLINK", "tenant:17:key1"),
+		mock.Match("UNLINK", "tenant:17:key2"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisInt64(1)),
+		mock.Result(mock.RedisInt64(1)),
+	})
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("SMEMBERS", "gocache_key_tags_tenant:17:key1"),
+		mock.Match("SMEMBERS", "gocache_key_tags_tenant:17:key2"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisArray(mock.RedisString("tag1"))),
+		mock.Result(mock.RedisArray()),
+	})
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("SREM", "gocache_tag_tag1", "tenant:17:key1"),
+		mock.Match("DEL", "gocache_key_tags_tenant:17:key1"),
+		mock.Match("DEL", "gocache_key_tags_tenant:17:key2"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisInt64(1)),
+		mock.Result(mock.RedisInt64(1)),
+		mock.Result(mock.RedisInt64(0)),
+	})
+
+	store := NewRueidis(client)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidatePrefix("tenant:17:"))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRueidisGetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().DoCache(ctx, mock.Match("MGET", "my-key", "my-other-key"), defaultClientSideCacheExpiration).
+		Return(mock.Result(mock.RedisArray(mock.RedisString("my-value"), mock.RedisNil())))
+
+	store := NewRueidis(client)
+
+	// When
+	values, err := store.GetMany(ctx, []any{"my-key", "my-other-key"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"my-key": "my-value"}, values)
+}
+
+func TestRueidisSetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().DoMulti(ctx, mock.Match("SET", "my-key", "my-value", "EX", "10")).
+		Return([]rueidis.RedisResult{mock.Result(mock.RedisString("OK"))})
+
+	store := NewRueidis(client, lib_store.WithExpiration(time.Second*10))
+
+	// When
+	err := store.SetMany(ctx, map[any]any{"my-key": "my-value"})
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRueidisSetManyWithBytes(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().DoMulti(ctx, mock.Match("SET", "gocache_tags_my-key", `["tag1"]`, "EX", "10")).
+		Return([]rueidis.RedisResult{mock.Result(mock.RedisString("OK"))})
+
+	store := NewRueidis(client, lib_store.WithExpiration(time.Second*10))
+
+	// When the chain cache with tag propagation writes the tags of a value, given as bytes
+	err := store.SetMany(ctx, map[any]any{"gocache_tags_my-key": []byte(`["tag1"]`)})
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRueidisDeleteMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("DEL", "my-key"),
+		mock.Match("DEL", "my-other-key"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisInt64(1)),
+		mock.Result(mock.RedisInt64(1)),
+	})
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("SMEMBERS", "gocache_key_tags_my-key"),
+		mock.Match("SMEMBERS", "gocache_key_tags_my-other-key"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisArray()),
+		mock.Result(mock.RedisArray()),
+	})
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("DEL", "gocache_key_tags_my-key"),
+		mock.Match("DEL", "gocache_key_tags_my-other-key"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisInt64(0)),
+		mock.Result(mock.RedisInt64(0)),
+	})
+
+	store := NewRueidis(client)
+
+	// When
+	err := store.DeleteMany(ctx, []any{"my-key", "my-other-key"})
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRueidisDeleteManyWhenKeysInDifferentSlots(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// The slot check makes the client panic as a cluster client on multi-slot commands
+	client := mock.NewClient(ctrl, mock.WithSlotCheck())
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("DEL", "{user1}:key"),
+		mock.Match("DEL", "{user2}:key"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisInt64(1)),
+		mock.Result(mock.RedisInt64(1)),
+	})
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("SMEMBERS", "gocache_key_tags_{user1}:key"),
+		mock.Match("SMEMBERS", "gocache_key_tags_{user2}:key"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisArray()),
+		mock.Result(mock.RedisArray()),
+	})
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("DEL", "gocache_key_tags_{user1}:key"),
+		mock.Match("DEL", "gocache_key_tags_{user2}:key"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisInt64(0)),
+		mock.Result(mock.RedisInt64(0)),
+	})
+
+	store := NewRueidis(client)
+
+	// When
+	err := store.DeleteMany(ctx, []any{"{user1}:key", "{user2}:key"})
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRueidisIncrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("INCRBY", "my-counter", "2")).Return(mock.Result(mock.RedisInt64(7)))
+
+	store := NewRueidis(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), value)
+}
+
+func TestRueidisDecrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("DECRBY", "my-counter", "2")).Return(mock.Result(mock.RedisInt64(-2)))
+
+	store := NewRueidis(client)
+
+	// When
+	value, err := store.Decrement(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-2), value)
+}
+
+func TestRueidisSetIfNotExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("SET", "my-key", "my-value", "NX", "EX", "10")).Return(mock.Result(mock.RedisString("OK")))
+
+	store := NewRueidis(client, lib_store.WithExpiration(time.Second*10))
+
+	// When
+	set, err := store.SetIfNotExists(ctx, "my-key", "my-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, set)
+}
+
+func TestRueidisSetIfNotExistsWhenKeyExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("SET", "my-key", "my-value", "NX")).Return(mock.Result(mock.RedisNil()))
+
+	store := NewRueidis(client)
+
+	// When
+	set, err := store.SetIfNotExists(ctx, "my-key", "my-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, set)
+}
+
+func TestRueidisCompareAndSwap(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("EVAL", compareAndSwapScript, "1", "my-key", "old-value", "new-value", "10000")).
+		Return(mock.Result(mock.RedisInt64(1)))
+
+	store := NewRueidis(client, lib_store.WithExpiration(time.Second*10))
+
+	// When
+	swapped, err := store.CompareAndSwap(ctx, "my-key", "old-value", "new-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, swapped)
+}
+
+func TestRueidisCompareAndDelete(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("EVAL", compareAndDeleteScript, "1", "my-key", "old-value")).
+		Return(mock.Result(mock.RedisInt64(0)))
+
+	store := NewRueidis(client)
+
+	// When
+	deleted, err := store.CompareAndDelete(ctx, "my-key", "old-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, deleted)
+}
+
 func TestRedisInvalidate(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -167,15 +616,44 @@ func TestRedisInvalidate(t *testing.T) {
 	ctx := context.Background()
 
 	client := mock.NewClient(ctrl)
-	client.EXPECT().Do(ctx, mock.Match("SMEMBERS", "gocache_tag_tag1")).Return(mock.Result(mock.RedisArray()))
-	client.EXPECT().Do(ctx, mock.Match("DEL", "gocache_tag_tag1")).Return(mock.Result(mock.RedisInt64(1)))
+	client.EXPECT().Do(ctx, mock.Match("SMEMBERS", "gocache_tag_tag1")).
+		Return(mock.Result(mock.RedisArray(mock.RedisString("my-key"), mock.RedisString("a-second-key"))))
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("DEL", "my-key"),
+		mock.Match("DEL", "a-second-key"),
+		mock.Match("DEL", "gocache_tag_tag1"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisInt64(1)),
+		mock.Result(mock.RedisInt64(1)),
+		mock.Result(mock.RedisInt64(1)),
+	})
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("SMEMBERS", "gocache_key_tags_my-key"),
+		mock.Match("SMEMBERS", "gocache_key_tags_a-second-key"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisArray(mock.RedisString("tag1"), mock.RedisString("tag2"))),
+		mock.Result(mock.RedisArray(mock.RedisString("tag1"))),
+	})
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("SREM", "gocache_tag_tag1", "my-key"),
+		mock.Match("SREM", "gocache_tag_tag2", "my-key"),
+		mock.Match("DEL", "gocache_key_tags_my-key"),
+		mock.Match("SREM", "gocache_tag_tag1", "a-second-key"),
+		mock.Match("DEL", "gocache_key_tags_a-second-key"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisInt64(0)),
+		mock.Result(mock.RedisInt64(1)),
+		mock.Result(mock.RedisInt64(1)),
+		mock.Result(mock.RedisInt64(0)),
+		mock.Result(mock.RedisInt64(1)),
+	})
 
 	store := NewRueidis(client)
 
 	// When
 	err := store.Invalidate(ctx, lib_store.WithInvalidateTags([]string{"tag1"}))
 
-	// Then
+	// Then the keys are deleted in bulk and dissociated from their other tags
 	assert.Nil(t, err)
 }
 
@@ -212,3 +690,15 @@ func TestRedisGetType(t *testing.T) {
 	// When - Then
 	assert.Equal(t, RueidisType, store.GetType())
 }
+
+func TestRueidisGetDefaultExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	client := mock.NewClient(ctrl)
+
+	store := NewRueidis(client, lib_store.WithExpiration(6*time.Second))
+
+	// When - Then
+	assert.Equal(t, 6*time.Second, store.GetDefaultExpiration())
+}